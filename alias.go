@@ -0,0 +1,47 @@
+package pflag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetAlias makes Parse replace the argument alias, like "-x" or "--old", by
+// the arguments of expansion, like "--extract" and "--verbose", e.g. to keep
+// accepting renamed or split options. A value attached to a long alias with
+// '=', as in "--old=value", is attached to the last argument of the
+// expansion. Only arguments which are flags are replaced, and not within
+// groups of shorthands, and the arguments of an expansion are not expanded
+// again. In VisitInOrder, the positions count the arguments of the
+// expansions rather than the aliases. A nil expansion removes the alias.
+func (f *FlagSet) SetAlias(alias string, expansion []string) error {
+	if len(alias) < 2 || alias[0] != '-' || alias == "--" || strings.Contains(alias, "=") {
+		return fmt.Errorf("alias %q is not a flag", alias)
+	}
+	if expansion == nil {
+		delete(f.aliases, alias)
+		return nil
+	}
+	if f.aliases == nil {
+		f.aliases = make(map[string][]string)
+	}
+	f.aliases[alias] = copyStrings(expansion)
+	return nil
+}
+
+// expandAlias returns the expansion of the argument s if it is an alias.
+func (f *FlagSet) expandAlias(s string) ([]string, bool) {
+	if len(f.aliases) == 0 {
+		return nil, false
+	}
+	if expansion, ok := f.aliases[s]; ok {
+		return expansion, true
+	}
+	if eq := strings.IndexByte(s, '='); eq > 2 && s[1] == '-' {
+		if expansion, ok := f.aliases[s[:eq]]; ok && len(expansion) > 0 {
+			expansion = copyStrings(expansion)
+			expansion[len(expansion)-1] += s[eq:]
+			return expansion, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,51 @@
+package pflag
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestSetAlias(t *testing.T) {
+	f := NewFlagSet("tar", ContinueOnError)
+	extract := f.Bool("extract", false, "")
+	verbose := f.BoolP("verbose", "v", false, "")
+	file := f.String("file", "", "")
+	name := f.String("name", "", "")
+	if err := f.SetAlias("-x", []string{"--extract", "--verbose"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.SetAlias("--archive", []string{"--file"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.SetAlias("--loop", []string{"--loop"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, alias := range []string{"x", "-", "--", "--a=b"} {
+		if err := f.SetAlias(alias, nil); err == nil {
+			t.Errorf("%q: expected an error", alias)
+		}
+	}
+
+	if err := f.Parse([]string{"-x", "--archive=a.tar", "--name", "-x", "pos"}); err != nil {
+		t.Fatal(err)
+	}
+	if !*extract || !*verbose || *file != "a.tar" || *name != "-x" {
+		t.Errorf("got extract %v, verbose %v, file %q and name %q", *extract, *verbose, *file, *name)
+	}
+	if !reflect.DeepEqual(f.Args(), []string{"pos"}) {
+		t.Errorf("got args %v", f.Args())
+	}
+	if err := f.Parse([]string{"--archive", "b.tar"}); err != nil || *file != "b.tar" {
+		t.Errorf("got file %q and error %v", *file, err)
+	}
+
+	f.SetOutput(ioutil.Discard)
+	if err := f.Parse([]string{"--loop"}); err == nil {
+		t.Error("expected an error for an alias expanding to itself, which is not expanded again")
+	}
+	f.SetAlias("-x", nil)
+	if err := f.Parse([]string{"-x"}); err == nil {
+		t.Error("expected an error for a removed alias")
+	}
+}
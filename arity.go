@@ -0,0 +1,43 @@
+package pflag
+
+// ExpectArgs makes Parse fail unless the number of remaining arguments, see
+// Args, is between min and max included, with a message like "expected at
+// most 2 arguments, got 3". A negative max means no maximum.
+func (f *FlagSet) ExpectArgs(min, max int) {
+	f.expectArgs = true
+	f.minArgs, f.maxArgs = min, max
+}
+
+// ExactArgs makes Parse fail unless there are exactly n remaining arguments,
+// see ExpectArgs.
+func (f *FlagSet) ExactArgs(n int) {
+	f.ExpectArgs(n, n)
+}
+
+// MinimumArgs makes Parse fail unless there are at least n remaining
+// arguments, see ExpectArgs.
+func (f *FlagSet) MinimumArgs(n int) {
+	f.ExpectArgs(n, -1)
+}
+
+// NoArgs makes Parse fail if there are remaining arguments, see ExpectArgs.
+func (f *FlagSet) NoArgs() {
+	f.ExpectArgs(0, 0)
+}
+
+// checkArgs checks the number of remaining arguments set by ExpectArgs.
+func (f *FlagSet) checkArgs() error {
+	if !f.expectArgs {
+		return nil
+	}
+	n := len(f.args)
+	switch {
+	case f.minArgs == f.maxArgs && n != f.minArgs:
+		return f.failf(f.msg(MsgArgCount), f.minArgs, n)
+	case n < f.minArgs:
+		return f.failf(f.msg(MsgTooFewArgs), f.minArgs, n)
+	case f.maxArgs >= 0 && n > f.maxArgs:
+		return f.failf(f.msg(MsgTooManyArgs), f.maxArgs, n)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package pflag
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestExpectArgs(t *testing.T) {
+	for _, test := range []struct {
+		expect func(f *FlagSet)
+		args   []string
+		err    string
+	}{
+		{func(f *FlagSet) { f.ExpectArgs(1, 2) }, []string{"a", "b"}, ""},
+		{func(f *FlagSet) { f.ExpectArgs(1, 2) }, []string{"a", "b", "c"}, "expected at most 2 arguments, got 3"},
+		{func(f *FlagSet) { f.ExpectArgs(1, 2) }, nil, "expected at least 1 arguments, got 0"},
+		{func(f *FlagSet) { f.ExactArgs(2) }, []string{"a", "--", "-b"}, ""},
+		{func(f *FlagSet) { f.ExactArgs(2) }, []string{"a"}, "expected 2 arguments, got 1"},
+		{func(f *FlagSet) { f.MinimumArgs(1) }, []string{"a", "b", "c"}, ""},
+		{func(f *FlagSet) { f.NoArgs() }, []string{"--verbose"}, ""},
+		{func(f *FlagSet) { f.NoArgs() }, []string{"a"}, "expected 0 arguments, got 1"},
+	} {
+		f := NewFlagSet("test", ContinueOnError)
+		f.SetOutput(ioutil.Discard)
+		f.Bool("verbose", false, "")
+		test.expect(f)
+		err := f.Parse(test.args)
+		if test.err == "" && err != nil || test.err != "" && (err == nil || err.Error() != test.err) {
+			t.Errorf("%q: got error %v, want %q", test.args, err, test.err)
+		}
+	}
+}
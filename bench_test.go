@@ -0,0 +1,83 @@
+package pflag
+
+import (
+	"fmt"
+	"testing"
+)
+
+// benchmarkArgs is a typical command line, mixing the forms of long and
+// short flags with positional arguments.
+var benchmarkArgs = []string{
+	"--name=value", "--count", "3", "-v", "-n5", "--verbose=false",
+	"arg1", "--enabled", "-xvn", "7", "arg2",
+}
+
+func newBenchmarkFlagSet() *FlagSet {
+	f := NewFlagSet("bench", ContinueOnError)
+	f.String("name", "", "")
+	f.Int("count", 0, "")
+	f.BoolP("verbose", "v", false, "")
+	f.IntP("num", "n", 0, "")
+	f.Bool("enabled", false, "")
+	f.BoolP("extra", "x", false, "")
+	return f
+}
+
+func BenchmarkParse(b *testing.B) {
+	f := newBenchmarkFlagSet()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		f.Reset()
+		if err := f.Parse(benchmarkArgs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestParseAllocs(t *testing.T) {
+	f := newBenchmarkFlagSet()
+	allocs := testing.AllocsPerRun(100, func() {
+		f.Reset()
+		f.Parse(benchmarkArgs)
+	})
+	// Only the slice returned by Args is allocated.
+	if allocs > 1 {
+		t.Errorf("Parse allocated %v times, want at most 1", allocs)
+	}
+}
+
+func BenchmarkDefine(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		f := NewFlagSet("bench", ContinueOnError)
+		f.String("name", "", "name of the `thing` to create")
+		f.Int("count", 1, "number of things")
+		f.Bool("verbose", false, "verbose output")
+		f.StringSlice("tags", nil, "tags of the thing")
+		f.StringArray("headers", nil, "headers to send")
+		f.IntSlice("ports", nil, "ports to listen on")
+	}
+}
+
+func BenchmarkVisitAll(b *testing.B) {
+	f := NewFlagSet("bench", ContinueOnError)
+	for i := 0; i < 500; i++ {
+		f.Int(fmt.Sprintf("flag-%d", i), 0, "")
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.VisitAll(func(*Flag) {})
+	}
+}
+
+func BenchmarkFlagsWithPrefix(b *testing.B) {
+	f := NewFlagSet("bench", ContinueOnError)
+	for i := 0; i < 5000; i++ {
+		f.Int(fmt.Sprintf("group%d-option%d", i%50, i), 0, "")
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.FlagsWithPrefix("group7-")
+	}
+}
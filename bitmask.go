@@ -0,0 +1,172 @@
+package pflag
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// -- bitmask Value, combining named bits
+type bitmaskValue struct {
+	value   *uint64
+	names   map[string]uint64
+	changed bool
+}
+
+func newBitmaskValue(val uint64, p *uint64, names map[string]uint64) *bitmaskValue {
+	*p = val
+	return &bitmaskValue{value: p, names: names}
+}
+
+// parse returns the bits named in s, separated by "|" or ",". Numbers are
+// accepted too, for the bits without a name.
+func (b *bitmaskValue) parse(s string) (uint64, error) {
+	var bits uint64
+	for _, name := range strings.FieldsFunc(s, func(r rune) bool { return r == '|' || r == ',' }) {
+		name = strings.TrimSpace(name)
+		if bit, ok := b.names[name]; ok {
+			bits |= bit
+			continue
+		}
+		bit, err := strconv.ParseUint(name, 0, 64)
+		if err != nil {
+			return 0, fmt.Errorf("unknown name %q, must be one of %s", name, strings.Join(b.sortedNames(), ", "))
+		}
+		bits |= bit
+	}
+	return bits, nil
+}
+
+func (b *bitmaskValue) Set(s string) error {
+	bits, err := b.parse(s)
+	if err != nil {
+		return err
+	}
+	if !b.changed {
+		*b.value = bits
+	} else {
+		*b.value |= bits
+	}
+	b.changed = true
+	return nil
+}
+
+func (b *bitmaskValue) Type() string {
+	return "bitmask"
+}
+
+// sortedNames returns the names of the bits in the order of their values.
+func (b *bitmaskValue) sortedNames() []string {
+	names := make([]string, 0, len(b.names))
+	for name := range b.names {
+		names = append(names, name)
+	}
+	sort.Sort(bitSorter{names, b.names})
+	return names
+}
+
+// bitSorter sorts the names of bits by value, then by name.
+type bitSorter struct {
+	names []string
+	bits  map[string]uint64
+}
+
+func (s bitSorter) Len() int      { return len(s.names) }
+func (s bitSorter) Swap(i, j int) { s.names[i], s.names[j] = s.names[j], s.names[i] }
+func (s bitSorter) Less(i, j int) bool {
+	if s.bits[s.names[i]] != s.bits[s.names[j]] {
+		return s.bits[s.names[i]] < s.bits[s.names[j]]
+	}
+	return s.names[i] < s.names[j]
+}
+
+// String returns the names of the bits which are set, joined with "|",
+// followed by the remaining bits as a number.
+func (b *bitmaskValue) String() string {
+	var parts []string
+	rest := *b.value
+	for _, name := range b.sortedNames() {
+		if bit := b.names[name]; bit != 0 && *b.value&bit == bit {
+			parts = append(parts, name)
+			rest &^= bit
+		}
+	}
+	if rest != 0 {
+		parts = append(parts, "0x"+strconv.FormatUint(rest, 16))
+	}
+	return strings.Join(parts, "|")
+}
+
+// GetBitmask return the uint64 value of a bitmask flag with the given name
+func (f *FlagSet) GetBitmask(name string) (uint64, error) {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return 0, fmt.Errorf("flag accessed but not defined: %s", name)
+	}
+	b, ok := flag.Value.(*bitmaskValue)
+	if !ok {
+		return 0, fmt.Errorf("trying to get bitmask value of flag of type %s", flag.Value.Type())
+	}
+	return *b.value, nil
+}
+
+// BitmaskVar defines a bitmask flag with specified name, default value, names of the bits, and usage string.
+// The value is given as names of bits separated by "|" or ",", as in "compress|encrypt", each occurrence
+// adding bits to the previous ones; the first one replaces the default value. Unknown names are rejected,
+// and the names are shown in usage messages.
+// The argument p points to a uint64 variable in which to store the value of the flag.
+func (f *FlagSet) BitmaskVar(p *uint64, name string, value uint64, names map[string]uint64, usage string) {
+	f.VarP(newBitmaskValue(value, p, names), name, "", usage)
+}
+
+// BitmaskVarP is like BitmaskVar, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) BitmaskVarP(p *uint64, name, shorthand string, value uint64, names map[string]uint64, usage string) {
+	f.VarP(newBitmaskValue(value, p, names), name, shorthand, usage)
+}
+
+// BitmaskVar defines a bitmask flag with specified name, default value, names of the bits, and usage string.
+// The value is given as names of bits separated by "|" or ",", as in "compress|encrypt", each occurrence
+// adding bits to the previous ones; the first one replaces the default value. Unknown names are rejected,
+// and the names are shown in usage messages.
+// The argument p points to a uint64 variable in which to store the value of the flag.
+func BitmaskVar(p *uint64, name string, value uint64, names map[string]uint64, usage string) {
+	CommandLine.VarP(newBitmaskValue(value, p, names), name, "", usage)
+}
+
+// BitmaskVarP is like BitmaskVar, but accepts a shorthand letter that can be used after a single dash.
+func BitmaskVarP(p *uint64, name, shorthand string, value uint64, names map[string]uint64, usage string) {
+	CommandLine.VarP(newBitmaskValue(value, p, names), name, shorthand, usage)
+}
+
+// Bitmask defines a bitmask flag with specified name, default value, names of the bits, and usage string.
+// The value is given as names of bits separated by "|" or ",", as in "compress|encrypt", each occurrence
+// adding bits to the previous ones; the first one replaces the default value. Unknown names are rejected,
+// and the names are shown in usage messages.
+// The return value is the address of a uint64 variable that stores the value of the flag.
+func (f *FlagSet) Bitmask(name string, value uint64, names map[string]uint64, usage string) *uint64 {
+	p := new(uint64)
+	f.BitmaskVarP(p, name, "", value, names, usage)
+	return p
+}
+
+// BitmaskP is like Bitmask, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) BitmaskP(name, shorthand string, value uint64, names map[string]uint64, usage string) *uint64 {
+	p := new(uint64)
+	f.BitmaskVarP(p, name, shorthand, value, names, usage)
+	return p
+}
+
+// Bitmask defines a bitmask flag with specified name, default value, names of the bits, and usage string.
+// The value is given as names of bits separated by "|" or ",", as in "compress|encrypt", each occurrence
+// adding bits to the previous ones; the first one replaces the default value. Unknown names are rejected,
+// and the names are shown in usage messages.
+// The return value is the address of a uint64 variable that stores the value of the flag.
+func Bitmask(name string, value uint64, names map[string]uint64, usage string) *uint64 {
+	return CommandLine.BitmaskP(name, "", value, names, usage)
+}
+
+// BitmaskP is like Bitmask, but accepts a shorthand letter that can be used after a single dash.
+func BitmaskP(name, shorthand string, value uint64, names map[string]uint64, usage string) *uint64 {
+	return CommandLine.BitmaskP(name, shorthand, value, names, usage)
+}
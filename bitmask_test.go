@@ -0,0 +1,47 @@
+package pflag
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+var testFeatures = map[string]uint64{"compress": 1, "encrypt": 2, "dedupe": 4}
+
+func TestBitmask(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	features := f.BitmaskP("features", "F", 1, testFeatures, "features to enable")
+
+	if err := f.Parse([]string{"--features", "encrypt|dedupe", "-F", "8"}); err != nil {
+		t.Fatal(err)
+	}
+	if *features != 14 {
+		t.Errorf("got features %b, want 1110", *features)
+	}
+	if got, err := f.GetBitmask("features"); err != nil || got != 14 {
+		t.Errorf("GetBitmask returned %d, %v", got, err)
+	}
+	if got := f.Lookup("features").Value.String(); got != "encrypt|dedupe|0x8" {
+		t.Errorf("got string %q", got)
+	}
+
+	if err := f.Parse([]string{"--features=compress,zip"}); err == nil || !strings.Contains(err.Error(), `unknown name "zip"`) {
+		t.Errorf("got error %v for an unknown name", err)
+	}
+
+	if err := f.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if *features != 1 {
+		t.Errorf("got features %b after reset, want 1", *features)
+	}
+	if err := f.Parse([]string{"--features=dedupe", "--features=encrypt"}); err != nil || *features != 6 {
+		t.Errorf("got features %b and error %v, want 110", *features, err)
+	}
+
+	usage := f.FlagUsages()
+	if want := "features to enable (default compress) (any of compress, encrypt, dedupe)"; !strings.Contains(usage, want) {
+		t.Errorf("got usage %q, want it to contain %q", usage, want)
+	}
+}
@@ -10,6 +10,7 @@ import (
 type boolSliceValue struct {
 	value   *[]bool
 	changed bool
+	sliceOptions
 }
 
 func newBoolSliceValue(val []bool, p *[]bool) *boolSliceValue {
@@ -27,7 +28,9 @@ func (s *boolSliceValue) Set(val string) error {
 	rmQuote := strings.NewReplacer(`"`, "", `'`, "", "`", "")
 
 	// read flag arguments with CSV parser
-	boolStrSlice, err := readAsCSV(rmQuote.Replace(val))
+	boolStrSlice, err := s.split(val, func(val string) ([]string, error) {
+		return readAsCSV(rmQuote.Replace(val))
+	})
 	if err != nil && err != io.EOF {
 		return err
 	}
@@ -42,10 +45,8 @@ func (s *boolSliceValue) Set(val string) error {
 		out = append(out, b)
 	}
 
-	if !s.changed {
-		*s.value = out
-	} else {
-		*s.value = append(*s.value, out...)
+	if err := s.assign(s.value, out, s.changed || s.appendDefault); err != nil {
+		return err
 	}
 
 	s.changed = true
@@ -58,6 +59,46 @@ func (s *boolSliceValue) Type() string {
 	return "boolSlice"
 }
 
+// parseBoolSliceElement parses an element of the value of a boolSlice flag.
+func parseBoolSliceElement(val string) (bool, error) {
+	return strconv.ParseBool(strings.TrimSpace(val))
+}
+
+func (s *boolSliceValue) Append(val string) error {
+	v, err := parseBoolSliceElement(val)
+	if err != nil {
+		return err
+	}
+	if err := s.assign(s.value, []bool{v}, true); err != nil {
+		return err
+	}
+	s.changed = true
+	return nil
+}
+
+func (s *boolSliceValue) Replace(val []string) error {
+	out := make([]bool, len(val))
+	for i, d := range val {
+		var err error
+		if out[i], err = parseBoolSliceElement(d); err != nil {
+			return err
+		}
+	}
+	if err := s.assign(s.value, out, false); err != nil {
+		return err
+	}
+	s.changed = true
+	return nil
+}
+
+func (s *boolSliceValue) GetSlice() []string {
+	out := make([]string, len(*s.value))
+	for i, d := range *s.value {
+		out[i] = strconv.FormatBool(d)
+	}
+	return out
+}
+
 // String defines a "native" format for this boolean slice flag value.
 func (s *boolSliceValue) String() string {
 
@@ -0,0 +1,259 @@
+package pflag
+
+import (
+	"fmt"
+	"time"
+)
+
+// FlagBuilder defines a flag by chaining the properties of the flag, e.g.
+//
+//	output := f.New("output").Short('o').Default("json").
+//		Choices("json", "yaml").Usage("output format").String()
+//
+// The flag is defined by the last call, which selects its type. Like the
+// other ways of defining flags, it panics if the definition is invalid, for
+// instance if the default value can't be parsed.
+type FlagBuilder struct {
+	fs         *FlagSet
+	name       string
+	shorthand  string
+	usage      string
+	def        *string
+	properties []func(name string) error
+}
+
+// New starts the definition of the flag name, see FlagBuilder.
+func (f *FlagSet) New(name string) *FlagBuilder {
+	return &FlagBuilder{fs: f, name: name}
+}
+
+// Short sets the shorthand of the flag.
+func (b *FlagBuilder) Short(shorthand rune) *FlagBuilder {
+	b.shorthand = string(shorthand)
+	return b
+}
+
+// Usage sets the usage message of the flag.
+func (b *FlagBuilder) Usage(usage string) *FlagBuilder {
+	b.usage = usage
+	return b
+}
+
+// Default sets the default value of the flag, in the syntax accepted by the
+// Set method of its value. Without it, the default is the zero value.
+func (b *FlagBuilder) Default(value string) *FlagBuilder {
+	b.def = &value
+	return b
+}
+
+// property adds a property to apply once the flag is defined.
+func (b *FlagBuilder) property(fn func(name string) error) *FlagBuilder {
+	b.properties = append(b.properties, fn)
+	return b
+}
+
+// Required marks the flag as required, see MarkRequired.
+func (b *FlagBuilder) Required() *FlagBuilder {
+	return b.property(b.fs.MarkRequired)
+}
+
+// Hidden sets whether the flag is hidden, see MarkHidden.
+func (b *FlagBuilder) Hidden(hidden bool) *FlagBuilder {
+	return b.property(func(name string) error {
+		b.fs.Lookup(name).Hidden = hidden
+		return nil
+	})
+}
+
+// Deprecated marks the flag as deprecated, see MarkDeprecated.
+func (b *FlagBuilder) Deprecated(usageMessage string) *FlagBuilder {
+	return b.property(func(name string) error {
+		return b.fs.MarkDeprecated(name, usageMessage)
+	})
+}
+
+// Sensitive marks the value of the flag as sensitive, see MarkSensitive.
+func (b *FlagBuilder) Sensitive() *FlagBuilder {
+	return b.property(b.fs.MarkSensitive)
+}
+
+// Group lists the flag in a section of the usage message, see SetGroup.
+func (b *FlagBuilder) Group(group string) *FlagBuilder {
+	return b.property(func(name string) error {
+		return b.fs.SetGroup(group, name)
+	})
+}
+
+// ArgName sets the name of the argument of the flag, see SetArgName.
+func (b *FlagBuilder) ArgName(argName string) *FlagBuilder {
+	return b.property(func(name string) error {
+		return b.fs.SetArgName(name, argName)
+	})
+}
+
+// Example sets an example invocation of the flag, see SetExample.
+func (b *FlagBuilder) Example(example string) *FlagBuilder {
+	return b.property(func(name string) error {
+		return b.fs.SetExample(name, example)
+	})
+}
+
+// Choices restricts the values of the flag, see SetChoices.
+func (b *FlagBuilder) Choices(choices ...string) *FlagBuilder {
+	return b.property(func(name string) error {
+		return b.fs.SetChoices(name, choices...)
+	})
+}
+
+// Validate sets the validator of the flag, see SetValidator.
+func (b *FlagBuilder) Validate(fn func(value string) error) *FlagBuilder {
+	return b.property(func(name string) error {
+		return b.fs.SetValidator(name, fn)
+	})
+}
+
+// Separator sets the separator of the elements of a slice flag, see
+// SetSliceSeparator.
+func (b *FlagBuilder) Separator(sep rune) *FlagBuilder {
+	return b.property(func(name string) error {
+		return b.fs.SetSliceSeparator(name, sep)
+	})
+}
+
+// Unique removes the duplicate elements of a slice flag, see
+// SetSliceDuplicates.
+func (b *FlagBuilder) Unique() *FlagBuilder {
+	return b.property(func(name string) error {
+		return b.fs.SetSliceDuplicates(name, DuplicatesRemove)
+	})
+}
+
+// NoDuplicates makes duplicate elements of a slice flag an error, see
+// SetSliceDuplicates.
+func (b *FlagBuilder) NoDuplicates() *FlagBuilder {
+	return b.property(func(name string) error {
+		return b.fs.SetSliceDuplicates(name, DuplicatesError)
+	})
+}
+
+// Sorted sorts the elements of a slice flag, see SetSliceSorted.
+func (b *FlagBuilder) Sorted() *FlagBuilder {
+	return b.property(func(name string) error {
+		return b.fs.SetSliceSorted(name, true)
+	})
+}
+
+// AppendDefault makes the values of a slice flag append to its default, see
+// SetSliceAppendDefault.
+func (b *FlagBuilder) AppendDefault() *FlagBuilder {
+	return b.property(func(name string) error {
+		return b.fs.SetSliceAppendDefault(name, true)
+	})
+}
+
+// Var defines the flag with the given value and returns it.
+func (b *FlagBuilder) Var(value Value) *Flag {
+	flag := b.fs.VarPF(value, b.name, b.shorthand, b.usage)
+	if err := b.define(flag); err != nil {
+		panic(fmt.Sprintf("flag --%s: %v", b.name, err))
+	}
+	return flag
+}
+
+// define sets the default value and the properties of flag.
+func (b *FlagBuilder) define(flag *Flag) error {
+	for _, fn := range b.properties {
+		if err := fn(flag.Name); err != nil {
+			return err
+		}
+	}
+	if b.def == nil {
+		return nil
+	}
+	if err := b.fs.checkValue(flag, *b.def); err != nil {
+		return fmt.Errorf("invalid default %q: %v", *b.def, err)
+	}
+	if err := flag.Value.Set(*b.def); err != nil {
+		return fmt.Errorf("invalid default %q: %v", *b.def, err)
+	}
+	// As for lazy defaults, slices must not append to the default when set.
+	flag.DefValue = flag.Value.String()
+	return restoreValue(flag, flag.DefValue)
+}
+
+// String defines a string flag.
+func (b *FlagBuilder) String() *string {
+	p := new(string)
+	b.Var(newStringValue("", p))
+	return p
+}
+
+// Bool defines a bool flag.
+func (b *FlagBuilder) Bool() *bool {
+	p := new(bool)
+	b.Var(newBoolValue(false, p))
+	return p
+}
+
+// Int defines an int flag.
+func (b *FlagBuilder) Int() *int {
+	p := new(int)
+	b.Var(newIntValue(0, p))
+	return p
+}
+
+// Int64 defines an int64 flag.
+func (b *FlagBuilder) Int64() *int64 {
+	p := new(int64)
+	b.Var(newInt64Value(0, p))
+	return p
+}
+
+// Uint defines a uint flag.
+func (b *FlagBuilder) Uint() *uint {
+	p := new(uint)
+	b.Var(newUintValue(0, p))
+	return p
+}
+
+// Float64 defines a float64 flag.
+func (b *FlagBuilder) Float64() *float64 {
+	p := new(float64)
+	b.Var(newFloat64Value(0, p))
+	return p
+}
+
+// Duration defines a time.Duration flag.
+func (b *FlagBuilder) Duration() *time.Duration {
+	p := new(time.Duration)
+	b.Var(newDurationValue(0, p))
+	return p
+}
+
+// Count defines a count flag, see Count.
+func (b *FlagBuilder) Count() *int {
+	p := new(int)
+	b.Var(newCountValue(0, p))
+	return p
+}
+
+// StringSlice defines a []string flag, see StringSlice.
+func (b *FlagBuilder) StringSlice() *[]string {
+	p := new([]string)
+	b.Var(newStringSliceValue(nil, p))
+	return p
+}
+
+// StringArray defines a []string flag, see StringArray.
+func (b *FlagBuilder) StringArray() *[]string {
+	p := new([]string)
+	b.Var(newStringArrayValue(nil, p))
+	return p
+}
+
+// IntSlice defines a []int flag.
+func (b *FlagBuilder) IntSlice() *[]int {
+	p := new([]int)
+	b.Var(newIntSliceValue(nil, p))
+	return p
+}
@@ -0,0 +1,48 @@
+package pflag
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	output := f.New("output").Short('o').Default("json").Choices("json", "yaml").Usage("output format").String()
+	token := f.New("token").Required().Sensitive().Hidden(true).String()
+	tags := f.New("tags").Default("a,b").Group("filters").StringSlice()
+	verbose := f.New("verbose").Short('v').Bool()
+	retries := f.New("retries").Default("3").Deprecated("use --attempts").Int()
+
+	flag := f.Lookup("output")
+	if flag.Shorthand != "o" || flag.Usage != "output format" || flag.DefValue != "json" || *output != "json" {
+		t.Errorf("unexpected flag %+v", flag)
+	}
+	if flag := f.Lookup("token"); !flag.Required || !flag.Sensitive || !flag.Hidden {
+		t.Errorf("unexpected flag %+v", flag)
+	}
+	if flag := f.Lookup("tags"); flag.Group != "filters" || flag.DefValue != "[a,b]" {
+		t.Errorf("unexpected flag %+v", flag)
+	}
+	if f.Lookup("verbose").NoOptDefVal != "true" || f.Lookup("retries").Deprecated == "" || *retries != 3 {
+		t.Error("unexpected verbose or retries flag")
+	}
+
+	f.SetOutput(ioutil.Discard)
+	if err := f.Parse([]string{"-o", "yaml", "--token", "x", "--tags", "c", "-v"}); err != nil {
+		t.Fatal(err)
+	}
+	if *output != "yaml" || *token != "x" || !reflect.DeepEqual(*tags, []string{"c"}) || !*verbose {
+		t.Errorf("unexpected values %q %q %v %v", *output, *token, *tags, *verbose)
+	}
+	if err := f.Set("output", "xml"); err == nil {
+		t.Error("expected an error for a value which is not a choice")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for an invalid default")
+		}
+	}()
+	f.New("port").Default("http").Int()
+}
@@ -0,0 +1,97 @@
+package pflag
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// pflagFuncPrefix is the prefix of the names of the functions of this
+// package, e.g. "github.com/spf13/pflag.".
+var pflagFuncPrefix = reflect.TypeOf(FlagSet{}).PkgPath() + "."
+
+// callSite holds the program counters of the stack where a flag was
+// defined. They are only resolved to a location when needed, see String,
+// since doing so for every flag would slow down the definition of flags.
+type callSite [8]uintptr
+
+// captureCallSite returns the call site of its caller.
+func captureCallSite() callSite {
+	var cs callSite
+	runtime.Callers(2, cs[:])
+	return cs
+}
+
+// String returns the location, as "file:line", of the first caller outside
+// of this package, or "" if it is unknown.
+func (cs *callSite) String() string {
+	n := 0
+	for n < len(cs) && cs[n] != 0 {
+		n++
+	}
+	frames := runtime.CallersFrames(cs[:n])
+	for {
+		frame, more := frames.Next()
+		internal := strings.HasPrefix(frame.Function, pflagFuncPrefix) && !strings.HasSuffix(frame.File, "_test.go")
+		if !internal && frame.File != "" {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// DefinedAt returns the location in the program, as "file:line", where the
+// flag was added to a FlagSet, or "" if it is unknown.
+func (f *Flag) DefinedAt() string {
+	return f.definedAt.String()
+}
+
+// FlagRedefinedError describes a flag whose name is already used by another
+// flag of the FlagSet. AddFlag panics with it.
+type FlagRedefinedError struct {
+	FlagSet  string // name of the FlagSet
+	Existing *Flag  // flag which was defined first
+	Flag     *Flag  // flag whose definition conflicted
+}
+
+func (e *FlagRedefinedError) Error() string {
+	msg := fmt.Sprintf("%s flag redefined: %s", e.FlagSet, e.Flag.Name)
+	if existing, redefined := e.Existing.DefinedAt(), e.Flag.DefinedAt(); existing != "" || redefined != "" {
+		msg += fmt.Sprintf(" (defined at %s, redefined at %s)", existing, redefined)
+	}
+	return msg
+}
+
+// ShorthandConflictError describes a flag whose shorthand is already used by
+// another flag of the FlagSet. AddFlag panics with it, unless conflicts are
+// collected, see SetCollectConflicts.
+type ShorthandConflictError struct {
+	FlagSet   string // name of the FlagSet
+	Shorthand string
+	Existing  *Flag // flag which registered the shorthand first
+	Flag      *Flag // flag whose definition conflicted
+}
+
+func (e *ShorthandConflictError) Error() string {
+	msg := fmt.Sprintf("unable to redefine %q shorthand in %q flagset: it's already used for %q flag", shorthandArg(e.Shorthand), e.FlagSet, e.Existing.Name)
+	if existing, redefined := e.Existing.DefinedAt(), e.Flag.DefinedAt(); existing != "" || redefined != "" {
+		msg += fmt.Sprintf(" (defined at %s, conflicting flag %q defined at %s)", existing, e.Flag.Name, redefined)
+	}
+	return msg
+}
+
+// SetCollectConflicts sets whether a shorthand which is already used is an
+// error to be reported by Conflicts rather than a panic. The flag whose
+// definition conflicted is then added without its shorthand.
+func (f *FlagSet) SetCollectConflicts(collect bool) {
+	f.collectConflicts = collect
+}
+
+// Conflicts returns the shorthand conflicts found while adding flags, in the
+// order they occurred, see SetCollectConflicts.
+func (f *FlagSet) Conflicts() []*ShorthandConflictError {
+	return f.conflicts
+}
@@ -0,0 +1,75 @@
+package pflag
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestShorthandConflict(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.BoolP("verbose", "v", false, "")
+	if site := f.Lookup("verbose").DefinedAt(); !strings.Contains(site, "callsite_test.go:") {
+		t.Errorf("DefinedAt() = %q, want a location in callsite_test.go", site)
+	}
+
+	func() {
+		defer func() {
+			err, ok := recover().(*ShorthandConflictError)
+			if !ok {
+				t.Fatal("expected a panic with a *ShorthandConflictError")
+			}
+			if err.Existing.Name != "verbose" || err.Flag.Name != "version" || err.Shorthand != "v" {
+				t.Errorf("unexpected conflict: %+v", err)
+			}
+			msg := err.Error()
+			if !strings.HasPrefix(msg, `unable to redefine 'v' shorthand in "test" flagset: it's already used for "verbose" flag`) {
+				t.Errorf("unexpected message: %s", msg)
+			}
+			if strings.Count(msg, "callsite_test.go:") != 2 {
+				t.Errorf("expected both definition sites in: %s", msg)
+			}
+		}()
+		f.IntP("version", "v", 0, "")
+	}()
+
+	f = NewFlagSet("test", ContinueOnError)
+	f.SetCollectConflicts(true)
+	f.BoolP("verbose", "v", false, "")
+	f.IntP("version", "v", 0, "")
+	f.StringP("value", "v", "", "")
+	conflicts := f.Conflicts()
+	if len(conflicts) != 2 || conflicts[0].Flag.Name != "version" || conflicts[1].Flag.Name != "value" {
+		t.Fatalf("unexpected conflicts: %v", conflicts)
+	}
+	if f.Lookup("version") == nil || f.Lookup("version").Shorthand != "" {
+		t.Error("the conflicting flag should be added without its shorthand")
+	}
+	if f.ShorthandLookup("v").Name != "verbose" {
+		t.Error("the shorthand should keep referring to the first flag")
+	}
+}
+
+func TestFlagRedefined(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.Bool("verbose", false, "")
+	defer func() {
+		err, ok := recover().(*FlagRedefinedError)
+		if !ok {
+			t.Fatal("expected a panic with a *FlagRedefinedError")
+		}
+		if err.Existing.Name != "verbose" || err.Flag.Name != "verbose" || err.Existing == err.Flag {
+			t.Errorf("unexpected redefinition: %+v", err)
+		}
+		msg := err.Error()
+		if !strings.HasPrefix(msg, "test flag redefined: verbose (defined at ") {
+			t.Errorf("unexpected message: %s", msg)
+		}
+		if strings.Count(msg, "callsite_test.go:") != 2 {
+			t.Errorf("expected both definition sites in: %s", msg)
+		}
+	}()
+	f.Int("verbose", 0, "")
+}
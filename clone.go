@@ -0,0 +1,242 @@
+package pflag
+
+import (
+	goflag "flag"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"reflect"
+	"text/template"
+)
+
+// ValueCloner is implemented by Values which can copy themselves. Clone
+// uses it for Values it does not know how to copy.
+type ValueCloner interface {
+	Value
+	// CloneValue returns a new, independent Value holding the same value.
+	CloneValue() Value
+}
+
+func copyBools(v []bool) []bool       { return append([]bool(nil), v...) }
+func copyInts(v []int) []int          { return append([]int(nil), v...) }
+func copyUints(v []uint) []uint       { return append([]uint(nil), v...) }
+func copyStrings(v []string) []string { return append([]string(nil), v...) }
+
+// cloneValue returns a new Value holding the same value as v, which doesn't
+// share any state with v.
+func cloneValue(v Value) (Value, error) {
+	switch v := v.(type) {
+	case ValueCloner:
+		return v.CloneValue(), nil
+	case *boolSliceValue:
+		c := newBoolSliceValue(copyBools(*v.value), new([]bool))
+		c.changed = v.changed
+		c.sliceOptions = v.sliceOptions
+		return c, nil
+	case *intSliceValue:
+		c := newIntSliceValue(copyInts(*v.value), new([]int))
+		c.changed = v.changed
+		c.sliceOptions = v.sliceOptions
+		return c, nil
+	case *uintSliceValue:
+		c := newUintSliceValue(copyUints(*v.value), new([]uint))
+		c.changed = v.changed
+		c.sliceOptions = v.sliceOptions
+		return c, nil
+	case *ipSliceValue:
+		c := newIPSliceValue(append((*v.value)[:0:0], *v.value...), new([]net.IP))
+		c.changed = v.changed
+		c.sliceOptions = v.sliceOptions
+		return c, nil
+	case *stringSliceValue:
+		c := newStringSliceValue(copyStrings(*v.value), new([]string))
+		c.changed = v.changed
+		c.sliceOptions = v.sliceOptions
+		return c, nil
+	case *stringArrayValue:
+		c := newStringArrayValue(copyStrings(*v.value), new([]string))
+		c.changed = v.changed
+		c.sliceOptions = v.sliceOptions
+		return c, nil
+	case *tlsCipherSuitesValue:
+		c := *v
+		c.value = new([]uint16)
+		*c.value = append([]uint16(nil), *v.value...)
+		return &c, nil
+	case *templateValue:
+		c := *v
+		c.value = new(*template.Template)
+		*c.value = *v.value
+		return &c, nil
+	case *headerValue:
+		c := *v
+		h := make(http.Header, len(*v.value))
+		for name, values := range *v.value {
+			h[name] = copyStrings(values)
+		}
+		c.value = &h
+		return &c, nil
+	case *stringToStringValue:
+		m := make(map[string]string, len(*v.value))
+		for k, s := range *v.value {
+			m[k] = s
+		}
+		c := *v
+		c.value = &m
+		return &c, nil
+	case *ipNetValue:
+		c := *v
+		return &c, nil
+	case *decimalValue:
+		c := *v
+		if v.Units != nil {
+			c.Units = new(big.Int).Set(v.Units)
+		}
+		return &c, nil
+	case *globValue:
+		c := *v
+		return &c, nil
+	case *rateValue:
+		c := *v
+		return &c, nil
+	case *signalValue:
+		return newSignalValue(*v.value, new(os.Signal)), nil
+	case *percentValue:
+		c := *v
+		c.value = new(float64)
+		*c.value = *v.value
+		return &c, nil
+	case *bitmaskValue:
+		c := *v
+		c.value = new(uint64)
+		*c.value = *v.value
+		return &c, nil
+	case *intRangeValue:
+		c := *v
+		c.value = new(int)
+		*c.value = *v.value
+		return &c, nil
+	case *float64RangeValue:
+		c := *v
+		c.value = new(float64)
+		*c.value = *v.value
+		return &c, nil
+
+	case *flagValueWrapper:
+		if inner, ok := cloneScalarPointer(v.inner); ok {
+			if inner, ok := inner.(goflag.Value); ok {
+				return &flagValueWrapper{inner: inner, flagType: v.flagType}, nil
+			}
+		}
+	case goBoolFlagWrapper:
+		if inner, ok := cloneScalarPointer(v.inner); ok {
+			if inner, ok := inner.(goflag.Value); ok {
+				return goBoolFlagWrapper{&flagValueWrapper{inner: inner, flagType: v.flagType}}, nil
+			}
+		}
+	case *transformValue:
+		inner, err := cloneValue(v.Value)
+		if err != nil {
+			return nil, err
+		}
+		return &transformValue{Value: inner, fn: v.fn}, nil
+	default:
+		if c, ok := cloneScalarPointer(v); ok {
+			if c, ok := c.(Value); ok {
+				return c, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("can not clone value of type %T, it should implement ValueCloner", v)
+}
+
+// cloneScalarPointer copies v if it is a pointer to a scalar type, like most
+// of the built-in Values, by copying what it points to.
+func cloneScalarPointer(v interface{}) (interface{}, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return nil, false
+	}
+	elem := rv.Elem()
+	switch elem.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Ptr, reflect.Interface, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return nil, false
+	}
+	c := reflect.New(elem.Type())
+	if elem.Kind() == reflect.Slice && !elem.IsNil() {
+		s := reflect.MakeSlice(elem.Type(), elem.Len(), elem.Len())
+		reflect.Copy(s, elem)
+		c.Elem().Set(s)
+	} else {
+		c.Elem().Set(elem)
+	}
+	return c.Interface(), true
+}
+
+// Clone returns a copy of the FlagSet. The flags of the copy have new Values
+// initialized to the current values of the flags of f, so that parsing into
+// the copy, or setting its flags, leaves f and the variables its flags are
+// bound to untouched. Values of custom types are copied if they are pointers
+// to a scalar type or implement ValueCloner, otherwise Clone fails.
+func (f *FlagSet) Clone() (*FlagSet, error) {
+	c := *f
+	c.formal = make(map[NormalizedName]*Flag, len(f.formal))
+	c.orderedFormal = make([]*Flag, 0, len(f.orderedFormal))
+	c.sortedFormal = nil
+	c.names = nil
+	c.sortedActual = nil
+	c.shorthands = nil
+	c.groups = copyStrings(f.groups)
+	c.args = copyStrings(f.args)
+	c.occurrences = nil
+
+	clones := make(map[*Flag]*Flag, len(f.formal))
+	for _, flag := range f.orderedFormal {
+		value, err := cloneValue(flag.Value)
+		if err != nil {
+			return nil, fmt.Errorf("flag %q: %v", flag.Name, err)
+		}
+		cf := *flag
+		cf.Value = value
+		if flag.Annotations != nil {
+			cf.Annotations = make(map[string][]string, len(flag.Annotations))
+			for k, v := range flag.Annotations {
+				cf.Annotations[k] = copyStrings(v)
+			}
+		}
+		clones[flag] = &cf
+		c.formal[NormalizedName(cf.Name)] = &cf
+		c.orderedFormal = append(c.orderedFormal, &cf)
+		if cf.Shorthand != "" {
+			if c.shorthands == nil {
+				c.shorthands = make(map[string]*Flag)
+			}
+			c.shorthands[cf.Shorthand] = &cf
+		}
+	}
+
+	if f.actual != nil {
+		c.actual = make(map[NormalizedName]*Flag, len(f.actual))
+		for name, flag := range f.actual {
+			c.actual[name] = clones[flag]
+		}
+	}
+	c.orderedActual = make([]*Flag, len(f.orderedActual))
+	for i, flag := range f.orderedActual {
+		c.orderedActual[i] = clones[flag]
+	}
+	for _, o := range f.occurrences {
+		c.occurrences = append(c.occurrences, flagOccurrence{clones[o.flag], o.position})
+	}
+	c.versionFlag = clones[f.versionFlag]
+	c.stdinFlag = clones[f.stdinFlag]
+	if f.onChanged != nil {
+		c.onChanged = make(map[*Flag][]func(old, new string), len(f.onChanged))
+		for flag, fns := range f.onChanged {
+			c.onChanged[clones[flag]] = append([]func(old, new string){}, fns...)
+		}
+	}
+	return &c, nil
+}
@@ -0,0 +1,88 @@
+package pflag
+
+import (
+	goflag "flag"
+	"strings"
+	"testing"
+)
+
+type opaqueValue struct{ v *string }
+
+func (o opaqueValue) String() string     { return *o.v }
+func (o opaqueValue) Set(s string) error { *o.v = s; return nil }
+func (o opaqueValue) Type() string       { return "opaque" }
+
+func TestClone(t *testing.T) {
+	fs := NewFlagSet("TestClone", ContinueOnError)
+	n := fs.IntP("n", "n", 1, "")
+	names := fs.StringSlice("names", []string{"a"}, "")
+	var tri triStateValue
+	fs.Var(&tri, "tri", "")
+	gs := goflag.NewFlagSet("go", goflag.ContinueOnError)
+	gs.Int("goint", 2, "")
+	fs.AddGoFlagSet(gs)
+	fs.SetAnnotation("n", "key", []string{"v"})
+	if err := fs.Parse([]string{"--names=b"}); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := fs.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Parse([]string{"-n", "5", "--names=c", "--tri=true", "--goint=3"}); err != nil {
+		t.Fatal(err)
+	}
+	c.Lookup("n").Annotations["key"][0] = "changed"
+
+	if *n != 1 || len(*names) != 1 || (*names)[0] != "b" || tri != triStateFalse {
+		t.Errorf("clone changed the original: n=%d names=%v tri=%v", *n, *names, tri)
+	}
+	if got := gs.Lookup("goint").Value.String(); got != "2" {
+		t.Errorf("clone changed the original go flag: %s", got)
+	}
+	if fs.Lookup("n").Annotations["key"][0] != "v" {
+		t.Error("clone shares annotations with the original")
+	}
+	if got, _ := c.GetStringSlice("names"); len(got) != 2 || got[0] != "b" || got[1] != "c" {
+		t.Errorf("unexpected clone value %v", got)
+	}
+	if got, _ := c.GetInt("n"); got != 5 {
+		t.Errorf("unexpected clone value %v", got)
+	}
+
+	s := ""
+	fs.Var(opaqueValue{&s}, "opaque", "")
+	if _, err := fs.Clone(); err == nil {
+		t.Error("expected an error cloning an opaque value")
+	}
+}
+
+func TestCloneOnChanged(t *testing.T) {
+	fs := NewFlagSet("TestCloneOnChanged", ContinueOnError)
+	fs.String("level", "info", "")
+	var calls []string
+	if err := fs.OnChanged("level", func(old, new string) {
+		calls = append(calls, old+"->"+new)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	c, err := fs.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.OnChanged("level", func(old, new string) {
+		calls = append(calls, "clone only")
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Set("level", "debug"); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Set("level", "warn"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(calls, " "), "info->debug clone only info->warn"; got != want {
+		t.Errorf("got calls %q want %q", got, want)
+	}
+}
@@ -0,0 +1,64 @@
+package pflag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// command is a subcommand added with AddCommand.
+type command struct {
+	name string
+	fs   *FlagSet
+}
+
+// AddCommand adds sub as the subcommand name of the FlagSet. When the first
+// positional argument given to Parse is name, the arguments following it are
+// parsed by sub instead, and the flags of sub are listed under the command in
+// the usage message of the FlagSet. Adding a command name twice panics.
+func (f *FlagSet) AddCommand(name string, sub *FlagSet) {
+	for _, cmd := range f.commands {
+		if cmd.name == name {
+			msg := fmt.Sprintf("%s command redefined: %s", f.name, name)
+			fmt.Fprintln(f.errOut(), msg)
+			panic(msg) // Happens only if commands are declared with identical names
+		}
+	}
+	f.commands = append(f.commands, command{name, sub})
+}
+
+// Command returns the name and the FlagSet of the subcommand selected by the
+// last call to Parse, or "" and nil if no subcommand was given. The arguments
+// of the subcommand are available from its own Args, while Args of the
+// FlagSet starts with the name of the subcommand.
+func (f *FlagSet) Command() (string, *FlagSet) {
+	if f.command == nil {
+		return "", nil
+	}
+	return f.command.name, f.command.fs
+}
+
+// lookupCommand returns the subcommand name, or nil if there is none.
+func (f *FlagSet) lookupCommand(name string) *command {
+	for i := range f.commands {
+		if f.commands[i].name == name {
+			return &f.commands[i]
+		}
+	}
+	return nil
+}
+
+// printCommands prints the subcommands and their flags, as part of the
+// usage message.
+func (f *FlagSet) printCommands() {
+	if len(f.commands) == 0 {
+		return
+	}
+	fmt.Fprint(f.out(), f.msg(MsgCommands))
+	for _, cmd := range f.commands {
+		fmt.Fprintf(f.out(), "  %s\n", cmd.name)
+		usages := strings.TrimSuffix(cmd.fs.FlagUsages(), "\n")
+		if usages != "" {
+			fmt.Fprintf(f.out(), "  %s\n", strings.Replace(usages, "\n", "\n  ", -1))
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package pflag
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestCommand(t *testing.T) {
+	f := NewFlagSet("app", ContinueOnError)
+	verbose := f.BoolP("verbose", "v", false, "verbose output")
+	serve := NewFlagSet("serve", ContinueOnError)
+	port := serve.Int("port", 80, "port to listen on")
+	serve.SetOutput(new(bytes.Buffer))
+	f.AddCommand("serve", serve)
+	f.AddCommand("version", NewFlagSet("version", ContinueOnError))
+
+	if err := f.Parse([]string{"-v", "serve", "--port=8080", "dir", "-v"}); err == nil {
+		t.Error("expected an error for a flag unknown to the subcommand")
+	}
+	f.Reset()
+	serve.Reset()
+	if err := f.Parse([]string{"-v", "serve", "--port=8080", "dir"}); err != nil {
+		t.Fatal(err)
+	}
+	name, sub := f.Command()
+	if name != "serve" || sub != serve {
+		t.Errorf("got command %q %p want serve %p", name, sub, serve)
+	}
+	if !*verbose || *port != 8080 {
+		t.Errorf("unexpected values %v %d", *verbose, *port)
+	}
+	if want := []string{"dir"}; !reflect.DeepEqual(serve.Args(), want) {
+		t.Errorf("got args %v want %v", serve.Args(), want)
+	}
+
+	f.Reset()
+	if err := f.Parse([]string{"file", "serve"}); err != nil {
+		t.Fatal(err)
+	}
+	if name, _ := f.Command(); name != "" {
+		t.Errorf("command %q selected by a later positional argument", name)
+	}
+
+	var buf bytes.Buffer
+	f.SetOutput(&buf)
+	defaultUsage(f)
+	want := `Usage of app:
+  -v, --verbose   verbose output
+
+Commands:
+  serve
+        --port int   port to listen on (default 80)
+  version
+`
+	if buf.String() != want {
+		t.Errorf("got usage\n%s\nwant\n%s", buf.String(), want)
+	}
+}
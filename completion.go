@@ -0,0 +1,239 @@
+package pflag
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// CompDirective tells the shell how to handle the completions of an
+// argument. Its values are those of the ShellCompDirective of cobra, so that
+// programs using this package work with the completion scripts cobra
+// generates.
+type CompDirective int
+
+// The completion directives, which can be combined.
+const (
+	// CompDirectiveError indicates an error; completions are ignored.
+	CompDirectiveError CompDirective = 1 << iota
+	// CompDirectiveNoSpace prevents the shell from adding a space after the
+	// completion, even if there is a single one.
+	CompDirectiveNoSpace
+	// CompDirectiveNoFileComp prevents the shell from completing file names
+	// when there are no completions.
+	CompDirectiveNoFileComp
+	// CompDirectiveFilterFileExt makes the completions file extensions to
+	// filter the file names completed by the shell with.
+	CompDirectiveFilterFileExt
+	// CompDirectiveFilterDirs makes the shell complete directory names only,
+	// in the single completion if any, which is then the parent directory.
+	CompDirectiveFilterDirs
+	// CompDirectiveKeepOrder prevents the shell from sorting the completions.
+	CompDirectiveKeepOrder
+
+	// CompDirectiveDefault lets the shell complete file names if there are no
+	// completions.
+	CompDirectiveDefault CompDirective = 0
+)
+
+// CompletionCommand is the first argument the completion scripts of cobra
+// give the program to request completions, see HandleCompletion.
+// CompletionCommandNoDesc requests completions without descriptions.
+const (
+	CompletionCommand       = "__complete"
+	CompletionCommandNoDesc = "__completeNoDesc"
+)
+
+// CompletionFunc returns the completions of toComplete, the argument being
+// completed. A completion may be followed by a tab and its description.
+type CompletionFunc func(ctx context.Context, toComplete string) ([]string, CompDirective)
+
+// RegisterCompletion makes fn complete the values of the named flag. Flags
+// without one complete their choices, see SetChoices.
+func (f *FlagSet) RegisterCompletion(name string, fn CompletionFunc) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	flag.complete = fn
+	return nil
+}
+
+// RegisterArgsCompletion makes fn complete the positional arguments which
+// aren't subcommands, see AddCommand.
+func (f *FlagSet) RegisterArgsCompletion(fn CompletionFunc) {
+	f.completeArgs = fn
+}
+
+// Complete returns the completions of the last of args, which are the
+// arguments of the program up to the one being completed, possibly empty.
+// The completion functions are called with the context of the FlagSet, see
+// Context. Flag values are not set.
+func (f *FlagSet) Complete(args []string) ([]string, CompDirective) {
+	if len(args) == 0 {
+		args = []string{""}
+	}
+	toComplete := args[len(args)-1]
+	given := make(map[*Flag]bool)
+	positional := 0
+	dashdash := false
+	var pending *Flag // flag waiting for its value
+	for i, a := range args[:len(args)-1] {
+		switch {
+		case pending != nil:
+			pending = nil
+		case dashdash || a == "-" || len(a) < 2 || a[0] != '-':
+			if positional == 0 {
+				if cmd := f.lookupCommand(a); cmd != nil {
+					return cmd.fs.Complete(args[i+1:])
+				}
+			}
+			positional++
+		case a == "--":
+			dashdash = true
+		case a[1] == '-':
+			name := a[2:]
+			hasValue := false
+			if i := strings.IndexByte(name, '='); i >= 0 {
+				name, hasValue = name[:i], true
+			}
+			if flag := f.Lookup(name); flag != nil {
+				given[flag] = true
+				if !hasValue && flag.NoOptDefVal == "" {
+					pending = flag
+				}
+			}
+		default:
+			pending = f.givenShorthands(a[1:], given)
+		}
+	}
+
+	switch {
+	case pending != nil:
+		return f.completeValue(pending, toComplete)
+	case dashdash || len(toComplete) == 0 || toComplete[0] != '-':
+		return f.completePositional(positional, toComplete)
+	case strings.HasPrefix(toComplete, "--") && strings.Contains(toComplete, "="):
+		i := strings.IndexByte(toComplete, '=')
+		flag := f.Lookup(toComplete[2:i])
+		if flag == nil {
+			return nil, CompDirectiveNoFileComp
+		}
+		values, directive := f.completeValue(flag, toComplete[i+1:])
+		comps := make([]string, len(values))
+		for j, value := range values {
+			comps[j] = toComplete[:i+1] + value
+		}
+		return comps, directive
+	}
+	return f.completeNames(toComplete, given), CompDirectiveNoFileComp
+}
+
+// givenShorthands records the flags of the group of shorthands in given,
+// and returns the last flag if it is waiting for its value.
+func (f *FlagSet) givenShorthands(shorthands string, given map[*Flag]bool) *Flag {
+	for len(shorthands) > 0 {
+		flag, n := f.shorthandAt(shorthands)
+		if flag == nil {
+			return nil
+		}
+		given[flag] = true
+		if flag.NoOptDefVal == "" {
+			if n == len(shorthands) {
+				return flag
+			}
+			// The rest of the group is the value.
+			return nil
+		}
+		shorthands = shorthands[n:]
+	}
+	return nil
+}
+
+// completeValue returns the completions of the value of flag.
+func (f *FlagSet) completeValue(flag *Flag, toComplete string) ([]string, CompDirective) {
+	if flag.complete != nil {
+		return flag.complete(f.Context(), toComplete)
+	}
+	if choices := allowedValues(flag); choices != nil {
+		var comps []string
+		for _, choice := range choices {
+			if strings.HasPrefix(choice, toComplete) {
+				comps = append(comps, choice)
+			}
+		}
+		return comps, CompDirectiveNoFileComp
+	}
+	return nil, CompDirectiveDefault
+}
+
+// completePositional returns the completions of a positional argument,
+// preceded by n others.
+func (f *FlagSet) completePositional(n int, toComplete string) ([]string, CompDirective) {
+	if n == 0 && len(f.commands) > 0 {
+		var comps []string
+		for _, cmd := range f.commands {
+			if strings.HasPrefix(cmd.name, toComplete) {
+				comps = append(comps, cmd.name)
+			}
+		}
+		if len(comps) > 0 {
+			return comps, CompDirectiveNoFileComp
+		}
+	}
+	if f.completeArgs != nil {
+		return f.completeArgs(f.Context(), toComplete)
+	}
+	return nil, CompDirectiveDefault
+}
+
+// completeNames returns the names of the flags starting with toComplete,
+// with their usage as description, leaving out those already given which
+// can't be repeated.
+func (f *FlagSet) completeNames(toComplete string, given map[*Flag]bool) []string {
+	var comps []string
+	add := func(name string, flag *Flag) {
+		if !strings.HasPrefix(name, toComplete) {
+			return
+		}
+		if usage := strings.SplitN(flag.Usage, "\n", 2)[0]; usage != "" {
+			name += "\t" + usage
+		}
+		comps = append(comps, name)
+	}
+	f.VisitAll(func(flag *Flag) {
+		if flag.Hidden || flag.Deprecated != "" || given[flag] && !accumulates(flag) {
+			return
+		}
+		add("--"+flag.Name, flag)
+		if flag.Shorthand != "" && flag.ShorthandDeprecated == "" {
+			add("-"+flag.Shorthand, flag)
+		}
+	})
+	return comps
+}
+
+// HandleCompletion answers the completion requests of the completion scripts
+// of cobra: if the first of args is CompletionCommand or
+// CompletionCommandNoDesc, it writes the completions of the rest of args to
+// w, one per line, followed by a line with the directive, and returns true.
+// The program should then exit. Otherwise it returns false.
+//
+//	if fs.HandleCompletion(os.Stdout, os.Args[1:]) {
+//		os.Exit(0)
+//	}
+func (f *FlagSet) HandleCompletion(w io.Writer, args []string) bool {
+	if len(args) == 0 || args[0] != CompletionCommand && args[0] != CompletionCommandNoDesc {
+		return false
+	}
+	comps, directive := f.Complete(args[1:])
+	for _, comp := range comps {
+		if args[0] == CompletionCommandNoDesc {
+			comp = strings.SplitN(comp, "\t", 2)[0]
+		}
+		fmt.Fprintln(w, comp)
+	}
+	fmt.Fprintf(w, ":%d\n", directive)
+	return true
+}
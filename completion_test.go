@@ -0,0 +1,64 @@
+package pflag
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestComplete(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.StringP("output", "o", "", "output format\nmore details")
+	f.SetChoices("output", "json", "yaml")
+	f.StringP("file", "f", "", "")
+	f.BoolP("verbose", "v", false, "verbose output")
+	f.StringSlice("tag", nil, "")
+	f.String("zone", "", "")
+	f.Bool("hidden", false, "")
+	f.MarkHidden("hidden")
+	f.RegisterCompletion("zone", func(ctx context.Context, toComplete string) ([]string, CompDirective) {
+		return []string{toComplete + "1", toComplete + "2"}, CompDirectiveNoSpace
+	})
+	sub := NewFlagSet("run", ContinueOnError)
+	sub.Int("jobs", 1, "")
+	f.AddCommand("run", sub)
+	f.AddCommand("rm", NewFlagSet("rm", ContinueOnError))
+
+	tests := []struct {
+		args      []string
+		comps     []string
+		directive CompDirective
+	}{
+		{[]string{"--o"}, []string{"--output\toutput format"}, CompDirectiveNoFileComp},
+		{[]string{"-"}, []string{"--file", "-f", "--output\toutput format", "-o\toutput format", "--tag", "--verbose\tverbose output", "-v\tverbose output", "--zone"}, CompDirectiveNoFileComp},
+		{[]string{"-v", "--tag", "a", "--t"}, []string{"--tag"}, CompDirectiveNoFileComp},
+		{[]string{"-v", "--v"}, nil, CompDirectiveNoFileComp},
+		{[]string{"--output", "y"}, []string{"yaml"}, CompDirectiveNoFileComp},
+		{[]string{"-vo", ""}, []string{"json", "yaml"}, CompDirectiveNoFileComp},
+		{[]string{"--output=j"}, []string{"--output=json"}, CompDirectiveNoFileComp},
+		{[]string{"--zone", "eu"}, []string{"eu1", "eu2"}, CompDirectiveNoSpace},
+		{[]string{"-f", ""}, nil, CompDirectiveDefault},
+		{[]string{"r"}, []string{"run", "rm"}, CompDirectiveNoFileComp},
+		{[]string{"-v", "run", "--j"}, []string{"--jobs"}, CompDirectiveNoFileComp},
+		{[]string{"x", ""}, nil, CompDirectiveDefault},
+		{[]string{"--", "-"}, nil, CompDirectiveDefault},
+	}
+	for _, test := range tests {
+		comps, directive := f.Complete(test.args)
+		if !reflect.DeepEqual(comps, test.comps) || directive != test.directive {
+			t.Errorf("%q: got %q %d, want %q %d", test.args, comps, directive, test.comps, test.directive)
+		}
+	}
+
+	var buf bytes.Buffer
+	if f.HandleCompletion(&buf, []string{"--verbose"}) {
+		t.Error("arguments which aren't completion requests should not be handled")
+	}
+	if !f.HandleCompletion(&buf, []string{CompletionCommandNoDesc, "--out"}) {
+		t.Fatal("completion request not handled")
+	}
+	if got := buf.String(); got != "--output\n:4\n" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,152 @@
+// Package config applies configuration documents, like YAML, JSON or TOML
+// files, to the flags of a pflag.FlagSet.
+//
+// The document is decoded by an unmarshal function with the signature of
+// json.Unmarshal, so any format with a decoder producing maps works, e.g.
+//
+//	unknown, err := config.Apply(fs, data, yaml.Unmarshal)
+//
+// Keys of nested maps are joined with "-", or else ".", to find the flag they
+// set: {"db": {"host": "x"}} sets the flag --db-host (or --db.host).
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/spf13/pflag"
+)
+
+// UnmarshalFunc decodes data into v, like json.Unmarshal.
+type UnmarshalFunc func(data []byte, v interface{}) error
+
+// JSON decodes JSON documents.
+var JSON UnmarshalFunc = json.Unmarshal
+
+// Apply decodes data with unmarshal and sets the flags of fs to the values it
+// holds. Flags whose value comes from a source with a higher precedence than
+// pflag.SourceConfig, by default the command line or the environment, keep
+// their value, so Apply is meant to be called after fs.Parse. List values
+// replace the elements of slice flags, see pflag.SliceValue, and set other
+// flags once per element. The keys which don't match any flag are returned,
+// joined with ".", in sorted order.
+func Apply(fs *pflag.FlagSet, data []byte, unmarshal UnmarshalFunc) (unknown []string, err error) {
+	var doc interface{}
+	if err := unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	m, ok := toStringMap(doc)
+	if !ok {
+		return nil, fmt.Errorf("config: document is a %T, not a map", doc)
+	}
+	a := applier{fs: fs}
+	if err := a.applyMap("", "", m); err != nil {
+		return nil, err
+	}
+	sort.Strings(a.unknown)
+	return a.unknown, nil
+}
+
+type applier struct {
+	fs      *pflag.FlagSet
+	unknown []string
+}
+
+func (a *applier) applyMap(dashPrefix, dotPrefix string, m map[string]interface{}) error {
+	for key, value := range m {
+		dashKey, dotKey := dashPrefix+key, dotPrefix+key
+		if sub, ok := toStringMap(value); ok {
+			if err := a.applyMap(dashKey+"-", dotKey+".", sub); err != nil {
+				return err
+			}
+			continue
+		}
+
+		flag := a.fs.Lookup(dashKey)
+		if flag == nil {
+			flag = a.fs.Lookup(dotKey)
+		}
+		if flag == nil {
+			a.unknown = append(a.unknown, dotKey)
+			continue
+		}
+		if !a.fs.Overrides(flag.Name, pflag.SourceConfig) {
+			continue
+		}
+
+		values := []interface{}{value}
+		if list, ok := value.([]interface{}); ok {
+			values = list
+			if _, ok := flag.Value.(pflag.SliceValue); ok {
+				if err := a.replaceSlice(flag, dotKey, list); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		for _, v := range values {
+			s, err := scalarString(v)
+			if err != nil {
+				return fmt.Errorf("config: key %q: %v", dotKey, err)
+			}
+			if err := a.fs.SetWithSource(flag.Name, s, pflag.SourceConfig); err != nil {
+				return fmt.Errorf("config: key %q: %v", dotKey, err)
+			}
+		}
+	}
+	return nil
+}
+
+// replaceSlice sets the elements of the slice flag to list, so that elements
+// holding separators aren't split.
+func (a *applier) replaceSlice(flag *pflag.Flag, dotKey string, list []interface{}) error {
+	elems := make([]string, len(list))
+	for i, v := range list {
+		s, err := scalarString(v)
+		if err != nil {
+			return fmt.Errorf("config: key %q: %v", dotKey, err)
+		}
+		elems[i] = s
+	}
+	if err := a.fs.ReplaceSliceWithSource(flag.Name, elems, pflag.SourceConfig); err != nil {
+		return fmt.Errorf("config: key %q: %v", dotKey, err)
+	}
+	return nil
+}
+
+// toStringMap returns v as a map with string keys, if it is a map.
+func toStringMap(v interface{}) (map[string]interface{}, bool) {
+	switch m := v.(type) {
+	case map[string]interface{}:
+		return m, true
+	case map[interface{}]interface{}:
+		// Some YAML decoders produce maps with keys of any type.
+		sm := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			sm[fmt.Sprint(k)] = v
+		}
+		return sm, true
+	}
+	return nil, false
+}
+
+// scalarString formats a decoded scalar value the way flags are set.
+func scalarString(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, json.Number:
+		return fmt.Sprint(v), nil
+	case nil:
+		return "", nil
+	}
+	return "", fmt.Errorf("unsupported value of type %T", v)
+}
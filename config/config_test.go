@@ -0,0 +1,53 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestApply(t *testing.T) {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	port := fs.Int("port", 0, "")
+	host := fs.String("db-host", "", "")
+	user := fs.String("db.user", "", "")
+	tags := fs.StringSlice("tags", nil, "")
+	debug := fs.Bool("debug", false, "")
+	ratio := fs.Float64("ratio", 0, "")
+	if err := fs.Parse([]string{"--debug=false"}); err != nil {
+		t.Fatal(err)
+	}
+
+	doc := `{
+		"port": 8080,
+		"db": {"host": "localhost", "user": "admin", "extra": 1},
+		"tags": ["a", "b"],
+		"debug": true,
+		"ratio": 0.5,
+		"nope": "x"
+	}`
+	unknown, err := Apply(fs, []byte(doc), JSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(unknown, []string{"db.extra", "nope"}) {
+		t.Errorf("unexpected unknown keys %q", unknown)
+	}
+	if *port != 8080 || *host != "localhost" || *user != "admin" || !reflect.DeepEqual(*tags, []string{"a", "b"}) || *ratio != 0.5 {
+		t.Errorf("unexpected values %d %q %q %q %v", *port, *host, *user, *tags, *ratio)
+	}
+	if *debug {
+		t.Error("command line value was overridden")
+	}
+	if src := fs.Lookup("port").Source(); src != pflag.SourceConfig {
+		t.Errorf("got source %v want %v", src, pflag.SourceConfig)
+	}
+
+	if _, err := Apply(fs, []byte(`{"port": "x"}`), JSON); err == nil {
+		t.Error("expected an error for an invalid value")
+	}
+	if _, err := Apply(fs, []byte(`[1]`), JSON); err == nil {
+		t.Error("expected an error for a document which is not a map")
+	}
+}
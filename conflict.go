@@ -0,0 +1,125 @@
+package pflag
+
+import "fmt"
+
+// ConflictPolicy tells AddFlagSetWithPolicy what to do with a flag whose name
+// or shorthand is already used in the FlagSet it is added to.
+type ConflictPolicy int
+
+const (
+	// ConflictPanic panics, like AddFlag does.
+	ConflictPanic ConflictPolicy = iota
+	// ConflictSkip keeps the flag already present. A flag which conflicts
+	// only by its shorthand is added without the shorthand.
+	ConflictSkip
+	// ConflictOverwrite replaces the flag already present. A flag already
+	// present which conflicts only by its shorthand loses the shorthand.
+	ConflictOverwrite
+	// ConflictPrefix adds the flag under a new name, made of the name of
+	// the FlagSet it comes from, a dash and its name. A flag which conflicts
+	// only by its shorthand is added without the shorthand.
+	ConflictPrefix
+)
+
+// Conflict describes a flag which collided with a flag already present in a
+// FlagSet while adding a FlagSet to it, and how it was resolved.
+type Conflict struct {
+	Name      string         // name of the added flag
+	Shorthand string         // the shorthand, if the collision was on the shorthand only
+	Existing  string         // name of the flag already present
+	Policy    ConflictPolicy // how the collision was resolved
+	NewName   string         // name the flag was added under, with ConflictPrefix
+}
+
+func (c Conflict) String() string {
+	if c.Shorthand != "" {
+		return fmt.Sprintf("shorthand -%s of flag --%s is already used by flag --%s", c.Shorthand, c.Name, c.Existing)
+	}
+	return fmt.Sprintf("flag --%s is already defined", c.Name)
+}
+
+// AddFlagSetWithPolicy adds the flags of newSet to f like AddFlagSet, but
+// resolves collisions of names and shorthands according to policy. It returns
+// the collisions which happened. An error is returned if a collision can't be
+// resolved, which only happens when the prefixed name of a flag is taken too.
+func (f *FlagSet) AddFlagSetWithPolicy(newSet *FlagSet, policy ConflictPolicy) ([]Conflict, error) {
+	if newSet == nil {
+		return nil, nil
+	}
+	var conflicts []Conflict
+	var err error
+	newSet.VisitAll(func(flag *Flag) {
+		if err != nil {
+			return
+		}
+		existing := f.formal[f.normalizeFlagName(flag.Name)]
+		if existing == flag {
+			return
+		}
+		var usedShorthand *Flag
+		if flag.Shorthand != "" {
+			usedShorthand = f.shorthands[flag.Shorthand]
+		}
+		if (existing == nil && usedShorthand == nil) || policy == ConflictPanic {
+			f.AddFlag(flag)
+			return
+		}
+
+		c := Conflict{Name: flag.Name, Policy: policy}
+		if existing != nil {
+			c.Existing = existing.Name
+		} else {
+			c.Shorthand = flag.Shorthand
+			c.Existing = usedShorthand.Name
+		}
+
+		switch policy {
+		case ConflictSkip:
+			if existing == nil {
+				f.AddFlag(withoutShorthand(flag))
+			}
+		case ConflictOverwrite:
+			if existing != nil {
+				f.Remove(existing.Name)
+			}
+			if flag.Shorthand != "" {
+				if used := f.shorthands[flag.Shorthand]; used != nil {
+					delete(f.shorthands, flag.Shorthand)
+					used.Shorthand = ""
+				}
+			}
+			f.AddFlag(flag)
+		case ConflictPrefix:
+			added := flag
+			if usedShorthand != nil {
+				added = withoutShorthand(flag)
+			}
+			if existing != nil {
+				if added == flag {
+					cp := *flag
+					added = &cp
+				}
+				added.Name = newSet.name + "-" + flag.Name
+				c.NewName = added.Name
+				if f.Lookup(added.Name) != nil {
+					err = fmt.Errorf("can not add flag %q as %q: flag %q is already defined", flag.Name, added.Name, added.Name)
+					return
+				}
+			}
+			f.AddFlag(added)
+		default:
+			err = fmt.Errorf("unknown conflict policy %d", policy)
+			return
+		}
+		conflicts = append(conflicts, c)
+	})
+	return conflicts, err
+}
+
+// withoutShorthand returns a copy of flag without its shorthand.
+func withoutShorthand(flag *Flag) *Flag {
+	cp := *flag
+	cp.Shorthand = ""
+	cp.ShorthandDeprecated = ""
+	return &cp
+}
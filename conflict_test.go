@@ -0,0 +1,72 @@
+package pflag
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func newConflictSets() (*FlagSet, *FlagSet) {
+	base := NewFlagSet("base", ContinueOnError)
+	base.StringP("output", "o", "base", "")
+	base.BoolP("verbose", "v", false, "")
+
+	lib := NewFlagSet("lib", ContinueOnError)
+	lib.StringP("output", "x", "lib", "")
+	lib.IntP("version", "v", 0, "")
+	lib.Int("other", 0, "")
+	return base, lib
+}
+
+func TestAddFlagSetWithPolicy(t *testing.T) {
+	base, lib := newConflictSets()
+	conflicts, err := base.AddFlagSetWithPolicy(lib, ConflictSkip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Conflict{
+		{Name: "output", Existing: "output", Policy: ConflictSkip},
+		{Name: "version", Shorthand: "v", Existing: "verbose", Policy: ConflictSkip},
+	}
+	if !reflect.DeepEqual(conflicts, want) {
+		t.Errorf("got %v want %v", conflicts, want)
+	}
+	if base.Lookup("output").DefValue != "base" || base.Lookup("version").Shorthand != "" || base.Lookup("other") == nil {
+		t.Error("unexpected flags after skipping")
+	}
+	if lib.Lookup("version").Shorthand != "v" {
+		t.Error("the added FlagSet was modified")
+	}
+
+	base, lib = newConflictSets()
+	if _, err := base.AddFlagSetWithPolicy(lib, ConflictOverwrite); err != nil {
+		t.Fatal(err)
+	}
+	if base.Lookup("output").DefValue != "lib" || base.ShorthandLookup("v").Name != "version" || base.Lookup("verbose").Shorthand != "" {
+		t.Error("unexpected flags after overwriting")
+	}
+
+	base, lib = newConflictSets()
+	conflicts, err = base.AddFlagSetWithPolicy(lib, ConflictPrefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conflicts[0].NewName != "lib-output" || base.Lookup("lib-output").DefValue != "lib" || base.Lookup("output").DefValue != "base" {
+		t.Errorf("unexpected flags after prefixing: %v", conflicts)
+	}
+	if lib.Lookup("output").Name != "output" {
+		t.Error("the added FlagSet was modified")
+	}
+	if _, err := base.AddFlagSetWithPolicy(lib, ConflictPrefix); err == nil {
+		t.Error("expected an error when the prefixed name is taken")
+	}
+
+	base, lib = newConflictSets()
+	base.SetOutput(ioutil.Discard)
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic")
+		}
+	}()
+	base.AddFlagSetWithPolicy(lib, ConflictPanic)
+}
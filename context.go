@@ -0,0 +1,56 @@
+package pflag
+
+import "context"
+
+// ParseContext parses the arguments like Parse, making ctx available to the
+// PostParse hooks, lazy defaults and other callbacks run while parsing
+// through Context. If ctx is done before parsing completes, ParseContext
+// stops and returns ctx.Err(), regardless of the ErrorHandling of the
+// FlagSet.
+func (f *FlagSet) ParseContext(ctx context.Context, arguments []string) error {
+	f.ctx = ctx
+	defer func() { f.ctx = nil }()
+	return f.Parse(arguments)
+}
+
+// Context returns the context given to ParseContext while it is parsing, and
+// context.Background() otherwise.
+func (f *FlagSet) Context() context.Context {
+	if f.ctx == nil {
+		return context.Background()
+	}
+	return f.ctx
+}
+
+// SetLazyDefaultContext is like SetLazyDefault, but fn is called with the
+// context of the FlagSet, see Context.
+func (f *FlagSet) SetLazyDefaultContext(name string, fn func(ctx context.Context) string) error {
+	return f.SetLazyDefault(name, func() string { return fn(f.Context()) })
+}
+
+// contextErr returns the error of the context given to ParseContext, if it
+// is done.
+func (f *FlagSet) contextErr() error {
+	if f.ctx == nil {
+		return nil
+	}
+	return f.ctx.Err()
+}
+
+// contextKey is the type of the key of the FlagSet in contexts, unexported
+// to avoid collisions with the keys of other packages.
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying fs, so that code far from the
+// command line parsing, like the handlers of a server, can read the flags
+// through FromContext.
+func NewContext(ctx context.Context, fs *FlagSet) context.Context {
+	return context.WithValue(ctx, contextKey{}, fs)
+}
+
+// FromContext returns the FlagSet carried by ctx, as added by NewContext, and
+// whether there is one.
+func FromContext(ctx context.Context) (*FlagSet, bool) {
+	fs, ok := ctx.Value(contextKey{}).(*FlagSet)
+	return fs, ok && fs != nil
+}
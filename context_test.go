@@ -0,0 +1,61 @@
+package pflag
+
+import (
+	"context"
+	"testing"
+)
+
+type ctxKey struct{}
+
+func TestParseContext(t *testing.T) {
+	f := NewFlagSet("test", PanicOnError)
+	region := f.String("region", "", "")
+	f.Bool("verbose", false, "")
+	f.SetLazyDefaultContext("region", func(ctx context.Context) string {
+		return ctx.Value(ctxKey{}).(string)
+	})
+	var hookValue interface{}
+	f.PostParse(func(f *FlagSet) error {
+		hookValue = f.Context().Value(ctxKey{})
+		return nil
+	})
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "eu-west")
+	if err := f.ParseContext(ctx, []string{"--verbose"}); err != nil {
+		t.Fatal(err)
+	}
+	if *region != "eu-west" || hookValue != "eu-west" {
+		t.Errorf("context not threaded: %q %v", *region, hookValue)
+	}
+	if f.Context() != context.Background() {
+		t.Error("context kept after ParseContext returned")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := f.ParseContext(ctx, []string{"--verbose"}); err != context.Canceled {
+		t.Errorf("got error %v want %v", err, context.Canceled)
+	}
+}
+
+func TestFlagSetContext(t *testing.T) {
+	if _, ok := FromContext(context.Background()); ok {
+		t.Error("expected no FlagSet in an empty context")
+	}
+	f := NewFlagSet("test", ContinueOnError)
+	port := f.Int("port", 80, "")
+	if err := f.Parse([]string{"--port=8080"}); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.WithValue(NewContext(context.Background(), f), ctxKey{}, "other")
+	fs, ok := FromContext(ctx)
+	if !ok || fs != f {
+		t.Fatalf("got %p, %v, expected %p", fs, ok, f)
+	}
+	if v, err := fs.GetInt("port"); err != nil || v != *port {
+		t.Errorf("got %d, %v", v, err)
+	}
+	if _, ok := FromContext(NewContext(context.Background(), nil)); ok {
+		t.Error("expected no FlagSet for a nil one")
+	}
+}
@@ -27,6 +27,8 @@ func (i *countValue) Type() string {
 
 func (i *countValue) String() string { return strconv.Itoa(int(*i)) }
 
+func (i *countValue) DefaultArg() string { return "-1" }
+
 func countConv(sval string) (interface{}, error) {
 	i, err := strconv.Atoi(sval)
 	if err != nil {
@@ -53,8 +55,7 @@ func (f *FlagSet) CountVar(p *int, name string, usage string) {
 
 // CountVarP is like CountVar only take a shorthand for the flag name.
 func (f *FlagSet) CountVarP(p *int, name, shorthand string, usage string) {
-	flag := f.VarPF(newCountValue(0, p), name, shorthand, usage)
-	flag.NoOptDefVal = "-1"
+	f.VarP(newCountValue(0, p), name, shorthand, usage)
 }
 
 // CountVar like CountVar only the flag is placed on the CommandLine instead of a given flag set
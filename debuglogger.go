@@ -0,0 +1,6 @@
+package pflag
+
+// debugLogger logs a debug event about parsing, with args holding alternate
+// keys and values, see SetLogger. It is only called when set, so that the
+// arguments are not even computed otherwise.
+type debugLogger func(msg string, args ...interface{})
@@ -0,0 +1,252 @@
+package pflag
+
+import (
+	"encoding"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FixedDecimal is an exact decimal number, Units × 10^Exponent, as given to
+// flags defined with Decimal. Its scale is kept as written, so "1.50" has 150
+// Units and an Exponent of -2. A nil Units is 0.
+type FixedDecimal struct {
+	Units    *big.Int
+	Exponent int32
+}
+
+// maxDecimalExponent bounds the exponents of the numbers ParseDecimal
+// accepts, since String and Rat expand them into as many digits.
+const maxDecimalExponent = 1000
+
+// ParseDecimal parses s as an exact decimal number, as in "12", "-0.050" or
+// "1.5e3". Infinities and NaNs are rejected, as are numbers whose exponent,
+// once the digits after the decimal point are counted in, is beyond ±1000.
+func ParseDecimal(s string) (FixedDecimal, error) {
+	invalid := fmt.Errorf("invalid decimal %q", s)
+	digits := strings.TrimSpace(s)
+	var sign string
+	if digits != "" && (digits[0] == '-' || digits[0] == '+') {
+		sign, digits = digits[:1], digits[1:]
+	}
+	var exp int64
+	if i := strings.IndexAny(digits, "eE"); i >= 0 {
+		var err error
+		if exp, err = strconv.ParseInt(digits[i+1:], 10, 32); err != nil {
+			return FixedDecimal{}, invalid
+		}
+		digits = digits[:i]
+	}
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		exp -= int64(len(digits) - i - 1)
+		digits = digits[:i] + digits[i+1:]
+	}
+	if digits == "" || !isDigits(digits) || exp < -maxDecimalExponent || exp > maxDecimalExponent {
+		return FixedDecimal{}, invalid
+	}
+	units, _ := new(big.Int).SetString(sign+digits, 10)
+	return FixedDecimal{Units: units, Exponent: int32(exp)}, nil
+}
+
+// String returns d in decimal notation, without an exponent and with as many
+// digits after the decimal point as its scale, as in "1.50".
+func (d FixedDecimal) String() string {
+	if d.Units == nil {
+		return "0"
+	}
+	s := new(big.Int).Abs(d.Units).String()
+	if d.Exponent > 0 {
+		s += strings.Repeat("0", int(d.Exponent))
+	} else if d.Exponent < 0 {
+		scale := int(-d.Exponent)
+		if len(s) <= scale {
+			s = strings.Repeat("0", scale-len(s)+1) + s
+		}
+		s = s[:len(s)-scale] + "." + s[len(s)-scale:]
+	}
+	if d.Units.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// Rat returns d as an exact rational number.
+func (d FixedDecimal) Rat() *big.Rat {
+	r := new(big.Rat)
+	if d.Units == nil {
+		return r
+	}
+	r.SetInt(d.Units)
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs32(d.Exponent))), nil)
+	if d.Exponent >= 0 {
+		return r.Mul(r, new(big.Rat).SetInt(pow))
+	}
+	return r.Quo(r, new(big.Rat).SetInt(pow))
+}
+
+// Float64 returns the float64 nearest to d.
+func (d FixedDecimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+func abs32(n int32) int64 {
+	if n < 0 {
+		return -int64(n)
+	}
+	return int64(n)
+}
+
+// DecimalText is implemented by the decimal types of popular libraries,
+// like *decimal.Decimal of github.com/shopspring/decimal or *apd.Decimal of
+// github.com/cockroachdb/apd, so that they can be set by flags defined with
+// DecimalTextVar.
+type DecimalText interface {
+	encoding.TextUnmarshaler
+	String() string
+}
+
+// -- FixedDecimal Value
+type decimalValue FixedDecimal
+
+func newDecimalValue(val FixedDecimal, p *FixedDecimal) *decimalValue {
+	*p = val
+	return (*decimalValue)(p)
+}
+
+func (d *decimalValue) Set(s string) error {
+	v, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = decimalValue(v)
+	return nil
+}
+
+func (d *decimalValue) Type() string {
+	return "decimal"
+}
+
+func (d *decimalValue) String() string { return FixedDecimal(*d).String() }
+
+// -- DecimalText Value
+type decimalTextValue struct {
+	value DecimalText
+}
+
+func (d decimalTextValue) Set(s string) error {
+	// The syntax is checked first, so that all libraries accept the same values.
+	if _, err := ParseDecimal(s); err != nil {
+		return err
+	}
+	return d.value.UnmarshalText([]byte(strings.TrimSpace(s)))
+}
+
+func (d decimalTextValue) Type() string {
+	return "decimal"
+}
+
+func (d decimalTextValue) String() string { return d.value.String() }
+
+// CloneValue returns a Value setting a new variable of the type of the one
+// d sets, holding the same value.
+func (d decimalTextValue) CloneValue() Value {
+	rv := reflect.ValueOf(d.value)
+	if rv.Kind() != reflect.Ptr {
+		return d
+	}
+	c := reflect.New(rv.Elem().Type()).Interface().(DecimalText)
+	c.UnmarshalText([]byte(d.value.String()))
+	return decimalTextValue{c}
+}
+
+func decimalConv(sval string) (interface{}, error) {
+	return ParseDecimal(sval)
+}
+
+// GetDecimal return the FixedDecimal value of a flag with the given name
+func (f *FlagSet) GetDecimal(name string) (FixedDecimal, error) {
+	val, err := f.getFlagType(name, "decimal", decimalConv)
+	if err != nil {
+		return FixedDecimal{}, err
+	}
+	return val.(FixedDecimal), nil
+}
+
+// DecimalVar defines a FixedDecimal flag with specified name, default value, and usage string.
+// The value is parsed exactly, keeping its scale, see ParseDecimal.
+// The argument p points to a FixedDecimal variable in which to store the value of the flag.
+func (f *FlagSet) DecimalVar(p *FixedDecimal, name string, value FixedDecimal, usage string) {
+	f.VarP(newDecimalValue(value, p), name, "", usage)
+}
+
+// DecimalVarP is like DecimalVar, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) DecimalVarP(p *FixedDecimal, name, shorthand string, value FixedDecimal, usage string) {
+	f.VarP(newDecimalValue(value, p), name, shorthand, usage)
+}
+
+// DecimalVar defines a FixedDecimal flag with specified name, default value, and usage string.
+// The value is parsed exactly, keeping its scale, see ParseDecimal.
+// The argument p points to a FixedDecimal variable in which to store the value of the flag.
+func DecimalVar(p *FixedDecimal, name string, value FixedDecimal, usage string) {
+	CommandLine.VarP(newDecimalValue(value, p), name, "", usage)
+}
+
+// DecimalVarP is like DecimalVar, but accepts a shorthand letter that can be used after a single dash.
+func DecimalVarP(p *FixedDecimal, name, shorthand string, value FixedDecimal, usage string) {
+	CommandLine.VarP(newDecimalValue(value, p), name, shorthand, usage)
+}
+
+// Decimal defines a FixedDecimal flag with specified name, default value, and usage string.
+// The value is parsed exactly, keeping its scale, see ParseDecimal.
+// The return value is the address of a FixedDecimal variable that stores the value of the flag.
+func (f *FlagSet) Decimal(name string, value FixedDecimal, usage string) *FixedDecimal {
+	p := new(FixedDecimal)
+	f.DecimalVarP(p, name, "", value, usage)
+	return p
+}
+
+// DecimalP is like Decimal, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) DecimalP(name, shorthand string, value FixedDecimal, usage string) *FixedDecimal {
+	p := new(FixedDecimal)
+	f.DecimalVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// Decimal defines a FixedDecimal flag with specified name, default value, and usage string.
+// The value is parsed exactly, keeping its scale, see ParseDecimal.
+// The return value is the address of a FixedDecimal variable that stores the value of the flag.
+func Decimal(name string, value FixedDecimal, usage string) *FixedDecimal {
+	return CommandLine.DecimalP(name, "", value, usage)
+}
+
+// DecimalP is like Decimal, but accepts a shorthand letter that can be used after a single dash.
+func DecimalP(name, shorthand string, value FixedDecimal, usage string) *FixedDecimal {
+	return CommandLine.DecimalP(name, shorthand, value, usage)
+}
+
+// DecimalTextVar defines a decimal flag with specified name and usage string, setting p,
+// whose default value is the current value of p. The value must be a decimal number as
+// read by ParseDecimal, and is passed to the UnmarshalText method of p.
+func (f *FlagSet) DecimalTextVar(p DecimalText, name string, usage string) {
+	f.VarP(decimalTextValue{p}, name, "", usage)
+}
+
+// DecimalTextVarP is like DecimalTextVar, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) DecimalTextVarP(p DecimalText, name, shorthand string, usage string) {
+	f.VarP(decimalTextValue{p}, name, shorthand, usage)
+}
+
+// DecimalTextVar defines a decimal flag with specified name and usage string, setting p,
+// whose default value is the current value of p. The value must be a decimal number as
+// read by ParseDecimal, and is passed to the UnmarshalText method of p.
+func DecimalTextVar(p DecimalText, name string, usage string) {
+	CommandLine.VarP(decimalTextValue{p}, name, "", usage)
+}
+
+// DecimalTextVarP is like DecimalTextVar, but accepts a shorthand letter that can be used after a single dash.
+func DecimalTextVarP(p DecimalText, name, shorthand string, usage string) {
+	CommandLine.VarP(decimalTextValue{p}, name, shorthand, usage)
+}
@@ -0,0 +1,108 @@
+package pflag
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// textDecimal stands for the decimal type of a library, see DecimalText.
+type textDecimal struct {
+	text string
+}
+
+func (d *textDecimal) UnmarshalText(text []byte) error {
+	d.text = "lib:" + strings.TrimPrefix(string(text), "lib:")
+	return nil
+}
+
+func (d *textDecimal) String() string { return d.text }
+
+func TestDecimal(t *testing.T) {
+	tests := []struct {
+		in    string
+		units int64
+		exp   int32
+		str   string
+	}{
+		{"12", 12, 0, "12"},
+		{"1.50", 150, -2, "1.50"},
+		{"-0.050", -50, -3, "-0.050"},
+		{"+.5", 5, -1, "0.5"},
+		{"1.5e3", 15, 2, "1500"},
+		{"25E-4", 25, -4, "0.0025"},
+	}
+	for _, test := range tests {
+		f := NewFlagSet("test", ContinueOnError)
+		amount := f.DecimalP("amount", "a", FixedDecimal{}, "")
+		if err := f.Parse([]string{"-a", test.in}); err != nil {
+			t.Errorf("%s: %v", test.in, err)
+			continue
+		}
+		if amount.Units.Int64() != test.units || amount.Exponent != test.exp {
+			t.Errorf("%s: got %v×10^%d, want %d×10^%d", test.in, amount.Units, amount.Exponent, test.units, test.exp)
+		}
+		if got := f.Lookup("amount").Value.String(); got != test.str {
+			t.Errorf("%s: got string %q, want %q", test.in, got, test.str)
+		}
+		if v, err := f.GetDecimal("amount"); err != nil || v.String() != test.str {
+			t.Errorf("%s: GetDecimal returned %v, %v", test.in, v, err)
+		}
+	}
+
+	for _, in := range []string{"", "-", ".", "1.2.3", "1e", "0x10", "NaN", "Inf", "1,5", "1e2147483647", "1e1001", "0.1e-1000"} {
+		if _, err := ParseDecimal(in); err == nil {
+			t.Errorf("%q: expected an error", in)
+		}
+	}
+
+	d, _ := ParseDecimal("0.1")
+	if r := d.Rat(); r.Cmp(big.NewRat(1, 10)) != 0 {
+		t.Errorf("got %v, want 1/10", r)
+	}
+	if d.Float64() != 0.1 {
+		t.Errorf("got %v, want 0.1", d.Float64())
+	}
+	if (FixedDecimal{}).String() != "0" {
+		t.Error("the zero decimal should be 0")
+	}
+
+	f := NewFlagSet("test", ContinueOnError)
+	price, _ := ParseDecimal("9.99")
+	f.Decimal("price", price, "")
+	c, err := f.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Set("price", "19.99"); err != nil {
+		t.Fatal(err)
+	}
+	if price.String() != "9.99" || f.Lookup("price").Value.String() != "9.99" {
+		t.Error("setting a clone changed the original")
+	}
+}
+
+func TestDecimalText(t *testing.T) {
+	v := &textDecimal{"lib:0"}
+	f := NewFlagSet("test", ContinueOnError)
+	f.DecimalTextVarP(v, "fee", "f", "")
+	if got := f.Lookup("fee").DefValue; got != "lib:0" {
+		t.Errorf("got default %q", got)
+	}
+	if err := f.Parse([]string{"-f", "0.25"}); err != nil {
+		t.Fatal(err)
+	}
+	if v.text != "lib:0.25" {
+		t.Errorf("got %q, want lib:0.25", v.text)
+	}
+	if err := f.Set("fee", "abc"); err == nil {
+		t.Error("expected an error for an invalid decimal")
+	}
+	c, err := f.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Set("fee", "1"); err != nil || v.text != "lib:0.25" {
+		t.Errorf("setting a clone changed the original to %q, %v", v.text, err)
+	}
+}
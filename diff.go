@@ -0,0 +1,39 @@
+package pflag
+
+// FlagDiff is a flag whose value differs from its default, see Diff. The
+// values are redacted if the flag is sensitive.
+type FlagDiff struct {
+	Name    string
+	Default string
+	Value   string
+}
+
+// ChangedValues returns the current values of the flags which have been
+// set, by their names, e.g. to log what the user overrode at startup. The
+// values of sensitive flags are redacted.
+func (f *FlagSet) ChangedValues() map[string]string {
+	values := make(map[string]string, len(f.actual))
+	for _, flag := range f.actual {
+		values[flag.Name] = redact(flag, flag.Value.String())
+	}
+	return values
+}
+
+// Diff returns the flags whose current value differs from their default,
+// in VisitAll order. Unlike ChangedValues, it compares the values, so flags
+// set to their default value are left out, and flags whose variable was
+// modified by the program are included.
+func (f *FlagSet) Diff() []FlagDiff {
+	var diff []FlagDiff
+	f.VisitAll(func(flag *Flag) {
+		flag.resolveDefault()
+		if value := flag.Value.String(); value != flag.DefValue {
+			diff = append(diff, FlagDiff{
+				Name:    flag.Name,
+				Default: redact(flag, flag.DefValue),
+				Value:   redact(flag, value),
+			})
+		}
+	})
+	return diff
+}
@@ -0,0 +1,39 @@
+package pflag
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChangedValuesAndDiff(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.Int("port", 80, "")
+	f.String("host", "localhost", "")
+	f.String("token", "", "")
+	f.StringSlice("tags", nil, "")
+	verbose := f.Bool("verbose", false, "")
+	f.MarkSensitive("token")
+	if err := f.Parse([]string{"--port=8080", "--host=localhost", "--token=secret", "--tags=a,b"}); err != nil {
+		t.Fatal(err)
+	}
+	*verbose = true
+
+	wantChanged := map[string]string{"port": "8080", "host": "localhost", "token": "****", "tags": "[a,b]"}
+	if got := f.ChangedValues(); !reflect.DeepEqual(got, wantChanged) {
+		t.Errorf("got changed values %v, want %v", got, wantChanged)
+	}
+
+	wantDiff := []FlagDiff{
+		{Name: "port", Default: "80", Value: "8080"},
+		{Name: "tags", Default: "[]", Value: "[a,b]"},
+		{Name: "token", Default: "", Value: "****"},
+		{Name: "verbose", Default: "false", Value: "true"},
+	}
+	if got := f.Diff(); !reflect.DeepEqual(got, wantDiff) {
+		t.Errorf("got diff %+v, want %+v", got, wantDiff)
+	}
+
+	if got := NewFlagSet("empty", ContinueOnError).ChangedValues(); len(got) != 0 {
+		t.Errorf("got %v for an empty FlagSet", got)
+	}
+}
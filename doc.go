@@ -0,0 +1,113 @@
+package pflag
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// markdownCell escapes s so it can be used in a cell of a markdown table.
+func markdownCell(s string) string {
+	s = strings.Replace(s, "|", `\|`, -1)
+	return strings.Replace(s, "\n", "<br>", -1)
+}
+
+// markdownCode renders s as inline code, or returns the empty string if s is empty.
+func markdownCode(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "`" + markdownCell(s) + "`"
+}
+
+// GenMarkdownDocs writes a markdown table documenting the flags of fs which
+// are shown in help and usage messages, in VisitAll order.
+func GenMarkdownDocs(fs *FlagSet, w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "| Flag | Shorthand | Type | Default | Usage |"); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, "|------|-----------|------|---------|-------|"); err != nil {
+		return err
+	}
+	for _, u := range fs.flagUsages() {
+		def := u.Flag.DefaultValueText()
+		usage := markdownCell(u.Usage)
+		if u.Semantics != "" {
+			usage += " " + markdownCell(u.Semantics)
+		}
+		if u.Versions != "" {
+			usage += " " + markdownCell(u.Versions)
+		}
+		if u.Example != "" {
+			usage += "<br>" + fmt.Sprintf(markdownCell(fs.msg(MsgExample)), markdownCode(u.Example))
+		}
+		var short string
+		if u.Shorthand != "" {
+			short = "-" + u.Shorthand
+		}
+		_, err := fmt.Fprintf(w, "| %s | %s | %s | %s | %s |\n",
+			markdownCode("--"+u.Name),
+			markdownCode(short),
+			markdownCell(u.Type),
+			markdownCode(def),
+			usage)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// roffEscape escapes s for use in roff text.
+func roffEscape(s string) string {
+	s = strings.Replace(s, `\`, `\e`, -1)
+	s = strings.Replace(s, "-", `\-`, -1)
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		// Lines starting with a control character would be taken for requests.
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// GenManOptionsSection writes the roff source of an OPTIONS man page section
+// documenting the flags of fs which are shown in help and usage messages.
+// Flag names are set in bold and argument placeholders in italics.
+func GenManOptionsSection(fs *FlagSet, w io.Writer) error {
+	buf := new(bytes.Buffer)
+	buf.WriteString(".SH OPTIONS\n")
+	for _, u := range fs.flagUsages() {
+		buf.WriteString(".TP\n")
+		if u.Shorthand != "" {
+			fmt.Fprintf(buf, `\fB%s\fP, `, roffEscape("-"+u.Shorthand))
+		}
+		fmt.Fprintf(buf, `\fB%s\fP`, roffEscape("--"+u.Name))
+		if arg := u.Flag.DefaultArgText(); arg != "" {
+			if u.OptionalArg != "" {
+				fmt.Fprintf(buf, `[=\fI%s\fP]`, roffEscape(arg))
+			}
+		} else if u.Varname != "" {
+			fmt.Fprintf(buf, `=\fI%s\fP`, roffEscape(u.Varname))
+		}
+		buf.WriteString("\n")
+		usage := u.Usage
+		if u.Default != "" {
+			usage += " " + u.Default
+		}
+		if u.Semantics != "" {
+			usage += " " + u.Semantics
+		}
+		if u.Versions != "" {
+			usage += " " + u.Versions
+		}
+		buf.WriteString(roffEscape(usage) + "\n")
+		if u.Example != "" {
+			fmt.Fprintf(buf, ".br\n"+roffEscape(fs.msg(MsgExample))+"\n", `\fB`+roffEscape(u.Example)+`\fP`)
+		}
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
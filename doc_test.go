@@ -0,0 +1,53 @@
+package pflag
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestGenMarkdownDocs(t *testing.T) {
+	fs := NewFlagSet("docs", ContinueOnError)
+	fs.StringP("output", "o", "json", "output `format`, json|yaml")
+	fs.Int("retries", 0, "number of retries")
+	fs.Bool("secret", false, "hidden")
+	fs.MarkHidden("secret")
+
+	var buf bytes.Buffer
+	if err := GenMarkdownDocs(fs, &buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "| Flag | Shorthand | Type | Default | Usage |\n" +
+		"|------|-----------|------|---------|-------|\n" +
+		"| `--output` | `-o` | string | `json` | output format, json\\|yaml |\n" +
+		"| `--retries` |  | int |  | number of retries |\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+}
+
+func TestGenManOptionsSection(t *testing.T) {
+	fs := NewFlagSet("docs", ContinueOnError)
+	fs.StringP("output", "o", "json", "output `format`")
+	fs.String("color", "never", ".colorize output")
+	fs.Lookup("color").NoOptDefVal = "auto"
+	fs.BoolP("verbose", "v", false, "be verbose")
+
+	var buf bytes.Buffer
+	if err := GenManOptionsSection(fs, &buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `.SH OPTIONS
+.TP
+\fB\-\-color\fP[=\fIauto\fP]
+\&.colorize output (default "never")
+.TP
+\fB\-o\fP, \fB\-\-output\fP=\fIformat\fP
+output format (default "json")
+.TP
+\fB\-v\fP, \fB\-\-verbose\fP
+be verbose
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+}
@@ -0,0 +1,188 @@
+package pflag
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// -- time.Duration Value with day and week units
+type durationExtValue time.Duration
+
+func newDurationExtValue(val time.Duration, p *time.Duration) *durationExtValue {
+	*p = val
+	return (*durationExtValue)(p)
+}
+
+func (d *durationExtValue) Set(s string) error {
+	v, err := parseDurationExt(s)
+	if err != nil {
+		return err
+	}
+	*d = durationExtValue(v)
+	return nil
+}
+
+func (d *durationExtValue) Type() string {
+	return "durationExt"
+}
+
+func (d *durationExtValue) String() string { return formatDurationExt(time.Duration(*d)) }
+
+// parseDurationExt parses a duration like time.ParseDuration does, also
+// accepting the "d" unit for days of 24 hours and "w" for weeks of 7 days, as
+// in "2w", "1d12h" or "1.5d".
+func parseDurationExt(s string) (time.Duration, error) {
+	invalid := fmt.Errorf("time: invalid duration %q", s)
+	rest := s
+	neg := false
+	if rest != "" && (rest[0] == '-' || rest[0] == '+') {
+		neg = rest[0] == '-'
+		rest = rest[1:]
+	}
+	if rest == "" {
+		return 0, invalid
+	}
+	// Days and weeks are summed here, the other units by time.ParseDuration.
+	var days float64
+	var std string
+	for rest != "" {
+		i := strings.IndexFunc(rest, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+		if i == 0 {
+			return 0, invalid
+		}
+		if i < 0 {
+			i = len(rest)
+		}
+		j := strings.IndexFunc(rest[i:], func(r rune) bool { return (r >= '0' && r <= '9') || r == '.' })
+		if j < 0 {
+			j = len(rest)
+		} else {
+			j += i
+		}
+		num, unit := rest[:i], rest[i:j]
+		rest = rest[j:]
+		if unit != "d" && unit != "w" {
+			std += num + unit
+			continue
+		}
+		n, err := strconv.ParseFloat(num, 64)
+		if err != nil {
+			return 0, invalid
+		}
+		if unit == "w" {
+			n *= 7
+		}
+		days += n
+	}
+	var d time.Duration
+	if std != "" {
+		var err error
+		if d, err = time.ParseDuration(std); err != nil {
+			return 0, invalid
+		}
+	}
+	total := days*float64(24*time.Hour) + float64(d)
+	if total >= math.MaxInt64 { // float64(math.MaxInt64) is 1<<63
+		return 0, fmt.Errorf("time: invalid duration %q", s)
+	}
+	d = time.Duration(total)
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+// formatDurationExt formats d in the form parseDurationExt reads, with whole
+// days in the "d" unit and without trailing zero units, as in "1d12h".
+func formatDurationExt(d time.Duration) string {
+	var sign string
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	if days == 0 {
+		return sign + d.String()
+	}
+	s := sign + strconv.FormatInt(int64(days), 10) + "d"
+	if d == 0 {
+		return s
+	}
+	rem := d.String()
+	if strings.HasSuffix(rem, "m0s") {
+		rem = rem[:len(rem)-2]
+	}
+	if strings.HasSuffix(rem, "h0m") {
+		rem = rem[:len(rem)-2]
+	}
+	return s + rem
+}
+
+func durationExtConv(sval string) (interface{}, error) {
+	return parseDurationExt(sval)
+}
+
+// GetDurationExt return the duration value of a flag defined with DurationExt with the given name
+func (f *FlagSet) GetDurationExt(name string) (time.Duration, error) {
+	val, err := f.getFlagType(name, "durationExt", durationExtConv)
+	if err != nil {
+		return 0, err
+	}
+	return val.(time.Duration), nil
+}
+
+// DurationExtVar defines a time.Duration flag with specified name, default value, and usage string.
+// Unlike with DurationVar, the value can be given in days and weeks, as in "3d", "2w" or "1d12h".
+// The argument p points to a time.Duration variable in which to store the value of the flag.
+func (f *FlagSet) DurationExtVar(p *time.Duration, name string, value time.Duration, usage string) {
+	f.VarP(newDurationExtValue(value, p), name, "", usage)
+}
+
+// DurationExtVarP is like DurationExtVar, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) DurationExtVarP(p *time.Duration, name, shorthand string, value time.Duration, usage string) {
+	f.VarP(newDurationExtValue(value, p), name, shorthand, usage)
+}
+
+// DurationExtVar defines a time.Duration flag with specified name, default value, and usage string.
+// Unlike with DurationVar, the value can be given in days and weeks, as in "3d", "2w" or "1d12h".
+// The argument p points to a time.Duration variable in which to store the value of the flag.
+func DurationExtVar(p *time.Duration, name string, value time.Duration, usage string) {
+	CommandLine.VarP(newDurationExtValue(value, p), name, "", usage)
+}
+
+// DurationExtVarP is like DurationExtVar, but accepts a shorthand letter that can be used after a single dash.
+func DurationExtVarP(p *time.Duration, name, shorthand string, value time.Duration, usage string) {
+	CommandLine.VarP(newDurationExtValue(value, p), name, shorthand, usage)
+}
+
+// DurationExt defines a time.Duration flag with specified name, default value, and usage string.
+// Unlike with Duration, the value can be given in days and weeks, as in "3d", "2w" or "1d12h".
+// The return value is the address of a time.Duration variable that stores the value of the flag.
+func (f *FlagSet) DurationExt(name string, value time.Duration, usage string) *time.Duration {
+	p := new(time.Duration)
+	f.DurationExtVarP(p, name, "", value, usage)
+	return p
+}
+
+// DurationExtP is like DurationExt, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) DurationExtP(name, shorthand string, value time.Duration, usage string) *time.Duration {
+	p := new(time.Duration)
+	f.DurationExtVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// DurationExt defines a time.Duration flag with specified name, default value, and usage string.
+// Unlike with Duration, the value can be given in days and weeks, as in "3d", "2w" or "1d12h".
+// The return value is the address of a time.Duration variable that stores the value of the flag.
+func DurationExt(name string, value time.Duration, usage string) *time.Duration {
+	return CommandLine.DurationExtP(name, "", value, usage)
+}
+
+// DurationExtP is like DurationExt, but accepts a shorthand letter that can be used after a single dash.
+func DurationExtP(name, shorthand string, value time.Duration, usage string) *time.Duration {
+	return CommandLine.DurationExtP(name, shorthand, value, usage)
+}
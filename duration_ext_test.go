@@ -0,0 +1,55 @@
+package pflag
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDurationExt(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+		str  string
+	}{
+		{"0", 0, "0s"},
+		{"90m", 90 * time.Minute, "1h30m0s"},
+		{"3d", 72 * time.Hour, "3d"},
+		{"2w", 14 * 24 * time.Hour, "14d"},
+		{"1d12h", 36 * time.Hour, "1d12h"},
+		{"1.5d", 36 * time.Hour, "1d12h"},
+		{"1d30m", 24*time.Hour + 30*time.Minute, "1d30m"},
+		{"1w1d1h1m1s", 8*24*time.Hour + time.Hour + time.Minute + time.Second, "8d1h1m1s"},
+		{"-1d", -24 * time.Hour, "-1d"},
+	}
+	for _, test := range tests {
+		f := NewFlagSet("test", ContinueOnError)
+		d := f.DurationExt("retention", 0, "")
+		if err := f.Parse([]string{"--retention=" + test.in}); err != nil {
+			t.Errorf("%s: %v", test.in, err)
+			continue
+		}
+		if *d != test.want {
+			t.Errorf("%s: got %v, want %v", test.in, *d, test.want)
+		}
+		if got := f.Lookup("retention").Value.String(); got != test.str {
+			t.Errorf("%s: got string %q, want %q", test.in, got, test.str)
+		}
+		if v, err := f.GetDurationExt("retention"); err != nil || v != test.want {
+			t.Errorf("%s: GetDurationExt returned %v, %v", test.in, v, err)
+		}
+	}
+
+	for _, in := range []string{"", "d", "3", "3x", "1.2.3d", "-", "200000w", "106751d23h47m16.854775807s", "0d9223372036854775807ns"} {
+		f := NewFlagSet("test", ContinueOnError)
+		f.DurationExt("retention", 0, "")
+		if err := f.Set("retention", in); err == nil {
+			t.Errorf("%q: expected an error", in)
+		}
+	}
+
+	f := NewFlagSet("test", ContinueOnError)
+	f.DurationExtP("retention", "r", 7*24*time.Hour, "keep backups for `period`")
+	if got, want := f.FlagUsages(), "  -r, --retention period   keep backups for period (default 7d)\n"; got != want {
+		t.Errorf("got usage %q, want %q", got, want)
+	}
+}
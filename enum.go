@@ -0,0 +1,87 @@
+package pflag
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Enum describes the values allowed for the flags of an enum-like type, see
+// RegisterEnum.
+type Enum struct {
+	// Values returns the allowed values, in the order they are listed in.
+	// It is called every time they are needed, so that they can change.
+	Values func() []string
+	// Hint is the name of the argument of the flags in help, like "level",
+	// in place of their type. Flags naming their argument in their usage
+	// message or with SetArgName keep it.
+	Hint string
+}
+
+// enums holds the Enums registered with RegisterEnum, by type, guarded by
+// enumsMu.
+var (
+	enumsMu sync.RWMutex
+	enums   = map[string]Enum{}
+)
+
+// RegisterEnum registers the values allowed for the flags whose Value.Type
+// returns typ, typically custom Values with a fixed set of values. Help and
+// usage messages then list the allowed values, as "(one of a, b, c)", the
+// flags can only be set to one of them, with an error listing them
+// otherwise, and they are the completions of the values of the flags. The
+// choices set by SetChoices and the completions registered with
+// RegisterCompletion take precedence for individual flags.
+//
+// Types are shared by all packages, so they should be qualified, like
+// "mypkg.Mode", to avoid collisions: RegisterEnum panics if typ is already
+// registered. It is safe for concurrent use, but is meant to be called from
+// init functions, before flags are parsed.
+func RegisterEnum(typ string, enum Enum) {
+	enumsMu.Lock()
+	defer enumsMu.Unlock()
+	if _, dup := enums[typ]; dup {
+		panic(fmt.Sprintf("pflag: RegisterEnum called twice for type %q", typ))
+	}
+	enums[typ] = enum
+}
+
+// lookupEnum returns the Enum registered for typ, if any.
+func lookupEnum(typ string) (Enum, bool) {
+	enumsMu.RLock()
+	defer enumsMu.RUnlock()
+	e, ok := enums[typ]
+	return e, ok
+}
+
+// allowedValues returns the values flag can be set to, set by SetChoices or
+// RegisterEnum, or nil if any value is allowed.
+func allowedValues(flag *Flag) []string {
+	if flag.choices != nil {
+		return flag.choices
+	}
+	if e, ok := lookupEnum(flag.Value.Type()); ok && e.Values != nil {
+		return e.Values()
+	}
+	return nil
+}
+
+// enumHint returns the name of the argument of flag registered with
+// RegisterEnum, if any.
+func enumHint(flag *Flag) string {
+	e, _ := lookupEnum(flag.Value.Type())
+	return e.Hint
+}
+
+// enumNote returns the note listing the values allowed for flag by
+// RegisterEnum shown in help, if any.
+func (f *FlagSet) enumNote(flag *Flag) string {
+	if flag.choices != nil {
+		return ""
+	}
+	values := allowedValues(flag)
+	if len(values) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(f.msg(MsgOneOf), strings.Join(values, ", "))
+}
@@ -0,0 +1,70 @@
+package pflag
+
+import (
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// modeValue is an enum-like flag value.
+type modeValue string
+
+func (m *modeValue) String() string     { return string(*m) }
+func (m *modeValue) Set(s string) error { *m = modeValue(s); return nil }
+func (m *modeValue) Type() string       { return "enumTestMode" }
+
+func TestRegisterEnum(t *testing.T) {
+	RegisterEnum("enumTestMode", Enum{
+		Values: func() []string { return []string{"fast", "safe", "slow"} },
+		Hint:   "mode",
+	})
+	defer func() {
+		enumsMu.Lock()
+		delete(enums, "enumTestMode")
+		enumsMu.Unlock()
+	}()
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected registering a type twice to panic")
+			}
+		}()
+		RegisterEnum("enumTestMode", Enum{})
+	}()
+
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	mode := modeValue("safe")
+	f.Var(&mode, "mode", "how to run")
+	other := modeValue("")
+	f.Var(&other, "other", "the `speed`")
+	if err := f.SetChoices("other", "fast"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `      --mode mode   how to run (default safe) (one of fast, safe, slow)`
+	if got := f.Lookup("mode").UsageLine(); got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+	if got := f.Lookup("other").UsageLine(); strings.Contains(got, "one of") || !strings.Contains(got, "--other speed") {
+		t.Errorf("got %q", got)
+	}
+
+	if err := f.Parse([]string{"--mode", "slow"}); err != nil || mode != "slow" {
+		t.Errorf("got %q, %v", mode, err)
+	}
+	err := f.Parse([]string{"--mode", "quick"})
+	if err == nil || !strings.Contains(err.Error(), "must be one of fast, safe, slow") {
+		t.Errorf("got error %v", err)
+	}
+	if err := f.Parse([]string{"--other", "slow"}); err == nil {
+		t.Error("expected SetChoices to take precedence")
+	}
+
+	comps, directive := f.Complete([]string{"--mode", "s"})
+	if !reflect.DeepEqual(comps, []string{"safe", "slow"}) || directive != CompDirectiveNoFileComp {
+		t.Errorf("got completions %q %v", comps, directive)
+	}
+}
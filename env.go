@@ -0,0 +1,185 @@
+package pflag
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// SetEnvPrefix makes Parse set flags from environment variables, unless
+// their value comes from a source with a higher precedence, such as the
+// command line, see SetPrecedence. The variable of a flag is named after the flag,
+// in upper case with dashes and dots replaced by underscores, and prefixed by
+// prefix and an underscore unless prefix is empty: with the prefix "MYAPP",
+// the flag --log-level is set from $MYAPP_LOG_LEVEL.
+func (f *FlagSet) SetEnvPrefix(prefix string) {
+	f.envPrefix = prefix
+	f.automaticEnv = true
+}
+
+// BindFlagToEnv makes Parse set the named flag from the environment variable
+// envVar, unless its value comes from a source with a higher precedence, such
+// as the command line, see SetPrecedence. The variable is noted in help and
+// usage messages, as "(env: VAR)", so that they document it. It takes
+// precedence over the variable named by SetEnvPrefix, which needs not be
+// called.
+func (f *FlagSet) BindFlagToEnv(name, envVar string) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	flag.envVar = envVar
+	return nil
+}
+
+// envVarName returns the name of the environment variable flag is set from,
+// or "" if none.
+func (f *FlagSet) envVarName(flag *Flag) string {
+	if flag.envVar != "" {
+		return flag.envVar
+	}
+	if !f.automaticEnv {
+		return ""
+	}
+	return envName(f.envPrefix, flag.Name)
+}
+
+// envName returns the name of the environment variable of the named flag
+// with the given prefix.
+func envName(prefix, name string) string {
+	name = strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name))
+	if prefix != "" {
+		name = prefix + "_" + name
+	}
+	return name
+}
+
+// PrintEnv writes to w an "export NAME=value" line per flag, in VisitAll
+// order, setting the environment variable read by SetEnvPrefix with prefix,
+// or the one bound by BindFlagToEnv, to the current value of the flag, so
+// that a shell sourcing the output gets the resolved configuration. Values
+// are quoted for the shell when needed. As with ToArgs, flags whose value
+// can't be written, like empty int slices, or only as several arguments, are
+// left out, as are passwords and sensitive flags, so that the output can be
+// sourced as is.
+func (f *FlagSet) PrintEnv(w io.Writer, prefix string) {
+	f.VisitAll(func(flag *Flag) {
+		args := flagArgs(flag)
+		if len(args) != 1 {
+			return
+		}
+		value := strings.TrimPrefix(args[0], "--"+flag.Name+"=")
+		key := flag.envVar
+		if key == "" {
+			key = envName(prefix, flag.Name)
+		}
+		fmt.Fprintf(w, "export %s=%s\n", key, shellQuote(value))
+	})
+}
+
+// shellQuote returns s quoted for a POSIX shell, in single quotes unless it
+// is made of characters which need none.
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_-.,:/@%+=") == "" {
+		return s
+	}
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+// lookupEnv returns the value of the environment variable key. Variables of
+// the process environment take precedence over the ones loaded by LoadDotenv.
+func (f *FlagSet) lookupEnv(key string) (string, bool) {
+	if value, ok := os.LookupEnv(key); ok {
+		return value, true
+	}
+	value, ok := f.dotenv[key]
+	return value, ok
+}
+
+// applyEnv sets the flags from the environment, according to the
+// precedence of their current source.
+func (f *FlagSet) applyEnv() error {
+	for _, flag := range f.orderedFormal {
+		// Flags already set from the environment by a previous Parse are
+		// left alone, so that slices don't accumulate.
+		if flag.source == SourceEnv || !f.overrides(flag, SourceEnv) {
+			continue
+		}
+		key := f.envVarName(flag)
+		if key == "" {
+			continue
+		}
+		value, ok := f.lookupEnv(key)
+		if !ok {
+			continue
+		}
+		if err := f.set(flag.Name, value, SourceEnv); err != nil {
+			// Keep the type of invalid values, for their exit code.
+			if ive, ok := err.(*InvalidValueError); ok {
+				ive.msg = fmt.Sprintf("%s (from $%s)", ive.msg, key)
+				return ive
+			}
+			return fmt.Errorf("%v (from $%s)", err, key)
+		}
+	}
+	return nil
+}
+
+// LoadDotenv loads environment variables from the dotenv file at path, for
+// flags to be set from by Parse, see SetEnvPrefix. The variables are only
+// seen by the FlagSet and don't change the environment of the process, whose
+// variables take precedence over the ones of the file.
+//
+// The file holds one KEY=VALUE assignment per line, optionally preceded by
+// "export". Values may be single quoted, to be taken literally, or double
+// quoted, in which case Go string escapes are interpreted. Lines starting
+// with '#' are comments, as is the text following " #" in unquoted values.
+func (f *FlagSet) LoadDotenv(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	vars := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for lineno := 1; scanner.Scan(); lineno++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+		i := strings.IndexByte(line, '=')
+		if i <= 0 {
+			return fmt.Errorf("%s:%d: expected KEY=VALUE: %s", path, lineno, line)
+		}
+		key, value := strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:])
+		switch {
+		case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+			value = value[1 : len(value)-1]
+		case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+			if value, err = strconv.Unquote(value); err != nil {
+				return fmt.Errorf("%s:%d: bad quoted value for %s: %v", path, lineno, key, err)
+			}
+		default:
+			if j := strings.Index(value, " #"); j >= 0 {
+				value = strings.TrimSpace(value[:j])
+			}
+		}
+		vars[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if f.dotenv == nil {
+		f.dotenv = make(map[string]string)
+	}
+	for key, value := range vars {
+		f.dotenv[key] = value
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package pflag
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEnv(t *testing.T) {
+	fs := NewFlagSet("TestEnv", ContinueOnError)
+	level := fs.String("log-level", "info", "")
+	port := fs.Int("port", 0, "")
+	name := fs.String("name", "", "")
+	quoted := fs.String("quoted", "", "")
+	other := fs.String("other", "", "")
+	fs.SetEnvPrefix("PFLAGTEST")
+
+	path := writeTempFile(t, ".env", `# comment
+export PFLAGTEST_LOG_LEVEL=debug
+PFLAGTEST_PORT=80
+PFLAGTEST_NAME=from-file # comment
+PFLAGTEST_QUOTED="a\tb"
+PFLAGTEST_OTHER='x # y'
+`)
+	defer os.RemoveAll(filepath.Dir(path))
+	if err := fs.LoadDotenv(path); err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv("PFLAGTEST_NAME", "from-env")
+	defer os.Unsetenv("PFLAGTEST_NAME")
+
+	if err := fs.Parse([]string{"--port=8080"}); err != nil {
+		t.Fatal(err)
+	}
+	if *level != "debug" || *port != 8080 || *name != "from-env" || *quoted != "a\tb" || *other != "x # y" {
+		t.Errorf("unexpected values %q %d %q %q %q", *level, *port, *name, *quoted, *other)
+	}
+	if src := fs.Lookup("log-level").Source(); src != SourceEnv {
+		t.Errorf("got source %v want %v", src, SourceEnv)
+	}
+
+	os.Setenv("PFLAGTEST_PORT", "x")
+	defer os.Unsetenv("PFLAGTEST_PORT")
+	fs = NewFlagSet("TestEnv", ContinueOnError)
+	fs.Int("port", 0, "")
+	fs.SetEnvPrefix("PFLAGTEST")
+	want := `invalid argument "x" for "--port" flag: strconv.ParseInt: parsing "x": invalid syntax (from $PFLAGTEST_PORT)`
+	err := fs.Parse(nil)
+	if err == nil || err.Error() != want {
+		t.Errorf("got error %v want %s", err, want)
+	}
+	if got := fs.ExitCode(err); got != DefaultExitCodes.Validation {
+		t.Errorf("got exit code %d want %d", got, DefaultExitCodes.Validation)
+	}
+}
+
+func TestPrintEnv(t *testing.T) {
+	fs := NewFlagSet("TestPrintEnv", ContinueOnError)
+	fs.String("log-level", "info", "")
+	fs.Int("port", 8080, "")
+	fs.String("greeting", "", "")
+	fs.StringSlice("tags", []string{"a", "b"}, "")
+	fs.StringArray("names", []string{"x", "y"}, "")
+	fs.IntSlice("ports", nil, "")
+	fs.String("token", "", "")
+	fs.MarkSensitive("token")
+	if err := fs.Parse([]string{"--greeting=it's here", "--token=secret"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	fs.PrintEnv(&buf, "MYAPP")
+	want := `export MYAPP_GREETING='it'\''s here'
+export MYAPP_LOG_LEVEL=info
+export MYAPP_PORT=8080
+export MYAPP_TAGS=a,b
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestBindFlagToEnv(t *testing.T) {
+	os.Setenv("PFLAG_TEST_DATA_DIR", "/srv/data")
+	defer os.Unsetenv("PFLAG_TEST_DATA_DIR")
+	fs := NewFlagSet("TestBindFlagToEnv", ContinueOnError)
+	dir := fs.String("data-dir", "/tmp", "data directory")
+	port := fs.Int("port", 80, "port")
+	if err := fs.BindFlagToEnv("data-dir", "PFLAG_TEST_DATA_DIR"); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.BindFlagToEnv("port", "PFLAG_TEST_PORT"); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.BindFlagToEnv("missing", "X"); err == nil {
+		t.Error("expected an error for a missing flag")
+	}
+
+	if err := fs.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	if *dir != "/srv/data" || *port != 80 || fs.Lookup("data-dir").Source() != SourceEnv {
+		t.Errorf("got --data-dir=%q --port=%d", *dir, *port)
+	}
+	if got, want := fs.Lookup("data-dir").UsageLine(), `      --data-dir string   data directory (default "/tmp") (env: PFLAG_TEST_DATA_DIR)`; got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+
+	fs = NewFlagSet("TestBindFlagToEnv", ContinueOnError)
+	dir = fs.String("data-dir", "/tmp", "data directory")
+	fs.BindFlagToEnv("data-dir", "PFLAG_TEST_DATA_DIR")
+	if err := fs.Parse([]string{"--data-dir=/var"}); err != nil {
+		t.Fatal(err)
+	}
+	if *dir != "/var" {
+		t.Errorf("the environment overrode the command line: %q", *dir)
+	}
+	var buf bytes.Buffer
+	fs.PrintEnv(&buf, "MYAPP")
+	if got, want := buf.String(), "export PFLAG_TEST_DATA_DIR=/var\n"; got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+}
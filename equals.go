@@ -0,0 +1,29 @@
+package pflag
+
+import "fmt"
+
+// SetRequireEquals sets whether the flags of the FlagSet taking a value only
+// accept it attached, as in "--files=foo" or "-ffoo", rather than in the
+// next argument, as in "--files foo", which would otherwise take an intended
+// positional argument as the value. Flags whose value is optional, like
+// boolean flags, are not affected. See MarkRequireEquals to set it per flag.
+func (f *FlagSet) SetRequireEquals(required bool) {
+	f.requireEquals = required
+}
+
+// MarkRequireEquals makes the named flag only accept its value attached, as
+// in "--files=foo", see SetRequireEquals.
+func (f *FlagSet) MarkRequireEquals(name string) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	flag.requireEquals = true
+	return nil
+}
+
+// requiresEquals returns true if the value of flag can't be given in the
+// next argument.
+func (f *FlagSet) requiresEquals(flag *Flag) bool {
+	return f.requireEquals || flag.requireEquals
+}
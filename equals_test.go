@@ -0,0 +1,53 @@
+package pflag
+
+import (
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMarkRequireEquals(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	files := f.StringP("files", "f", "", "")
+	name := f.String("name", "", "")
+	verbose := f.Bool("verbose", false, "")
+	if err := f.MarkRequireEquals("files"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.MarkRequireEquals("nope"); err == nil {
+		t.Error("expected an error for an undefined flag")
+	}
+
+	if err := f.Parse([]string{"--files=a", "--name", "x", "--verbose", "pos"}); err != nil {
+		t.Fatal(err)
+	}
+	if *files != "a" || *name != "x" || !*verbose || !reflect.DeepEqual(f.Args(), []string{"pos"}) {
+		t.Errorf("got files %q, name %q, verbose %v and args %v", *files, *name, *verbose, f.Args())
+	}
+	if err := f.Parse([]string{"-fb"}); err != nil || *files != "b" {
+		t.Errorf("got files %q and error %v", *files, err)
+	}
+
+	for _, args := range [][]string{{"--files", "foo"}, {"-f", "foo"}} {
+		err := f.Parse(args)
+		if err == nil || !strings.Contains(err.Error(), "--files=foo") {
+			t.Errorf("got error %v for %q", err, args)
+		}
+	}
+}
+
+func TestSetRequireEquals(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.String("name", "", "")
+	f.SetRequireEquals(true)
+	if err := f.Parse([]string{"--name", "x"}); err == nil {
+		t.Error("expected an error for a value in the next argument")
+	}
+	f.SetRequireEquals(false)
+	if err := f.Parse([]string{"--name", "x"}); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,108 @@
+package pflag
+
+import (
+	"fmt"
+	"os"
+)
+
+// ExitCodes maps the errors returned by Parse to the status a program exits
+// with, see SetExitCodes and HandleError.
+type ExitCodes struct {
+	Help       int // ErrHelp
+	Version    int // ErrVersion
+	Usage      int // errors in the command line, like unknown flags or missing arguments
+	Validation int // values rejected by flags, see InvalidValueError
+}
+
+// DefaultExitCodes are the exit codes used by HandleError unless others are
+// set with SetExitCodes.
+var DefaultExitCodes = ExitCodes{Help: 0, Version: 0, Usage: 2, Validation: 3}
+
+// legacyExitCodes are the exit codes used by ExitOnError unless others are
+// set with SetExitCodes.
+var legacyExitCodes = ExitCodes{Help: 2, Version: 0, Usage: 2, Validation: 2}
+
+// InvalidValueError is the error returned when a flag rejects the value it is
+// set to.
+type InvalidValueError struct {
+	Flag  *Flag
+	Value string
+	Err   error // error returned by the Value or its validation
+	msg   string
+}
+
+func (e *InvalidValueError) Error() string { return e.msg }
+
+// Unwrap returns the error returned by the Value or its validation.
+func (e *InvalidValueError) Unwrap() error { return e.Err }
+
+// reportedError is an error failf already printed, with the usage message.
+type reportedError struct {
+	error
+}
+
+func (e *reportedError) Unwrap() error { return e.error }
+
+// SetExitCodes sets the status the program exits with for each kind of
+// error, for ExitOnError and HandleError, so that all the programs of an
+// organization exit consistently. Unless set, ExitOnError exits with 0 for
+// ErrVersion and 2 for other errors, and HandleError uses DefaultExitCodes.
+func (f *FlagSet) SetExitCodes(codes ExitCodes) {
+	f.exitCodes = &codes
+}
+
+// ExitCode returns the status the program exits with for err, an error
+// returned by Parse, according to the exit codes of the FlagSet, see
+// SetExitCodes. It returns 0 for a nil error.
+func (f *FlagSet) ExitCode(err error) int {
+	codes := DefaultExitCodes
+	if f.exitCodes != nil {
+		codes = *f.exitCodes
+	}
+	return codes.of(err)
+}
+
+// of returns the exit code for err.
+func (codes ExitCodes) of(err error) int {
+	switch err.(type) {
+	case nil:
+		return 0
+	case *InvalidValueError:
+		return codes.Validation
+	}
+	switch err {
+	case ErrHelp:
+		return codes.Help
+	case ErrVersion:
+		return codes.Version
+	}
+	return codes.Usage
+}
+
+// HandleError exits the program with the status ExitCode returns for err, an
+// error returned by Parse with ContinueOnError, after printing it to the
+// error output of the FlagSet unless Parse already did:
+//
+//	if err := f.Parse(os.Args[1:]); err != nil {
+//		f.HandleError(err)
+//	}
+//
+// It returns without doing anything if err is nil.
+func (f *FlagSet) HandleError(err error) {
+	if err == nil {
+		return
+	}
+	if _, ok := err.(*reportedError); !ok && err != ErrHelp && err != ErrVersion {
+		fmt.Fprintln(f.errOut(), err)
+	}
+	os.Exit(f.ExitCode(err))
+}
+
+// exitOnError exits the program on err, for ExitOnError.
+func (f *FlagSet) exitOnError(err error) {
+	codes := legacyExitCodes
+	if f.exitCodes != nil {
+		codes = *f.exitCodes
+	}
+	os.Exit(codes.of(err))
+}
@@ -0,0 +1,53 @@
+package pflag
+
+import (
+	"errors"
+	"io/ioutil"
+	"strconv"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.Int("port", 0, "")
+	f.SetVersion("1.0")
+
+	parse := func(args ...string) error {
+		f.Reset()
+		return f.Parse(args)
+	}
+	invalid := parse("--port=x")
+	ive, ok := invalid.(*InvalidValueError)
+	if !ok || ive.Flag.Name != "port" || ive.Value != "x" {
+		t.Fatalf("got error %#v", invalid)
+	}
+	if ne, ok := ive.Unwrap().(*strconv.NumError); !ok || ne.Err != strconv.ErrSyntax {
+		t.Errorf("got wrapped error %#v", ive.Unwrap())
+	}
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{nil, 0},
+		{parse("--help"), 0},
+		{parse("--version"), 0},
+		{parse("--nope"), 2},
+		{parse("--port"), 2},
+		{invalid, 3},
+		{errors.New("other"), 2},
+	}
+	for _, test := range tests {
+		if got := f.ExitCode(test.err); got != test.code {
+			t.Errorf("%v: got exit code %d want %d", test.err, got, test.code)
+		}
+	}
+
+	f.SetExitCodes(ExitCodes{Help: 10, Version: 11, Usage: 12, Validation: 13})
+	for err, want := range map[error]int{ErrHelp: 10, ErrVersion: 11, tests[3].err: 12, invalid: 13} {
+		if got := f.ExitCode(err); got != want {
+			t.Errorf("%v: got exit code %d want %d", err, got, want)
+		}
+	}
+	f.HandleError(nil)
+}
@@ -0,0 +1,44 @@
+package pflag
+
+import (
+	"fmt"
+	"os"
+)
+
+// SetExpandEnv sets whether the values given to the flags of the FlagSet on
+// the command line undergo the expansion of environment variables at parse
+// time, so that --data-dir='${HOME}/data' works the same whether or not the
+// shell expanded it. Both ${VAR} and $VAR are expanded, from the process
+// environment or else the variables loaded by LoadDotenv, and undefined
+// variables expand to the empty string; "$$" stands for a literal '$'.
+// Values set otherwise, as with Set or from the environment, are not
+// expanded. See MarkExpandEnv to set it per flag.
+func (f *FlagSet) SetExpandEnv(expand bool) {
+	f.expandEnv = expand
+}
+
+// MarkExpandEnv makes the values given to the named flag on the command line
+// undergo the expansion of environment variables, see SetExpandEnv.
+func (f *FlagSet) MarkExpandEnv(name string) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	flag.expandEnv = true
+	return nil
+}
+
+// expandValue returns value with the environment variables it refers to
+// expanded, if enabled for flag.
+func (f *FlagSet) expandValue(flag *Flag, value string) string {
+	if !f.expandEnv && !flag.expandEnv {
+		return value
+	}
+	return os.Expand(value, func(key string) string {
+		if key == "$" {
+			return "$"
+		}
+		value, _ := f.lookupEnv(key)
+		return value
+	})
+}
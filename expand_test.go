@@ -0,0 +1,53 @@
+package pflag
+
+import (
+	"os"
+	"testing"
+)
+
+func TestExpandEnv(t *testing.T) {
+	os.Setenv("PFLAG_TEST_HOME", "/home/test")
+	defer os.Unsetenv("PFLAG_TEST_HOME")
+
+	f := NewFlagSet("test", ContinueOnError)
+	dir := f.String("data-dir", "", "")
+	raw := f.String("raw", "", "")
+	if err := f.MarkExpandEnv("data-dir"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.MarkExpandEnv("missing"); err == nil {
+		t.Error("expected an error for a missing flag")
+	}
+	if err := f.Parse([]string{"--data-dir=${PFLAG_TEST_HOME}/data", "--raw=$PFLAG_TEST_HOME"}); err != nil {
+		t.Fatal(err)
+	}
+	if *dir != "/home/test/data" || *raw != "$PFLAG_TEST_HOME" {
+		t.Errorf("got %q %q", *dir, *raw)
+	}
+
+	f.SetExpandEnv(true)
+	tests := []struct {
+		arg  string
+		want string
+	}{
+		{"$PFLAG_TEST_HOME/x", "/home/test/x"},
+		{"cost: $$5", "cost: $5"},
+		{"${PFLAG_TEST_UNDEFINED}x", "x"},
+		{"plain", "plain"},
+	}
+	for _, test := range tests {
+		if err := f.Parse([]string{"--raw", test.arg}); err != nil {
+			t.Fatal(err)
+		}
+		if *raw != test.want {
+			t.Errorf("%q: got %q want %q", test.arg, *raw, test.want)
+		}
+	}
+
+	if err := f.Set("raw", "$PFLAG_TEST_HOME"); err != nil {
+		t.Fatal(err)
+	}
+	if *raw != "$PFLAG_TEST_HOME" {
+		t.Errorf("Set expanded the value to %q", *raw)
+	}
+}
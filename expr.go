@@ -0,0 +1,282 @@
+package pflag
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// SetExpressions sets whether the values of the numeric and duration flags
+// of the FlagSet may be arithmetic expressions, evaluated when the flags are
+// set, as in --cache-size=64*1024*1024 or --ttl=2*24h. See MarkExpression to
+// enable them per flag.
+//
+// Expressions combine numbers, and durations for duration flags, with +, -,
+// * and / and parentheses, and are evaluated exactly: an integer flag is
+// only set if the result is an integer, and a duration flag if it is a
+// duration, so 2*24h and 90m/2 are valid but 24h*24h is not. Integers are
+// read as by the integer flags, so 010+1 is 9. Values without operators,
+// like 0x10 or 1e-3, are left to the flag as is.
+func (f *FlagSet) SetExpressions(enabled bool) {
+	f.expressions = enabled
+}
+
+// MarkExpression lets the values of the named numeric or duration flag be
+// arithmetic expressions, see SetExpressions.
+func (f *FlagSet) MarkExpression(name string) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	if exprKind(flag.Value.Type()) == exprNone {
+		return fmt.Errorf("flag %q is not numeric", name)
+	}
+	flag.expression = true
+	return nil
+}
+
+// The kinds of values expressions can be evaluated to.
+const (
+	exprNone = iota
+	exprInt
+	exprFloat
+	exprDuration
+)
+
+// exprKind returns the kind of the values of flags of type typ.
+func exprKind(typ string) int {
+	switch typ {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return exprInt
+	case "float32", "float64":
+		return exprFloat
+	case "duration":
+		return exprDuration
+	}
+	return exprNone
+}
+
+// evalValue returns value evaluated if it is an expression and expressions
+// are enabled for flag.
+func (f *FlagSet) evalValue(flag *Flag, value string) (string, error) {
+	if !f.expressions && !flag.expression || !isExpression(value) {
+		return value, nil
+	}
+	kind := exprKind(flag.Value.Type())
+	if kind == exprNone {
+		return value, nil
+	}
+	return evalExpression(value, kind)
+}
+
+// isExpression returns true if s holds an arithmetic operator, other than a
+// leading sign or the sign of an exponent.
+func isExpression(s string) bool {
+	if strings.ContainsAny(s, "*/()") {
+		return true
+	}
+	for i := 1; i < len(s); i++ {
+		if (s[i] == '+' || s[i] == '-') && s[i-1] != 'e' && s[i-1] != 'E' {
+			return true
+		}
+	}
+	return false
+}
+
+// evalExpression evaluates the expression s to a value of the given kind,
+// formatted for the Set method of the flags of the kind.
+func evalExpression(s string, kind int) (string, error) {
+	p := &exprParser{s: s, durations: kind == exprDuration, floats: kind == exprFloat}
+	v, err := p.expr()
+	if err == nil {
+		p.skipSpace()
+		if p.pos < len(p.s) {
+			err = fmt.Errorf("unexpected %q", p.s[p.pos:])
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("bad expression %q: %v", s, err)
+	}
+	if kind == exprDuration {
+		if v.dim != 1 || !v.r.IsInt() || v.r.Num().BitLen() > 63 {
+			return "", fmt.Errorf("%q is not a duration", s)
+		}
+		return time.Duration(v.r.Num().Int64()).String(), nil
+	}
+	if v.dim != 0 {
+		return "", fmt.Errorf("%q is not a number", s)
+	}
+	if kind == exprFloat {
+		x, _ := v.r.Float64()
+		return strconv.FormatFloat(x, 'g', -1, 64), nil
+	}
+	if !v.r.IsInt() {
+		return "", fmt.Errorf("%q is not an integer: %s", s, v.r.RatString())
+	}
+	return v.r.Num().String(), nil
+}
+
+// exprValue is the value of an expression, in nanoseconds if dim is 1.
+type exprValue struct {
+	r   *big.Rat
+	dim int // exponent of the time dimension: 0 for numbers, 1 for durations
+}
+
+// exprParser evaluates arithmetic expressions by recursive descent.
+type exprParser struct {
+	s         string
+	pos       int
+	durations bool // duration literals are allowed
+	floats    bool // integer literals are decimal, as for the float flags
+}
+
+func (p *exprParser) skipSpace() {
+	for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// next returns the next character, skipping spaces, or 0 at the end.
+func (p *exprParser) next() byte {
+	p.skipSpace()
+	if p.pos == len(p.s) {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+// expr evaluates a sum: term (('+' | '-') term)*.
+func (p *exprParser) expr() (exprValue, error) {
+	v, err := p.term()
+	for err == nil {
+		op := p.next()
+		if op != '+' && op != '-' {
+			break
+		}
+		p.pos++
+		var w exprValue
+		if w, err = p.term(); err != nil {
+			break
+		}
+		if v.dim != w.dim {
+			return v, errors.New("mixing durations and numbers")
+		}
+		if op == '+' {
+			v.r = new(big.Rat).Add(v.r, w.r)
+		} else {
+			v.r = new(big.Rat).Sub(v.r, w.r)
+		}
+	}
+	return v, err
+}
+
+// term evaluates a product: unary (('*' | '/') unary)*.
+func (p *exprParser) term() (exprValue, error) {
+	v, err := p.unary()
+	for err == nil {
+		op := p.next()
+		if op != '*' && op != '/' {
+			break
+		}
+		p.pos++
+		var w exprValue
+		if w, err = p.unary(); err != nil {
+			break
+		}
+		if op == '*' {
+			v.r, v.dim = new(big.Rat).Mul(v.r, w.r), v.dim+w.dim
+		} else {
+			if w.r.Sign() == 0 {
+				return v, errors.New("division by zero")
+			}
+			v.r, v.dim = new(big.Rat).Quo(v.r, w.r), v.dim-w.dim
+		}
+	}
+	return v, err
+}
+
+// unary evaluates a signed operand: ('-' | '+') unary | primary.
+func (p *exprParser) unary() (exprValue, error) {
+	switch p.next() {
+	case '-':
+		p.pos++
+		v, err := p.unary()
+		if err == nil {
+			v.r = new(big.Rat).Neg(v.r)
+		}
+		return v, err
+	case '+':
+		p.pos++
+		return p.unary()
+	}
+	return p.primary()
+}
+
+// primary evaluates a literal or an expression in parentheses.
+func (p *exprParser) primary() (exprValue, error) {
+	switch c := p.next(); {
+	case c == 0:
+		return exprValue{}, errors.New("unexpected end")
+	case c == '(':
+		p.pos++
+		v, err := p.expr()
+		if err != nil {
+			return v, err
+		}
+		if p.next() != ')' {
+			return v, errors.New("missing )")
+		}
+		p.pos++
+		return v, nil
+	}
+	lit := p.literal()
+	if lit == "" {
+		return exprValue{}, fmt.Errorf("unexpected %q", p.s[p.pos:])
+	}
+	if p.durations {
+		if d, err := time.ParseDuration(lit); err == nil {
+			return exprValue{new(big.Rat).SetInt64(int64(d)), 1}, nil
+		}
+	}
+	if !p.floats {
+		// As for the integer flags, 010 is octal and 0x10 hexadecimal.
+		if i, ok := new(big.Int).SetString(lit, 0); ok {
+			return exprValue{new(big.Rat).SetInt(i), 0}, nil
+		}
+	}
+	r, ok := new(big.Rat).SetString(lit)
+	if !ok || strings.Contains(lit, "/") {
+		return exprValue{}, fmt.Errorf("invalid number %q", lit)
+	}
+	return exprValue{r, 0}, nil
+}
+
+// literal returns the number or duration starting at the current position,
+// made of letters, digits and dots, and signs in decimal exponents.
+func (p *exprParser) literal() string {
+	start := p.pos
+	decimal := true
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		switch {
+		case c >= '0' && c <= '9' || c == '.':
+		case (c == 'e' || c == 'E') && decimal && p.pos > start:
+			if p.pos+1 < len(p.s) && (p.s[p.pos+1] == '+' || p.s[p.pos+1] == '-') {
+				p.pos++
+			}
+			decimal = false
+		case c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_':
+			decimal = false
+		case c >= utf8.RuneSelf: // µs
+			decimal = false
+		default:
+			return p.s[start:p.pos]
+		}
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
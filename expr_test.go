@@ -0,0 +1,79 @@
+package pflag
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+func TestExpressions(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	size := f.Int64("cache-size", 0, "")
+	ttl := f.Duration("ttl", 0, "")
+	ratio := f.Float64("ratio", 0, "")
+	count := f.Int("count", 0, "")
+	name := f.String("name", "", "")
+	if err := f.MarkExpression("cache-size"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.MarkExpression("name"); err == nil {
+		t.Error("expected an error for a string flag")
+	}
+	if err := f.MarkExpression("missing"); err == nil {
+		t.Error("expected an error for a missing flag")
+	}
+	if err := f.Parse([]string{"--cache-size=64*1024*1024"}); err != nil {
+		t.Fatal(err)
+	}
+	if *size != 64<<20 {
+		t.Errorf("got --cache-size=%d", *size)
+	}
+	if err := f.Parse([]string{"--count=2*3"}); err == nil {
+		t.Error("expected an error for a flag without expressions")
+	}
+
+	f.SetExpressions(true)
+	tests := []struct {
+		arg   string
+		check func() bool
+	}{
+		{"--ttl=2*24h", func() bool { return *ttl == 48*time.Hour }},
+		{"--ttl=(1h + 30m) / 2", func() bool { return *ttl == 45*time.Minute }},
+		{"--ttl=1h30m", func() bool { return *ttl == 90*time.Minute }},
+		{"--ratio=1/3", func() bool { return *ratio == 1.0/3 }},
+		{"--ratio=1e-3", func() bool { return *ratio == 0.001 }},
+		{"--ratio=2.5e-1*4", func() bool { return *ratio == 1 }},
+		{"--count=-(2+3)*4", func() bool { return *count == -20 }},
+		{"--count=0x10", func() bool { return *count == 16 }},
+		{"--count=010+1", func() bool { return *count == 9 }},
+		{"--count=0x10*2", func() bool { return *count == 32 }},
+		{"--ratio=010+1", func() bool { return *ratio == 11 }},
+		{"--count=7/7", func() bool { return *count == 1 }},
+		{"--name=a-b*c", func() bool { return *name == "a-b*c" }},
+	}
+	for _, test := range tests {
+		if err := f.Parse([]string{test.arg}); err != nil {
+			t.Errorf("%s: %v", test.arg, err)
+		} else if !test.check() {
+			t.Errorf("%s: got ttl %v ratio %v count %d name %q", test.arg, *ttl, *ratio, *count, *name)
+		}
+	}
+
+	for _, arg := range []string{
+		"--count=7/2",
+		"--count=1/0",
+		"--count=2*",
+		"--count=(1+2",
+		"--count=1+2)",
+		"--count=2*3h",
+		"--ttl=24h*24h",
+		"--ttl=2*3",
+		"--ttl=1h+1",
+		"--count=1/2/3x",
+	} {
+		if err := f.Parse([]string{arg}); err == nil {
+			t.Errorf("%s: expected an error", arg)
+		}
+	}
+}
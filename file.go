@@ -0,0 +1,90 @@
+package pflag
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ParseFile sets flags from the configuration file at path. The file holds
+// one "name = value" assignment per line, where name is the name of a flag
+// without its leading dashes. Values may be double quoted, in which case Go
+// string escapes are interpreted. A name without a value sets a flag which
+// has a NoOptDefVal, like a boolean flag, to that value. Lines starting with
+// '#' or ';' are comments. A "[section]" line prefixes the names which follow
+// it with "section-", so that
+//
+//	[db]
+//	host = localhost
+//
+// sets the flag --db-host. Names may be repeated to set slice flags to
+// several values.
+//
+// Flags whose value comes from a source with a higher precedence, by default
+// the command line or the environment, keep their value, see SetPrecedence;
+// ParseFile is thus meant to be called after Parse. Unknown names are an
+// error.
+func (f *FlagSet) ParseFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return f.parseConfig(file, path)
+}
+
+// parseConfig sets flags from the assignments read from r. name is the name
+// of the input used in error messages.
+func (f *FlagSet) parseConfig(r io.Reader, name string) error {
+	scanner := bufio.NewScanner(r)
+	section := ""
+	for lineno := 1; scanner.Scan(); lineno++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if line[0] == '[' {
+			if line[len(line)-1] != ']' {
+				return fmt.Errorf("%s:%d: bad section header: %s", name, lineno, line)
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+
+		key, value, hasValue := line, "", false
+		if i := strings.IndexByte(line, '='); i >= 0 {
+			key, value, hasValue = strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:]), true
+		}
+		if section != "" {
+			key = section + "-" + key
+		}
+		if hasValue && len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			v, err := strconv.Unquote(value)
+			if err != nil {
+				return fmt.Errorf("%s:%d: bad quoted value for %q: %v", name, lineno, key, err)
+			}
+			value = v
+		}
+
+		flag := f.Lookup(key)
+		if flag == nil {
+			return fmt.Errorf("%s:%d: unknown flag: %s", name, lineno, key)
+		}
+		if !hasValue {
+			if flag.NoOptDefVal == "" {
+				return fmt.Errorf("%s:%d: flag needs a value: %s", name, lineno, key)
+			}
+			value = flag.NoOptDefVal
+		}
+		if !f.overrides(flag, SourceConfig) {
+			continue
+		}
+		if err := f.set(flag.Name, value, SourceConfig); err != nil {
+			return fmt.Errorf("%s:%d: %v", name, lineno, err)
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,66 @@
+package pflag
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeTempFile(t *testing.T, name, content string) string {
+	dir, err := ioutil.TempDir("", "pflag")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestParseFile(t *testing.T) {
+	fs := NewFlagSet("TestParseFile", ContinueOnError)
+	level := fs.String("log-level", "info", "")
+	verbose := fs.Bool("verbose", false, "")
+	hosts := fs.StringSlice("hosts", nil, "")
+	dbHost := fs.String("db-host", "", "")
+	port := fs.Int("port", 0, "")
+	fs.Int("retries", 0, "")
+
+	path := writeTempFile(t, "config.ini", `# comment
+log-level = debug
+verbose
+hosts = a
+hosts = b
+port = 80
+; other comment
+[db]
+host = "local\thost"
+`)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	if err := fs.Parse([]string{"--port=8080"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.ParseFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if *level != "debug" || !*verbose || !reflect.DeepEqual(*hosts, []string{"a", "b"}) || *dbHost != "local\thost" {
+		t.Errorf("unexpected values %q %v %q %q", *level, *verbose, *hosts, *dbHost)
+	}
+	if *port != 8080 {
+		t.Errorf("command line value was overridden: %d", *port)
+	}
+	if src := fs.Lookup("log-level").Source(); src != SourceConfig {
+		t.Errorf("got source %v want %v", src, SourceConfig)
+	}
+
+	for _, content := range []string{"nope = 1\n", "retries = x\n", "[db\n", "retries\n"} {
+		if err := fs.parseConfig(strings.NewReader(content), "test"); err == nil || !strings.HasPrefix(err.Error(), "test:1: ") {
+			t.Errorf("%q: unexpected error %v", content, err)
+		}
+	}
+}
@@ -100,15 +100,20 @@ package pflag
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
+	"text/template"
+	"unicode/utf8"
 )
 
 // ErrHelp is the error returned if the flag -help is invoked but no such flag is defined.
+// The help flag can be renamed or disabled with SetHelpFlag.
 var ErrHelp = errors.New("pflag: help requested")
 
 // ErrorHandling defines how to handle flag parsing errors.
@@ -117,7 +122,8 @@ type ErrorHandling int
 const (
 	// ContinueOnError will return an err from Parse() if an error is found
 	ContinueOnError ErrorHandling = iota
-	// ExitOnError will call os.Exit(2) if an error is found when parsing
+	// ExitOnError will call os.Exit(2) if an error is found when parsing,
+	// or exit with the status set with SetExitCodes
 	ExitOnError
 	// PanicOnError will panic() if an error is found when parsing flags
 	PanicOnError
@@ -138,21 +144,80 @@ type FlagSet struct {
 	// help/usage messages.
 	SortFlags bool
 
-	name              string
-	parsed            bool
-	actual            map[NormalizedName]*Flag
-	orderedActual     []*Flag
-	sortedActual      []*Flag
-	formal            map[NormalizedName]*Flag
-	orderedFormal     []*Flag
-	sortedFormal      []*Flag
-	shorthands        map[byte]*Flag
-	args              []string // arguments after flags
-	argsLenAtDash     int      // len(args) when a '--' was located when parsing, or -1 if no --
-	errorHandling     ErrorHandling
-	output            io.Writer // nil means stderr; use out() accessor
-	interspersed      bool      // allow interspersed option/non-option args
-	normalizeNameFunc func(f *FlagSet, name string) NormalizedName
+	name                  string
+	parsed                bool
+	actual                map[NormalizedName]*Flag
+	orderedActual         []*Flag
+	sortedActual          []*Flag
+	formal                map[NormalizedName]*Flag
+	orderedFormal         []*Flag
+	sortedFormal          []*Flag
+	shorthands            map[string]*Flag
+	args                  []string // arguments after flags
+	argsLenAtDash         int      // len(args) when a '--' was located when parsing, or -1 if no --
+	errorHandling         ErrorHandling
+	output                io.Writer // nil means stderr; use out() accessor
+	errOutput             io.Writer // nil means output; use errOut() accessor
+	interspersed          bool      // allow interspersed option/non-option args
+	normalizeNameFunc     func(f *FlagSet, name string) NormalizedName
+	groups                []string // group names in the order they were first used
+	lessFunc              func(a, b *Flag) bool
+	locale                Messages         // catalog selected by SetLocale
+	messages              Messages         // overrides set by SetMessages
+	occurrences           []flagOccurrence // flags in the order they were parsed
+	parseSource           ValueSource      // source of values set while ParseAll calls its fn
+	automaticEnv          bool             // set flags from the environment, see SetEnvPrefix
+	envPrefix             string
+	dotenv                map[string]string // variables loaded by LoadDotenv
+	precedence            []ValueSource     // resolution order of sources, see SetPrecedence
+	onChanged             map[*Flag][]func(old, new string)
+	muteChanged           bool // don't call the OnChanged callbacks, see Reload
+	preParse              []func(args []string) []string
+	postParse             []func(f *FlagSet) error
+	commands              []command // subcommands, see AddCommand
+	command               *command  // subcommand selected by Parse
+	argsParsed            int       // number of arguments parsed by previous calls to Parse
+	repeatPolicy          RepeatPolicy
+	parseSeq              int // incremented by every call to Parse
+	collectConflicts      bool
+	verboseHelp           bool           // see SetVerboseHelp
+	strictShorthandGroups bool           // see SetStrictShorthandGroups
+	requireEquals         bool           // see SetRequireEquals
+	expandEnv             bool           // see SetExpandEnv
+	expressions           bool           // see SetExpressions
+	exitCodes             *ExitCodes     // see SetExitCodes
+	multiCharShorthands   bool           // see SetMultiCharShorthands
+	maxShorthandLen       int            // length in bytes of the longest shorthand
+	completeArgs          CompletionFunc // see RegisterArgsCompletion
+	conflicts             []*ShorthandConflictError
+	negativeNumbers       bool            // treat arguments like -1 as positional, see SetNegativeNumbers
+	ctx                   context.Context // context given to ParseContext
+	promptMissing         bool            // prompt for missing required flags, see SetPromptMissing
+	parent                *FlagSet        // see SetParent
+	parseInherited        bool            // see SetParseInherited
+	logDebug              debugLogger     // see SetLogger
+	stdin                 io.Reader       // answers to prompts and values read with "-", os.Stdin if nil
+	stdinFlag             *Flag           // flag whose value was read from stdin, see AllowStdinValue
+	inGreedy              bool            // set while consumeGreedy sets the values following a flag
+	usageTemplate         *template.Template
+	observer              Observer // see SetObserver
+	release               string   // release of the program, see SetRelease
+	version               string   // printed by --version, see SetVersion
+	versionFlag           *Flag
+	helpConfigured        bool // see SetHelpFlag
+	helpName              string
+	helpShorthand         string
+	helpHandler           func(f *FlagSet) error
+	description           string // see SetDescription
+	usageLine             string // see SetUsageLine
+	positionals           []*PositionalArg
+	expectArgs            bool // see ExpectArgs
+	minArgs               int
+	maxArgs               int
+	aliases               map[string][]string // see SetAlias
+	names                 *nameTrie           // index of the names of the flags, see nameIndex
+	abbreviations         bool                // see SetAbbreviations
+	suggestions           bool                // see SetSuggestions
 }
 
 // A Flag represents the state of a flag.
@@ -168,6 +233,38 @@ type Flag struct {
 	Hidden              bool                // used by cobra.Command to allow flags to be hidden from help/usage text
 	ShorthandDeprecated string              // If the shorthand of this flag is deprecated, this string is the new or now thing to use
 	Annotations         map[string][]string // used by cobra.Command bash autocomple code
+	Group               string              // name of the section this flag is listed under in help/usage text
+	ArgName             string              // name of the flag's argument in help/usage text, e.g. "FILE"
+	DefaultText         string              // if set, shown in place of DefValue in help/usage text
+	HideDefault         bool                // if true, the default value isn't shown in help/usage text
+	Example             string              // example invocation shown under the flag in help/usage text
+	Required            bool                // if true, parsing fails when the flag is not set, see MarkRequired
+	Sensitive           bool                // if true, the values are redacted in help/usage text and dumps, see MarkSensitive
+	AddedIn             string              // release of the program the flag was added in, see SetAddedIn
+	DeprecatedIn        string              // release of the program the flag was deprecated in, see MarkDeprecatedIn
+	RemovalAfter        string              // release or date after which setting the flag is an error, see MarkDeprecatedUntil
+
+	source      ValueSource   // where the current value comes from, see Source
+	lazyDefault func() string // computes DefValue when first needed, see SetLazyDefault
+
+	occurrences    int // number of times the flag was found in the arguments
+	minOccurrences int // see SetMinOccurrences
+	maxOccurrences int // see SetMaxOccurrences
+	repeatPolicy   RepeatPolicy
+	promptable     bool     // see MarkPromptable
+	parseSeq       int      // FlagSet.parseSeq of the last call to Parse the flag was given in
+	parsedBy       *FlagSet // FlagSet parseSeq refers to, flags being shareable with AddFlagSet
+	definedAt      callSite
+	choices        []string           // see SetChoices
+	validate       func(string) error // see SetValidator
+	complete       CompletionFunc     // see RegisterCompletion
+	greedy         bool               // see SetGreedy
+	fileValue      bool               // see AllowFileValue
+	stdinValue     bool               // see AllowStdinValue
+	requireEquals  bool               // see MarkRequireEquals
+	expandEnv      bool               // see MarkExpandEnv
+	envVar         string             // see BindFlagToEnv
+	expression     bool               // see MarkExpression
 }
 
 // Value is the interface to the dynamic value stored in a flag.
@@ -178,6 +275,26 @@ type Value interface {
 	Type() string
 }
 
+// DefaultArgValue is implemented by values which may be given on the command
+// line without an argument, like booleans. DefaultArg returns the argument
+// the value is then set to; it becomes the NoOptDefVal of flags defined with
+// the value, so that explicit arguments must be attached with '=', as in
+// --flag=value or -f=value.
+type DefaultArgValue interface {
+	Value
+	DefaultArg() string
+}
+
+// DefaultValueValue is implemented by values which render their default
+// value for help and usage messages themselves, rather than having it
+// guessed from DefValue. DefaultValue returns the text shown as
+// "(default X)", or an empty string to show no default, e.g. for a value
+// whose zero value doesn't print as one of the usual zero values.
+type DefaultValueValue interface {
+	Value
+	DefaultValue() string
+}
+
 // sortFlags returns the flags as a slice in lexicographical sorted order.
 func sortFlags(flags map[NormalizedName]*Flag) []*Flag {
 	list := make(sort.StringSlice, len(flags))
@@ -194,6 +311,47 @@ func sortFlags(flags map[NormalizedName]*Flag) []*Flag {
 	return result
 }
 
+// flagSorter sorts flags using a custom comparison function.
+type flagSorter struct {
+	flags []*Flag
+	less  func(a, b *Flag) bool
+}
+
+func (s flagSorter) Len() int           { return len(s.flags) }
+func (s flagSorter) Less(i, j int) bool { return s.less(s.flags[i], s.flags[j]) }
+func (s flagSorter) Swap(i, j int)      { s.flags[i], s.flags[j] = s.flags[j], s.flags[i] }
+
+// sortFlags returns the flags as a slice sorted with the comparison function
+// set by SetSortFunc, or in lexicographical order if there is none.
+func (f *FlagSet) sortFlags(flags map[NormalizedName]*Flag) []*Flag {
+	result := sortFlags(flags)
+	if f.lessFunc != nil {
+		// Flags comparing equal stay in lexicographical order.
+		sort.Stable(flagSorter{result, f.lessFunc})
+	}
+	return result
+}
+
+// sortedFormalFlags returns all the flags in sorted order. The order is
+// cached until flags are added or removed, or the sort function changes.
+func (f *FlagSet) sortedFormalFlags() []*Flag {
+	if f.sortedFormal == nil {
+		f.sortedFormal = f.sortFlags(f.formal)
+	}
+	return f.sortedFormal
+}
+
+// SetSortFunc sets the function used to order flags in help/usage messages
+// and when visiting them, instead of the lexicographical order. less reports
+// whether a must be listed before b; flags for which neither is less than the
+// other are listed lexicographically. The function is only used if SortFlags
+// is true; pass nil to restore the lexicographical order.
+func (f *FlagSet) SetSortFunc(less func(a, b *Flag) bool) {
+	f.lessFunc = less
+	f.sortedFormal = nil
+	f.sortedActual = nil
+}
+
 // SetNormalizeFunc allows you to add a function which can translate flag names.
 // Flags added to the FlagSet will be translated and then when anything tries to
 // look up the flag that will also be translated. So it would be possible to create
@@ -201,7 +359,8 @@ func sortFlags(flags map[NormalizedName]*Flag) []*Flag {
 // "--getUrl" which may also be translated to "geturl" and everything will work.
 func (f *FlagSet) SetNormalizeFunc(n func(f *FlagSet, name string) NormalizedName) {
 	f.normalizeNameFunc = n
-	f.sortedFormal = f.sortedFormal[:0]
+	f.sortedFormal = nil
+	f.names = nil
 	for k, v := range f.orderedFormal {
 		delete(f.formal, NormalizedName(v.Name))
 		nname := f.normalizeFlagName(v.Name)
@@ -232,15 +391,37 @@ func (f *FlagSet) out() io.Writer {
 	return f.output
 }
 
-// SetOutput sets the destination for usage and error messages.
+// SetOutput sets the destination for usage and error messages, unless error
+// messages are sent elsewhere with SetErrOutput.
 // If output is nil, os.Stderr is used.
 func (f *FlagSet) SetOutput(output io.Writer) {
 	f.output = output
 }
 
-// VisitAll visits the flags in lexicographical order or
-// in primordial order if f.SortFlags is false, calling fn for each.
-// It visits all flags, even those not set.
+func (f *FlagSet) errOut() io.Writer {
+	if f.errOutput == nil {
+		return f.out()
+	}
+	return f.errOutput
+}
+
+// SetErrOutput sets the destination for error messages, warnings about
+// deprecated flags and the usage message printed after a parse error,
+// leaving the output set with SetOutput to the usage message requested with
+// --help and to the version printed by --version. This allows the GNU
+// convention of printing requested help to stdout and errors to stderr:
+//
+//	f.SetOutput(os.Stdout)
+//	f.SetErrOutput(os.Stderr)
+//
+// If errOutput is nil, the output set with SetOutput is used.
+func (f *FlagSet) SetErrOutput(errOutput io.Writer) {
+	f.errOutput = errOutput
+}
+
+// VisitAll visits the flags in lexicographical order (or the order set by
+// SetSortFunc) or in primordial order if f.SortFlags is false, calling fn
+// for each. It visits all flags, even those not set.
 func (f *FlagSet) VisitAll(fn func(*Flag)) {
 	if len(f.formal) == 0 {
 		return
@@ -248,10 +429,7 @@ func (f *FlagSet) VisitAll(fn func(*Flag)) {
 
 	var flags []*Flag
 	if f.SortFlags {
-		if len(f.formal) != len(f.sortedFormal) {
-			f.sortedFormal = sortFlags(f.formal)
-		}
-		flags = f.sortedFormal
+		flags = f.sortedFormalFlags()
 	} else {
 		flags = f.orderedFormal
 	}
@@ -284,9 +462,9 @@ func VisitAll(fn func(*Flag)) {
 	CommandLine.VisitAll(fn)
 }
 
-// Visit visits the flags in lexicographical order or
-// in primordial order if f.SortFlags is false, calling fn for each.
-// It visits only those flags that have been set.
+// Visit visits the flags in lexicographical order (or the order set by
+// SetSortFunc) or in primordial order if f.SortFlags is false, calling fn
+// for each. It visits only those flags that have been set.
 func (f *FlagSet) Visit(fn func(*Flag)) {
 	if len(f.actual) == 0 {
 		return
@@ -294,8 +472,8 @@ func (f *FlagSet) Visit(fn func(*Flag)) {
 
 	var flags []*Flag
 	if f.SortFlags {
-		if len(f.actual) != len(f.sortedActual) {
-			f.sortedActual = sortFlags(f.actual)
+		if f.sortedActual == nil {
+			f.sortedActual = f.sortFlags(f.actual)
 		}
 		flags = f.sortedActual
 	} else {
@@ -307,6 +485,25 @@ func (f *FlagSet) Visit(fn func(*Flag)) {
 	}
 }
 
+// flagOccurrence records a flag found while parsing, and the position of the
+// argument it was found in.
+type flagOccurrence struct {
+	flag     *Flag
+	position int
+}
+
+// VisitInOrder visits the flags in the order they appeared in the arguments
+// given to Parse, calling fn once for every occurrence of a flag. position is
+// the index, in the arguments, of the argument the flag was found in; flags
+// combined in a single argument, like -abc, share their position. The
+// arguments of successive calls to Parse are numbered as if they were a
+// single list.
+func (f *FlagSet) VisitInOrder(fn func(flag *Flag, position int)) {
+	for _, o := range f.occurrences {
+		fn(o.flag, o.position)
+	}
+}
+
 // Visit visits the command-line flags in lexicographical order or
 // in primordial order if f.SortFlags is false, calling fn for each.
 // It visits only those flags that have been set.
@@ -321,23 +518,26 @@ func (f *FlagSet) Lookup(name string) *Flag {
 
 // ShorthandLookup returns the Flag structure of the short handed flag,
 // returning nil if none exists.
-// It panics, if len(name) > 1.
+// It panics, if len(name) > 1, unless SetMultiCharShorthands is enabled.
 func (f *FlagSet) ShorthandLookup(name string) *Flag {
 	if name == "" {
 		return nil
 	}
-	if len(name) > 1 {
+	if len(name) > 1 && !f.multiCharShorthands {
 		msg := fmt.Sprintf("can not look up shorthand which is more than one ASCII character: %q", name)
-		fmt.Fprintf(f.out(), msg)
+		fmt.Fprint(f.errOut(), msg)
 		panic(msg)
 	}
-	c := name[0]
-	return f.shorthands[c]
+	return f.shorthands[name]
 }
 
 // lookup returns the Flag structure of the named flag, returning nil if none exists.
+// Flags not defined in f are looked up in its parent, see SetParent.
 func (f *FlagSet) lookup(name NormalizedName) *Flag {
-	return f.formal[name]
+	if flag, ok := f.formal[name]; ok || f.parent == nil {
+		return flag
+	}
+	return f.parent.Lookup(string(name))
 }
 
 // func to return a given type for a given flag name
@@ -409,6 +609,176 @@ func (f *FlagSet) MarkHidden(name string) error {
 	return nil
 }
 
+// SetGroup assigns the named flags to a group. Grouped flags are listed in
+// help and usage messages under a header carrying the group name, after the
+// flags which do not belong to any group. Groups are rendered in the order
+// they were first used.
+func (f *FlagSet) SetGroup(group string, names ...string) error {
+	for _, name := range names {
+		if f.Lookup(name) == nil {
+			return fmt.Errorf("flag %q does not exist", name)
+		}
+	}
+	for _, name := range names {
+		f.Lookup(name).Group = group
+	}
+	f.addGroup(group)
+	return nil
+}
+
+// addGroup records group in the list of known groups, if not already there.
+func (f *FlagSet) addGroup(group string) {
+	if group == "" || containsString(f.groups, group) {
+		return
+	}
+	f.groups = append(f.groups, group)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// SetArgName sets the name used for the argument of a flag in help and
+// usage messages, so that e.g. "--output FILE" is shown instead of
+// "--output string". It takes precedence over a back-quoted name in the
+// usage string.
+func (f *FlagSet) SetArgName(name, argName string) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	flag.ArgName = argName
+	return nil
+}
+
+// SetDefaultText sets the text shown as the default value of a flag in help
+// and usage messages, e.g. "$HOME/.config" for a default computed at
+// runtime. The text is shown even if the default is the zero value.
+func (f *FlagSet) SetDefaultText(name, text string) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	flag.DefaultText = text
+	return nil
+}
+
+// SetNoOptDefVal makes a flag of any type optionally take an argument: given
+// on the command line without one, the flag is set to arg. Explicit values
+// must then be attached with '=', as in --flag=value or -f=value, since in
+// --flag value the value is an argument of its own. The usage message shows
+// both forms as --flag[=arg].
+func (f *FlagSet) SetNoOptDefVal(name, arg string) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	flag.NoOptDefVal = arg
+	return nil
+}
+
+// SetLazyDefault makes the default value of a flag computed by fn, for
+// defaults which are expensive or depend on the environment, like a host name
+// lookup. fn is called at most once, when Parse completes without the flag
+// having been set, or earlier if the default is needed to print help. Its
+// result, in the syntax accepted by the flag's Set method, replaces the value
+// of the flag unless the flag was set, and DefValue.
+func (f *FlagSet) SetLazyDefault(name string, fn func() string) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	flag.lazyDefault = fn
+	return nil
+}
+
+// resolveDefault computes the default value of the flag set by
+// SetLazyDefault, if any.
+func (f *Flag) resolveDefault() error {
+	if f.lazyDefault == nil {
+		return nil
+	}
+	def := f.lazyDefault()
+	f.lazyDefault = nil
+	if f.source != SourceDefault {
+		f.DefValue = def
+		return nil
+	}
+	if err := f.Value.Set(def); err != nil {
+		return fmt.Errorf("invalid default %q for flag --%s: %v", def, f.Name, err)
+	}
+	// Set the value again from its string representation, so that slices
+	// are replaced rather than appended to when the flag is then set.
+	f.DefValue = f.Value.String()
+	return restoreValue(f, f.DefValue)
+}
+
+// resolveDefaults computes the lazy defaults of the flags which were not set.
+func (f *FlagSet) resolveDefaults() error {
+	for _, flag := range f.orderedFormal {
+		if flag.lazyDefault != nil && flag.source == SourceDefault {
+			if err := flag.resolveDefault(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MarkDefaultHidden hides the default value of a flag in help and usage
+// messages, e.g. for secrets.
+func (f *FlagSet) MarkDefaultHidden(name string) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	flag.HideDefault = true
+	return nil
+}
+
+// SetExample sets an example invocation of a flag, e.g.
+// `--header "Accept: application/json"`. It is shown under the flag in help
+// and usage messages and by the documentation generators.
+func (f *FlagSet) SetExample(name, example string) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	flag.Example = example
+	return nil
+}
+
+// OnChanged registers fn to be called whenever the value of the named flag is
+// set, during Parse or later with Set, with the string representations of
+// the value before and after it was set. Callbacks are called in the order
+// they were registered, after the value was set successfully.
+func (f *FlagSet) OnChanged(name string, fn func(old, new string)) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	if f.onChanged == nil {
+		f.onChanged = make(map[*Flag][]func(old, new string))
+	}
+	f.onChanged[flag] = append(f.onChanged[flag], fn)
+	return nil
+}
+
+// notifyChanged calls the OnChanged callbacks of flag.
+func (f *FlagSet) notifyChanged(flag *Flag, old, new string) {
+	if f.muteChanged {
+		return
+	}
+	for _, fn := range f.onChanged[flag] {
+		fn(old, new)
+	}
+}
+
 // Lookup returns the Flag structure of the named command-line flag,
 // returning nil if none exists.
 func Lookup(name string) *Flag {
@@ -423,33 +793,112 @@ func ShorthandLookup(name string) *Flag {
 
 // Set sets the value of the named flag.
 func (f *FlagSet) Set(name, value string) error {
+	src := SourceSet
+	if f.parseSource != SourceDefault {
+		src = f.parseSource
+	}
+	return f.set(name, value, src)
+}
+
+// SetWithSource sets the value of the named flag like Set, but records src
+// as where the value comes from, see Flag.Source. It is meant for packages
+// loading flag values from other places than the command line.
+func (f *FlagSet) SetWithSource(name, value string, src ValueSource) error {
+	return f.set(name, value, src)
+}
+
+// set sets the value of the named flag, recording src as where it came from.
+func (f *FlagSet) set(name, value string, src ValueSource) error {
 	normalName := f.normalizeFlagName(name)
 	flag, ok := f.formal[normalName]
 	if !ok {
-		return fmt.Errorf("no such flag -%v", name)
+		if flag := f.Lookup(name); flag != nil {
+			// Inherited flags are set on their FlagSet, see SetParent.
+			return f.flagSetOf(flag).set(flag.Name, value, src)
+		}
+		return fmt.Errorf(f.msg(MsgNoSuchFlag), name)
 	}
+	return f.update(flag, normalName, value, src, func() error {
+		value, err := f.evalValue(flag, value)
+		if err != nil {
+			return err
+		}
+		if err := f.checkValue(flag, value); err != nil {
+			return err
+		}
+		return flag.Value.Set(value)
+	})
+}
 
-	err := flag.Value.Set(value)
-	if err != nil {
+// update changes the value of flag, named normalName in f.formal, with
+// apply, recording src as where the value, whose text is value, came from.
+func (f *FlagSet) update(flag *Flag, normalName NormalizedName, value string, src ValueSource, apply func() error) error {
+	if flag.RemovalAfter != "" && f.removed(flag) {
+		err := fmt.Errorf(f.msg(MsgRemoved), flag.Name, flag.RemovalAfter, flag.Deprecated)
+		if f.logDebug != nil {
+			f.logDebug("removed flag", "flag", flag.Name, "removal", flag.RemovalAfter)
+		}
+		if f.observer != nil {
+			f.observer.DeprecatedFlag(flag, false)
+			f.observer.ValidationFailed(flag, redact(flag, value), err)
+		}
+		return err
+	}
+	// The previous value is only formatted when a callback needs it.
+	var old string
+	notify := len(f.onChanged[flag]) > 0 && !f.muteChanged
+	if notify {
+		old = flag.Value.String()
+	}
+	if err := apply(); err != nil {
+		if f.observer != nil {
+			f.observer.ValidationFailed(flag, redact(flag, value), err)
+		}
 		var flagName string
 		if flag.Shorthand != "" && flag.ShorthandDeprecated == "" {
 			flagName = fmt.Sprintf("-%s, --%s", flag.Shorthand, flag.Name)
 		} else {
 			flagName = fmt.Sprintf("--%s", flag.Name)
 		}
-		return fmt.Errorf("invalid argument %q for %q flag: %v", value, flagName, err)
+		return &InvalidValueError{
+			Flag:  flag,
+			Value: value,
+			Err:   err,
+			msg:   fmt.Sprintf(f.msg(MsgInvalidArgument), value, flagName, err),
+		}
 	}
 
 	if f.actual == nil {
 		f.actual = make(map[NormalizedName]*Flag)
 	}
-	f.actual[normalName] = flag
-	f.orderedActual = append(f.orderedActual, flag)
+	if _, ok := f.actual[normalName]; !ok {
+		f.actual[normalName] = flag
+		f.orderedActual = append(f.orderedActual, flag)
+		f.sortedActual = nil
+	}
 
 	flag.Changed = true
+	flag.source = src
 
-	if flag.Deprecated != "" {
-		fmt.Fprintf(f.out(), "Flag --%s has been deprecated, %s\n", flag.Name, flag.Deprecated)
+	if flag.RemovalAfter != "" {
+		fmt.Fprintf(f.errOut(), f.msg(MsgDeprecatedUntil), flag.Name, flag.RemovalAfter, flag.Deprecated)
+	} else if flag.Deprecated != "" {
+		fmt.Fprintf(f.errOut(), f.msg(MsgDeprecated), flag.Name, flag.Deprecated)
+	}
+	if f.logDebug != nil && flag.Deprecated != "" {
+		f.logDebug("deprecated flag", "flag", flag.Name, "message", flag.Deprecated)
+	}
+	if f.logDebug != nil {
+		f.logDebug("value set", "flag", flag.Name, "value", redact(flag, value), "source", src.String())
+	}
+	if f.observer != nil {
+		if flag.Deprecated != "" {
+			f.observer.DeprecatedFlag(flag, false)
+		}
+		f.observer.FlagSet(flag, redact(flag, value))
+	}
+	if notify {
+		f.notifyChanged(flag, old, flag.Value.String())
 	}
 	return nil
 }
@@ -488,8 +937,17 @@ func Set(name, value string) error {
 
 // PrintDefaults prints, to standard error unless configured
 // otherwise, the default values of all defined flags in the set.
+// Each flag is listed with its argument, as in
+//
+//	-p, --port int       port to listen on (default 8080)
+//	    --color[=auto]   colorize the output (default "never")
+//
+// where --color[=auto] stands for both forms a flag with an optional
+// argument accepts: --color alone sets it to auto, and --color=always to
+// always. The usage messages are wrapped to the width of the terminal, see
+// FlagUsagesWrapped.
 func (f *FlagSet) PrintDefaults() {
-	usages := f.FlagUsages()
+	usages := f.flagUsagesWrapped(f.outputWidth())
 	fmt.Fprint(f.out(), usages)
 }
 
@@ -499,16 +957,18 @@ func (f *Flag) defaultIsZeroValue() bool {
 	switch f.Value.(type) {
 	case boolFlag:
 		return f.DefValue == "false"
-	case *durationValue:
+	case *durationValue, *durationExtValue:
 		// Beginning in Go 1.7, duration zero values are "0s"
 		return f.DefValue == "0" || f.DefValue == "0s"
 	case *intValue, *int8Value, *int32Value, *int64Value, *uintValue, *uint8Value, *uint16Value, *uint32Value, *uint64Value, *countValue, *float32Value, *float64Value:
 		return f.DefValue == "0"
-	case *stringValue:
+	case *stringValue, *templateValue, *globValue:
 		return f.DefValue == ""
+	case *percentValue:
+		return f.DefValue == "0%"
 	case *ipValue, *ipMaskValue, *ipNetValue:
 		return f.DefValue == "<nil>"
-	case *intSliceValue, *stringSliceValue, *stringArrayValue:
+	case *intSliceValue, *stringSliceValue, *stringArrayValue, *stringToStringValue, *tlsCipherSuitesValue, *headerValue:
 		return f.DefValue == "[]"
 	default:
 		switch f.Value.String() {
@@ -528,6 +988,7 @@ func (f *Flag) defaultIsZeroValue() bool {
 // UnquoteUsage extracts a back-quoted name from the usage
 // string for a flag and returns it and the un-quoted usage.
 // Given "a `name` to show" it returns ("name", "a name to show").
+// If the flag has an ArgName, it is returned as the name instead.
 // If there are no back quotes, the name is an educated guess of the
 // type of the flag's value, or the empty string if the flag is boolean.
 func UnquoteUsage(flag *Flag) (name string, usage string) {
@@ -539,6 +1000,9 @@ func UnquoteUsage(flag *Flag) (name string, usage string) {
 				if usage[j] == '`' {
 					name = usage[i+1 : j]
 					usage = usage[:i] + name + usage[j+1:]
+					if flag.ArgName != "" {
+						name = flag.ArgName
+					}
 					return name, usage
 				}
 			}
@@ -546,10 +1010,23 @@ func UnquoteUsage(flag *Flag) (name string, usage string) {
 		}
 	}
 
+	if flag.ArgName != "" {
+		return flag.ArgName, usage
+	}
+	if hint := enumHint(flag); hint != "" {
+		return hint, usage
+	}
+
 	name = flag.Value.Type()
 	switch name {
 	case "bool":
 		name = ""
+	case "durationExt":
+		name = "duration"
+	case "tlsVersion":
+		name = "version"
+	case "tlsCipherSuiteSlice":
+		name = "suites"
 	case "float64":
 		name = "float"
 	case "int64":
@@ -580,30 +1057,42 @@ func wrapN(i, slop int, s string) (string, string) {
 
 // Wraps the string `s` to a maximum width `w` with leading indent
 // `i`. The first line is not indented (this is assumed to be done by
-// caller). Pass `w` == 0 to do no wrapping
+// caller). Line breaks already present in `s` are kept and the lines
+// following them are indented as well. Pass `w` == 0 to do no wrapping
 func wrap(i, w int, s string) string {
 	if w == 0 {
 		return s
 	}
 
-	// space between indent i and end of line width w into which
-	// we should wrap the text.
-	wrap := w - i
-
-	var r, l string
+	var r string
 
 	// Not enough space for sensible wrapping. Wrap as a block on
 	// the next line instead.
-	if wrap < 24 {
+	if w-i < 24 {
 		i = 16
-		wrap = w - i
 		r += "\n" + strings.Repeat(" ", i)
 	}
 	// If still not enough space then don't even try to wrap.
-	if wrap < 24 {
+	if w-i < 24 {
 		return s
 	}
 
+	for n, line := range strings.Split(s, "\n") {
+		if n > 0 {
+			r += "\n" + strings.Repeat(" ", i)
+		}
+		r += wrapLine(i, w, line)
+	}
+	return r
+}
+
+// wrapLine wraps the single line `s` like wrap does, `i` and `w` are
+// expected to leave enough space for wrapping.
+func wrapLine(i, w int, s string) string {
+	// space between indent i and end of line width w into which
+	// we should wrap the text.
+	wrap := w - i
+
 	// Try to avoid short orphan words on the final line, by
 	// allowing wrapN to go a bit over if that would fit in the
 	// remainder of the line.
@@ -611,9 +1100,8 @@ func wrap(i, w int, s string) string {
 	wrap = wrap - slop
 
 	// Handle first line, which is indented by the caller (or the
-	// special case above)
-	l, s = wrapN(wrap, slop, s)
-	r = r + l
+	// special case in wrap)
+	r, s := wrapN(wrap, slop, s)
 
 	// Now wrap the rest
 	for s != "" {
@@ -624,70 +1112,84 @@ func wrap(i, w int, s string) string {
 	}
 
 	return r
+}
 
+// outputWidth returns the width to wrap usage messages to when no explicit
+// width is requested: the width of the terminal the FlagSet's output refers
+// to, or else the value of $COLUMNS. It returns 0 if neither is known, and
+// if the output is not a file, so that help written to a buffer stays the
+// same whatever the environment.
+func (f *FlagSet) outputWidth() int {
+	file, ok := f.out().(*os.File)
+	if !ok {
+		return 0
+	}
+	if cols := terminalWidth(file.Fd()); cols > 0 {
+		return cols
+	}
+	if cols, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && cols > 0 {
+		return cols
+	}
+	return 0
 }
 
 // FlagUsagesWrapped returns a string containing the usage information
-// for all flags in the FlagSet. Wrapped to `cols` columns (0 for no
-// wrapping)
+// for all flags in the FlagSet. Usage messages are wrapped to `cols`
+// columns, with continuation lines aligned under the usage column. If cols
+// is 0, the width of the terminal the FlagSet's output goes to is used,
+// falling back to $COLUMNS; no wrapping happens if neither is known, or if
+// the output is not a file. PrintDefaults and the default usage message
+// wrap the same way, while FlagUsages never wraps.
+// A negative cols disables wrapping. Flags belonging to a group are listed
+// in a separate section per group, see SetGroup.
 func (f *FlagSet) FlagUsagesWrapped(cols int) string {
-	buf := new(bytes.Buffer)
-
-	lines := make([]string, 0, len(f.formal))
+	if cols == 0 {
+		cols = f.outputWidth()
+	}
+	return f.flagUsagesWrapped(cols)
+}
 
-	maxlen := 0
-	f.VisitAll(func(flag *Flag) {
-		if flag.Deprecated != "" || flag.Hidden {
-			return
-		}
+func (f *FlagSet) flagUsagesWrapped(cols int) string {
+	if cols < 0 {
+		cols = 0
+	}
+	return f.formatFlagUsages(f.flagUsages(), cols)
+}
 
-		line := ""
-		if flag.Shorthand != "" && flag.ShorthandDeprecated == "" {
-			line = fmt.Sprintf("  -%s, --%s", flag.Shorthand, flag.Name)
-		} else {
-			line = fmt.Sprintf("      --%s", flag.Name)
-		}
+// formatFlagUsages renders usages in columns, wrapped to cols columns if cols
+// is positive, in sections per group.
+func (f *FlagSet) formatFlagUsages(usages []*FlagUsage, cols int) string {
+	buf := new(bytes.Buffer)
+	sections := f.usageGroups(usages)
 
-		varname, usage := UnquoteUsage(flag)
-		if flag.NoOptDefVal != "" {
-			switch flag.Value.Type() {
-			case "string":
-				line += fmt.Sprintf("[=\"%s\"]", flag.NoOptDefVal)
-			case "bool":
-				if flag.NoOptDefVal != "true" {
-					line += fmt.Sprintf("[=%s]", flag.NoOptDefVal)
-				}
-			default:
-				line += fmt.Sprintf("[=%s]", flag.NoOptDefVal)
+	maxlen := 0
+	for _, section := range sections {
+		for _, u := range section.Flags {
+			// + 1 accounts for the separator placed between the columns
+			if l := len(u.Spec()) + 1; l > maxlen {
+				maxlen = l
 			}
-		} else if varname != "" {
-			line += " " + varname
 		}
+	}
 
-		// This special character will be replaced with spacing once the
-		// correct alignment is calculated
-		line += "\x00"
-		if len(line) > maxlen {
-			maxlen = len(line)
+	for _, section := range sections {
+		if section.Name != "" {
+			if buf.Len() > 0 {
+				fmt.Fprintln(buf)
+			}
+			fmt.Fprintf(buf, "%s:\n", section.Name)
 		}
-
-		line += usage
-		if !flag.defaultIsZeroValue() {
-			if flag.Value.Type() == "string" {
-				line += fmt.Sprintf(" (default %q)", flag.DefValue)
-			} else {
-				line += fmt.Sprintf(" (default %s)", flag.DefValue)
+		for _, u := range section.Flags {
+			line := u.Spec()
+			spacing := strings.Repeat(" ", maxlen-len(line))
+			usage := u.text()
+			// maxlen + 2 comes from + 1 for the separator and + 1 for the (deliberate) off-by-one in the spacing
+			fmt.Fprintln(buf, line, spacing, wrap(maxlen+2, cols, usage))
+			if u.Example != "" {
+				indent := strings.Repeat(" ", maxlen+2)
+				fmt.Fprintln(buf, indent+wrap(maxlen+2, cols, fmt.Sprintf(f.msg(MsgExample), u.Example)))
 			}
 		}
-
-		lines = append(lines, line)
-	})
-
-	for _, line := range lines {
-		sidx := strings.Index(line, "\x00")
-		spacing := strings.Repeat(" ", maxlen-sidx)
-		// maxlen + 2 comes from + 1 for the \x00 and + 1 for the (deliberate) off-by-one in maxlen-sidx
-		fmt.Fprintln(buf, line[:sidx], spacing, wrap(maxlen+2, cols, line[sidx+1:]))
 	}
 
 	return buf.String()
@@ -696,7 +1198,7 @@ func (f *FlagSet) FlagUsagesWrapped(cols int) string {
 // FlagUsages returns a string containing the usage information for all flags in
 // the FlagSet
 func (f *FlagSet) FlagUsages() string {
-	return f.FlagUsagesWrapped(0)
+	return f.flagUsagesWrapped(0)
 }
 
 // PrintDefaults prints to standard error the default values of all defined command-line flags.
@@ -706,8 +1208,15 @@ func PrintDefaults() {
 
 // defaultUsage is the default function to print a usage message.
 func defaultUsage(f *FlagSet) {
-	fmt.Fprintf(f.out(), "Usage of %s:\n", f.name)
+	if f.usageTemplate != nil {
+		f.executeUsageTemplate()
+		return
+	}
+	f.printUsageHeader(f.name)
 	f.PrintDefaults()
+	f.printInherited()
+	f.printPositionals()
+	f.printCommands()
 }
 
 // NOTE: Usage is not just defaultUsage(CommandLine)
@@ -719,8 +1228,15 @@ func defaultUsage(f *FlagSet) {
 // By default it prints a simple header and calls PrintDefaults; for details about the
 // format of the output and how to control it, see the documentation for PrintDefaults.
 var Usage = func() {
-	fmt.Fprintf(os.Stderr, "Usage of %s:\n", os.Args[0])
+	if CommandLine.usageTemplate != nil {
+		CommandLine.executeUsageTemplate()
+		return
+	}
+	CommandLine.printUsageHeader(os.Args[0])
 	PrintDefaults()
+	CommandLine.printInherited()
+	CommandLine.printPositionals()
+	CommandLine.printCommands()
 }
 
 // NFlag returns the number of flags that have been set.
@@ -787,41 +1303,119 @@ func (f *FlagSet) VarP(value Value, name, shorthand, usage string) {
 
 // AddFlag will add the flag to the FlagSet
 func (f *FlagSet) AddFlag(flag *Flag) {
+	if flag.definedAt[0] == 0 {
+		flag.definedAt = captureCallSite()
+	}
 	normalizedFlagName := f.normalizeFlagName(flag.Name)
 
-	_, alreadyThere := f.formal[normalizedFlagName]
+	existing, alreadyThere := f.formal[normalizedFlagName]
 	if alreadyThere {
-		msg := fmt.Sprintf("%s flag redefined: %s", f.name, flag.Name)
-		fmt.Fprintln(f.out(), msg)
-		panic(msg) // Happens only if flags are declared with identical names
+		err := &FlagRedefinedError{FlagSet: f.name, Existing: existing, Flag: flag}
+		fmt.Fprintln(f.errOut(), err.Error())
+		panic(err) // Happens only if flags are declared with identical names
 	}
 	if f.formal == nil {
 		f.formal = make(map[NormalizedName]*Flag)
 	}
 
+	if v, ok := flag.Value.(DefaultArgValue); ok && flag.NoOptDefVal == "" {
+		flag.NoOptDefVal = v.DefaultArg()
+	}
 	flag.Name = string(normalizedFlagName)
 	f.formal[normalizedFlagName] = flag
 	f.orderedFormal = append(f.orderedFormal, flag)
+	f.sortedFormal = nil
+	f.names = nil
+	f.addGroup(flag.Group)
 
 	if flag.Shorthand == "" {
 		return
 	}
-	if len(flag.Shorthand) > 1 {
+	if len(flag.Shorthand) > 1 && !f.multiCharShorthands {
 		msg := fmt.Sprintf("%q shorthand is more than one ASCII character", flag.Shorthand)
-		fmt.Fprintf(f.out(), msg)
+		fmt.Fprint(f.errOut(), msg)
 		panic(msg)
 	}
 	if f.shorthands == nil {
-		f.shorthands = make(map[byte]*Flag)
+		f.shorthands = make(map[string]*Flag)
 	}
-	c := flag.Shorthand[0]
-	used, alreadyThere := f.shorthands[c]
+	used, alreadyThere := f.shorthands[flag.Shorthand]
 	if alreadyThere {
-		msg := fmt.Sprintf("unable to redefine %q shorthand in %q flagset: it's already used for %q flag", c, f.name, used.Name)
-		fmt.Fprintf(f.out(), msg)
-		panic(msg)
+		err := &ShorthandConflictError{FlagSet: f.name, Shorthand: flag.Shorthand, Existing: used, Flag: flag}
+		if f.collectConflicts {
+			f.conflicts = append(f.conflicts, err)
+			flag.Shorthand = ""
+			return
+		}
+		fmt.Fprint(f.errOut(), err.Error())
+		panic(err)
+	}
+	f.shorthands[flag.Shorthand] = flag
+	if len(flag.Shorthand) > f.maxShorthandLen {
+		f.maxShorthandLen = len(flag.Shorthand)
 	}
-	f.shorthands[c] = flag
+}
+
+// Remove removes the named flag from the FlagSet, together with its
+// shorthand, so that it can no longer be used on the command line nor shows
+// up in help and usage messages.
+func (f *FlagSet) Remove(name string) error {
+	normalName := f.normalizeFlagName(name)
+	flag, ok := f.formal[normalName]
+	if !ok {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+
+	delete(f.formal, normalName)
+	delete(f.onChanged, flag)
+	f.orderedFormal = removeFlag(f.orderedFormal, flag)
+	f.sortedFormal = nil
+	f.names = nil
+	if flag.Shorthand != "" && f.shorthands[flag.Shorthand] == flag {
+		delete(f.shorthands, flag.Shorthand)
+	}
+	if _, ok := f.actual[normalName]; ok {
+		delete(f.actual, normalName)
+		f.orderedActual = removeFlag(f.orderedActual, flag)
+		f.sortedActual = nil
+	}
+	return nil
+}
+
+// removeFlag returns flags without any occurrence of flag.
+func removeFlag(flags []*Flag, flag *Flag) []*Flag {
+	result := flags[:0]
+	for _, fl := range flags {
+		if fl != flag {
+			result = append(result, fl)
+		}
+	}
+	return result
+}
+
+// Replace replaces the named flag by the given one, which may have a
+// different name, shorthand or type, e.g. to override a flag inherited from
+// a library through AddFlagSet. It returns an error, leaving the FlagSet
+// unchanged, if the name or shorthand of flag is used by another flag.
+func (f *FlagSet) Replace(name string, flag *Flag) error {
+	old := f.formal[f.normalizeFlagName(name)]
+	if old == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	if existing := f.formal[f.normalizeFlagName(flag.Name)]; existing != nil && existing != old {
+		return &FlagRedefinedError{FlagSet: f.name, Existing: existing, Flag: flag}
+	}
+	if flag.Shorthand != "" {
+		if len(flag.Shorthand) > 1 && !f.multiCharShorthands {
+			return fmt.Errorf("%q shorthand is more than one ASCII character", flag.Shorthand)
+		}
+		if used := f.shorthands[flag.Shorthand]; used != nil && used != old && !f.collectConflicts {
+			return &ShorthandConflictError{FlagSet: f.name, Shorthand: flag.Shorthand, Existing: used, Flag: flag}
+		}
+	}
+	f.Remove(name)
+	f.AddFlag(flag)
+	return nil
 }
 
 // AddFlagSet adds one FlagSet to another. If a flag is already present in f
@@ -831,12 +1425,39 @@ func (f *FlagSet) AddFlagSet(newSet *FlagSet) {
 		return
 	}
 	newSet.VisitAll(func(flag *Flag) {
-		if f.Lookup(flag.Name) == nil {
+		if _, ok := f.formal[f.normalizeFlagName(flag.Name)]; !ok {
 			f.AddFlag(flag)
 		}
 	})
 }
 
+// AddFlagSetWithPrefix adds copies of the flags of newSet to f, named with
+// prefix and a dash before their name, like --db-host for the flag host and
+// the prefix "db". The copies share the Values of the flags of newSet, so
+// that they set the same variables, but are marked as Changed in f only.
+// Shorthands are dropped, being likely to collide. Like AddFlag, it panics
+// if a prefixed name is already defined in f.
+func (f *FlagSet) AddFlagSetWithPrefix(prefix string, newSet *FlagSet) {
+	if newSet == nil {
+		return
+	}
+	newSet.VisitAll(func(flag *Flag) {
+		c := *flag
+		c.Name = prefix + "-" + flag.Name
+		c.Shorthand = ""
+		c.ShorthandDeprecated = ""
+		c.Changed = false
+		c.occurrences = 0
+		if flag.Annotations != nil {
+			c.Annotations = make(map[string][]string, len(flag.Annotations))
+			for k, v := range flag.Annotations {
+				c.Annotations[k] = copyStrings(v)
+			}
+		}
+		f.AddFlag(&c)
+	})
+}
+
 // Var defines a flag with the specified name and usage string. The type and
 // value of the flag are represented by the first argument, of type Value, which
 // typically holds a user-defined implementation of Value. For instance, the
@@ -855,8 +1476,14 @@ func VarP(value Value, name, shorthand, usage string) {
 // failf prints to standard error a formatted error and usage message and
 // returns the error.
 func (f *FlagSet) failf(format string, a ...interface{}) error {
-	err := fmt.Errorf(format, a...)
-	fmt.Fprintln(f.out(), err)
+	err := &reportedError{fmt.Errorf(format, a...)}
+	fmt.Fprintln(f.errOut(), err)
+	if f.errOutput != nil {
+		// The usage printed after an error is part of the error.
+		output := f.output
+		f.output = f.errOutput
+		defer func() { f.output = output }()
+	}
 	f.usage()
 	return err
 }
@@ -877,90 +1504,144 @@ func (f *FlagSet) parseLongArg(s string, args []string, fn parseFunc) (a []strin
 	a = args
 	name := s[2:]
 	if len(name) == 0 || name[0] == '-' || name[0] == '=' {
-		err = f.failf("bad flag syntax: %s", s)
+		err = f.failf(f.msg(MsgBadFlagSyntax), s)
 		return
 	}
 
-	split := strings.SplitN(name, "=", 2)
-	name = split[0]
-	flag, exists := f.formal[f.normalizeFlagName(name)]
+	// Look for '=' rather than splitting, to avoid allocating on every flag.
+	eq := strings.IndexByte(name, '=')
+	if eq >= 0 {
+		name = s[2 : 2+eq]
+	}
+	if !utf8.ValidString(name) {
+		err = f.failf(f.msg(MsgBadFlagSyntax), strconv.Quote(s))
+		return
+	}
+	flag, exists := f.parseLookup(name)
+	if !exists && !f.isHelp(name) {
+		if flag, err = f.lookupAbbreviation(name); err != nil {
+			return
+		}
+		exists = flag != nil
+	}
 	if !exists {
-		if name == "help" { // special case for nice help message.
-			f.usage()
-			return a, ErrHelp
+		if f.isHelp(name) { // special case for nice help message.
+			return a, f.help()
 		}
-		err = f.failf("unknown flag: --%s", name)
+		if f.logDebug != nil {
+			f.logDebug("unknown flag", "arg", "--"+name)
+		}
+		if f.observer != nil {
+			f.observer.UnknownFlag("--" + name)
+		}
+		err = f.unknownFlag(name)
 		return
 	}
 
 	var value string
-	if len(split) == 2 {
+	src := SourceCommandLine
+	if eq >= 0 {
 		// '--flag=arg'
-		value = split[1]
+		value = s[3+eq:]
 	} else if flag.NoOptDefVal != "" {
 		// '--flag' (arg was optional)
 		value = flag.NoOptDefVal
+		src = SourceDefaultArg
 	} else if len(a) > 0 {
 		// '--flag arg'
+		if f.requiresEquals(flag) {
+			err = f.failf(f.msg(MsgRequiresEquals), flag.Name, flag.Name, a[0])
+			return
+		}
 		value = a[0]
 		a = a[1:]
 	} else {
 		// '--flag' (arg was required)
-		err = f.failf("flag needs an argument: %s", s)
+		err = f.failf(f.msg(MsgNeedsArgument), s)
 		return
 	}
 
-	err = fn(flag, value)
+	err = fn(flag, value, src)
+	if err == nil && flag.greedy && len(a) < len(args) {
+		// '--flag arg arg...', see SetGreedy
+		a, err = f.consumeGreedy(flag, a, fn)
+	}
 	return
 }
 
-func (f *FlagSet) parseSingleShortArg(shorthands string, args []string, fn parseFunc) (outShorts string, outArgs []string, err error) {
+func (f *FlagSet) parseSingleShortArg(group, shorthands string, args []string, fn parseFunc) (outShorts string, outArgs []string, err error) {
 	if strings.HasPrefix(shorthands, "test.") {
 		return
 	}
 
 	outArgs = args
-	outShorts = shorthands[1:]
-	c := shorthands[0]
+	flag, n := f.shorthandAt(shorthands)
+	outShorts = shorthands[n:]
+	c := shorthandArg(shorthands[:n])
 
-	flag, exists := f.shorthands[c]
-	if !exists {
-		if c == 'h' { // special case for nice help message.
-			f.usage()
-			err = ErrHelp
+	if flag == nil {
+		if f.isHelpShorthand(shorthands[:n]) { // special case for nice help message.
+			err = f.help()
 			return
 		}
-		err = f.failf("unknown shorthand flag: %q in -%s", c, shorthands)
+		if f.logDebug != nil {
+			f.logDebug("unknown flag", "arg", "-"+shorthands[:n])
+		}
+		if f.observer != nil {
+			f.observer.UnknownFlag("-" + shorthands[:n])
+		}
+		err = f.failf(f.msg(MsgUnknownShorthand), c, shorthands)
 		return
 	}
 
 	var value string
-	if len(shorthands) > 2 && shorthands[1] == '=' {
-		// '-f=arg'
-		value = shorthands[2:]
+	src := SourceCommandLine
+	if len(shorthands) > n && shorthands[n] == '=' {
+		// '-f=arg', possibly empty
+		value = shorthands[n+1:]
 		outShorts = ""
 	} else if flag.NoOptDefVal != "" {
 		// '-f' (arg was optional)
 		value = flag.NoOptDefVal
-	} else if len(shorthands) > 1 {
-		// '-farg'
-		value = shorthands[1:]
+		src = SourceDefaultArg
+	} else if len(shorthands) > n {
+		// '-farg', the rest of the group being the argument
+		if f.strictShorthandGroups && len(shorthands) < len(group) {
+			// '-abfarg' is likely a mistake for '-abf arg' or '-afb'
+			err = f.failf(f.msg(MsgShorthandMidGroup), c, group)
+			return
+		}
+		value = shorthands[n:]
 		outShorts = ""
 	} else if len(args) > 0 {
 		// '-f arg'
+		if f.requiresEquals(flag) {
+			err = f.failf(f.msg(MsgRequiresEquals), flag.Name, flag.Name, args[0])
+			return
+		}
 		value = args[0]
 		outArgs = args[1:]
 	} else {
 		// '-f' (arg was required)
-		err = f.failf("flag needs an argument: %q in -%s", c, shorthands)
+		err = f.failf(f.msg(MsgShorthandNeedsArgument), c, shorthands)
 		return
 	}
 
 	if flag.ShorthandDeprecated != "" {
-		fmt.Fprintf(f.out(), "Flag shorthand -%s has been deprecated, %s\n", flag.Shorthand, flag.ShorthandDeprecated)
+		fmt.Fprintf(f.errOut(), f.msg(MsgShorthandDeprecated), flag.Shorthand, flag.ShorthandDeprecated)
+		if f.logDebug != nil {
+			f.logDebug("deprecated shorthand", "flag", flag.Name, "shorthand", flag.Shorthand, "message", flag.ShorthandDeprecated)
+		}
+		if f.observer != nil {
+			f.observer.DeprecatedFlag(flag, true)
+		}
 	}
 
-	err = fn(flag, value)
+	err = fn(flag, value, src)
+	if err == nil && flag.greedy && len(outArgs) < len(args) {
+		// '-f arg arg...', see SetGreedy
+		outArgs, err = f.consumeGreedy(flag, outArgs, fn)
+	}
 	return
 }
 
@@ -970,7 +1651,7 @@ func (f *FlagSet) parseShortArg(s string, args []string, fn parseFunc) (a []stri
 
 	// "shorthands" can be a series of shorthand letters of flags (e.g. "-vvv").
 	for len(shorthands) > 0 {
-		shorthands, a, err = f.parseSingleShortArg(shorthands, args, fn)
+		shorthands, a, err = f.parseSingleShortArg(s[1:], shorthands, a, fn)
 		if err != nil {
 			return
 		}
@@ -980,10 +1661,56 @@ func (f *FlagSet) parseShortArg(s string, args []string, fn parseFunc) (a []stri
 }
 
 func (f *FlagSet) parseArgs(args []string, fn parseFunc) (err error) {
+	total := len(args)
+	defer func() { f.argsParsed += total }()
+	argsBefore := len(f.args)
+	pos := 0
+	f.parseSeq++
+	record := func(flag *Flag, value string, src ValueSource) error {
+		if f.logDebug != nil {
+			f.logDebug("flag matched", "flag", flag.Name, "value", redact(flag, value), "source", src.String(), "position", pos)
+		}
+		if !f.inGreedy {
+			repeated := flag.parsedBy == f && flag.parseSeq == f.parseSeq
+			flag.parseSeq, flag.parsedBy = f.parseSeq, f
+			f.occurrences = append(f.occurrences, flagOccurrence{flag, pos})
+			skip, err := f.countOccurrence(flag, repeated)
+			if skip || err != nil {
+				return err
+			}
+		}
+		var err error
+		if value, err = f.resolveValue(flag, value, src); err != nil {
+			return err
+		}
+		if err = fn(flag, value, src); err == nil && f.versionRequested(flag, value) {
+			err = f.printVersion()
+		}
+		return err
+	}
+
+	aliasRest := len(args) // args left after the expansion of an alias
 	for len(args) > 0 {
+		if err = f.contextErr(); err != nil {
+			return
+		}
+		pos = f.argsParsed + total - len(args)
 		s := args[0]
+		inAlias := len(args) > aliasRest
 		args = args[1:]
-		if len(s) == 0 || s[0] != '-' || len(s) == 1 {
+		if len(s) == 0 || s[0] != '-' || len(s) == 1 || f.isNegativeNumber(s) {
+			if f.logDebug != nil {
+				f.logDebug("positional argument", "arg", s, "position", pos)
+			}
+			if cmd := f.lookupCommand(s); cmd != nil && len(f.args) == argsBefore {
+				if f.logDebug != nil {
+					f.logDebug("command selected", "command", s)
+				}
+				f.command = cmd
+				f.args = append(f.args, s)
+				f.args = append(f.args, args...)
+				return cmd.fs.Parse(args)
+			}
 			if !f.interspersed {
 				f.args = append(f.args, s)
 				f.args = append(f.args, args...)
@@ -993,15 +1720,28 @@ func (f *FlagSet) parseArgs(args []string, fn parseFunc) (err error) {
 			continue
 		}
 
+		if expansion, ok := f.expandAlias(s); ok && !inAlias {
+			if f.logDebug != nil {
+				f.logDebug("alias expanded", "alias", s, "expansion", expansion, "position", pos)
+			}
+			aliasRest = len(args)
+			args = append(copyStrings(expansion), args...)
+			total += len(expansion) - 1
+			continue
+		}
+
 		if s[1] == '-' {
 			if len(s) == 2 { // "--" terminates the flags
+				if f.logDebug != nil {
+					f.logDebug("end of flags", "position", pos)
+				}
 				f.argsLenAtDash = len(f.args)
 				f.args = append(f.args, args...)
 				break
 			}
-			args, err = f.parseLongArg(s, args, fn)
+			args, err = f.parseLongArg(s, args, record)
 		} else {
-			args, err = f.parseShortArg(s, args, fn)
+			args, err = f.parseShortArg(s, args, record)
 		}
 		if err != nil {
 			return
@@ -1010,10 +1750,52 @@ func (f *FlagSet) parseArgs(args []string, fn parseFunc) (err error) {
 	return
 }
 
+// ParseOne parses the flag at the start of args, for callers driving the
+// parsing themselves. It returns the number of arguments consumed, which is
+// 2 if the value of the flag was the next argument and 1 otherwise, and the
+// flag that was set, or the last one for a group of shorthands like "-vvv".
+// If args is empty or starts with a positional argument, ParseOne returns 0
+// and a nil flag; for the "--" terminator it returns 1 and a nil flag.
+// Unlike Parse, errors are returned regardless of the ErrorHandling of the
+// FlagSet, and Args and the environment are left alone.
+func (f *FlagSet) ParseOne(args []string) (consumed int, flag *Flag, err error) {
+	if len(args) == 0 {
+		return 0, nil, nil
+	}
+	f.parsed = true
+	s := args[0]
+	if len(s) == 0 || s[0] != '-' || len(s) == 1 {
+		return 0, nil, nil
+	}
+	if s == "--" {
+		return 1, nil, nil
+	}
+
+	set := func(fl *Flag, value string, src ValueSource) error {
+		flag = fl
+		return f.set(fl.Name, value, src)
+	}
+	var rest []string
+	if s[1] == '-' {
+		rest, err = f.parseLongArg(s, args[1:], set)
+	} else {
+		rest, err = f.parseShortArg(s, args[1:], set)
+	}
+	return len(args) - len(rest), flag, err
+}
+
 // Parse parses flag definitions from the argument list, which should not
 // include the command name.  Must be called after all flags in the FlagSet
 // are defined and before flags are accessed by the program.
 // The return value will be ErrHelp if -help was set but not defined.
+// Flags not given in the arguments are then set from the environment, if
+// enabled with SetEnvPrefix. See also PreParse and PostParse.
+//
+// Parse may be called several times, e.g. with base arguments and then with
+// the ones of a profile: every call sets flags on top of the previous ones,
+// so that Changed reports the flags set by any call, and the remaining
+// arguments are appended to Args. Reset restores the FlagSet to its state
+// before the first call.
 func (f *FlagSet) Parse(arguments []string) error {
 	f.parsed = true
 
@@ -1021,19 +1803,49 @@ func (f *FlagSet) Parse(arguments []string) error {
 		return nil
 	}
 
-	f.args = make([]string, 0, len(arguments))
+	arguments = f.runPreParse(arguments)
+	if f.args == nil {
+		f.args = make([]string, 0, len(arguments))
+	}
 
-	set := func(flag *Flag, value string) error {
-		return f.Set(flag.Name, value)
+	set := func(flag *Flag, value string, src ValueSource) error {
+		return f.flagSetOf(flag).set(flag.Name, value, src)
 	}
 
 	err := f.parseArgs(arguments, set)
+	if err == nil {
+		err = f.checkMinOccurrences()
+	}
+	if err == nil {
+		err = f.applyEnv()
+	}
+	if err == nil {
+		err = f.resolveDefaults()
+	}
+	if err == nil {
+		err = f.contextErr()
+	}
+	if err == nil {
+		err = f.checkRequired()
+	}
+	if err == nil {
+		err = f.checkArgs()
+	}
+	if err == nil {
+		err = f.bindPositionals()
+	}
+	if err == nil {
+		err = f.runPostParse()
+	}
+	if err != nil && err == f.contextErr() {
+		return err
+	}
 	if err != nil {
 		switch f.errorHandling {
 		case ContinueOnError:
 			return err
 		case ExitOnError:
-			os.Exit(2)
+			f.exitOnError(err)
 		case PanicOnError:
 			panic(err)
 		}
@@ -1041,7 +1853,7 @@ func (f *FlagSet) Parse(arguments []string) error {
 	return nil
 }
 
-type parseFunc func(flag *Flag, value string) error
+type parseFunc func(flag *Flag, value string, src ValueSource) error
 
 // ParseAll parses flag definitions from the argument list, which should not
 // include the command name. The arguments for fn are flag and value. Must be
@@ -1050,15 +1862,41 @@ type parseFunc func(flag *Flag, value string) error
 // but not defined.
 func (f *FlagSet) ParseAll(arguments []string, fn func(flag *Flag, value string) error) error {
 	f.parsed = true
-	f.args = make([]string, 0, len(arguments))
+	arguments = f.runPreParse(arguments)
+	if f.args == nil {
+		f.args = make([]string, 0, len(arguments))
+	}
 
-	err := f.parseArgs(arguments, fn)
+	err := f.parseArgs(arguments, func(flag *Flag, value string, src ValueSource) error {
+		// Flags set by fn through Set are reported as coming from src.
+		f.parseSource = src
+		defer func() { f.parseSource = SourceDefault }()
+		return fn(flag, value)
+	})
+	if err == nil {
+		err = f.checkMinOccurrences()
+	}
+	if err == nil {
+		err = f.resolveDefaults()
+	}
+	if err == nil {
+		err = f.checkRequired()
+	}
+	if err == nil {
+		err = f.checkArgs()
+	}
+	if err == nil {
+		err = f.bindPositionals()
+	}
+	if err == nil {
+		err = f.runPostParse()
+	}
 	if err != nil {
 		switch f.errorHandling {
 		case ContinueOnError:
 			return err
 		case ExitOnError:
-			os.Exit(2)
+			f.exitOnError(err)
 		case PanicOnError:
 			panic(err)
 		}
@@ -1112,6 +1950,44 @@ func NewFlagSet(name string, errorHandling ErrorHandling) *FlagSet {
 	return f
 }
 
+// SetNegativeNumbers sets whether arguments which are negative numbers, like
+// -1 or -2.5, are positional arguments rather than groups of shorthands, so
+// that e.g. "seq -5 5" needs no "--". It only applies while no shorthand of
+// the FlagSet is a digit. Negative numbers are always accepted as the value
+// of a flag, as in "--offset -5".
+func (f *FlagSet) SetNegativeNumbers(enabled bool) {
+	f.negativeNumbers = enabled
+}
+
+// SetStrictShorthandGroups sets whether a shorthand taking a value may only
+// be the last of a group of shorthands, as in "-rvf archive.tar" or
+// "-rvf=archive.tar", or else the first, as in "-farchive.tar". Parsing then
+// fails for "-rfv archive.tar", which would otherwise set -f to "v".
+func (f *FlagSet) SetStrictShorthandGroups(strict bool) {
+	f.strictShorthandGroups = strict
+}
+
+// isNegativeNumber returns true if s must be handled as a positional
+// negative number, see SetNegativeNumbers.
+func (f *FlagSet) isNegativeNumber(s string) bool {
+	if !f.negativeNumbers || len(s) < 2 || s[0] != '-' {
+		return false
+	}
+	// Leave alone groups of shorthands which ParseFloat accepts, like -inf.
+	if c := s[1]; c != '.' && (c < '0' || c > '9') {
+		return false
+	}
+	if _, err := strconv.ParseFloat(s, 64); err != nil {
+		return false
+	}
+	for c := range f.shorthands {
+		if '0' <= c[0] && c[0] <= '9' {
+			return false
+		}
+	}
+	return true
+}
+
 // SetInterspersed sets whether to support interspersed option/non-option arguments.
 func (f *FlagSet) SetInterspersed(interspersed bool) {
 	f.interspersed = interspersed
@@ -128,6 +128,36 @@ func TestAddFlagSet(t *testing.T) {
 	}
 }
 
+func TestAddFlagSetWithPrefix(t *testing.T) {
+	dbSet := NewFlagSet("db", ContinueOnError)
+	host := dbSet.StringP("host", "H", "localhost", "database host")
+	port := dbSet.Int("port", 5432, "database port")
+
+	f := NewFlagSet("app", ContinueOnError)
+	f.AddFlagSetWithPrefix("db", dbSet)
+	if err := f.Parse([]string{"--db-host=db.example.com", "--db-port", "6432"}); err != nil {
+		t.Fatal(err)
+	}
+	if *host != "db.example.com" || *port != 6432 {
+		t.Errorf("got host %q and port %d", *host, *port)
+	}
+	flag := f.Lookup("db-host")
+	if flag == nil || flag.Shorthand != "" || flag.DefValue != "localhost" || !flag.Changed {
+		t.Errorf("got %+v", flag)
+	}
+	if f.Lookup("host") != nil || dbSet.Changed("host") {
+		t.Error("the flags of the added set should be left alone")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a redefined flag")
+		}
+	}()
+	f.SetOutput(ioutil.Discard)
+	f.AddFlagSetWithPrefix("db", dbSet)
+}
+
 func TestAnnotation(t *testing.T) {
 	f := NewFlagSet("shorthand", ContinueOnError)
 
@@ -702,8 +732,42 @@ func TestSetOutput(t *testing.T) {
 	}
 }
 
-// This tests that one can reset the flags. This still works but not well, and is
-// superseded by FlagSet.
+func TestSetErrOutput(t *testing.T) {
+	var out, errOut bytes.Buffer
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(&out)
+	f.SetErrOutput(&errOut)
+	f.Bool("old", false, "")
+	f.MarkDeprecated("old", "use --new")
+
+	if err := f.Parse([]string{"--help"}); err != ErrHelp {
+		t.Fatalf("got error %v, want ErrHelp", err)
+	}
+	if !strings.Contains(out.String(), "Usage of test") || errOut.Len() != 0 {
+		t.Errorf("got output %q and error output %q for --help", out.String(), errOut.String())
+	}
+
+	out.Reset()
+	if err := f.Parse([]string{"--old", "--unknown"}); err == nil {
+		t.Fatal("expected an error for an unknown flag")
+	}
+	if got := errOut.String(); !strings.Contains(got, "deprecated") || !strings.Contains(got, "unknown flag: --unknown") || !strings.Contains(got, "Usage of test") {
+		t.Errorf("got error output %q", got)
+	}
+	if out.Len() != 0 {
+		t.Errorf("got output %q after an error", out.String())
+	}
+
+	out.Reset()
+	f.usage()
+	if out.Len() == 0 {
+		t.Error("expected the usage on the output once parsing failed")
+	}
+}
+
+// This tests that one can define more flags and parse again, the remaining
+// arguments accumulating across calls to Parse. This still works but not
+// well, and is superseded by FlagSet.
 func TestChangingArgs(t *testing.T) {
 	ResetForTesting(func() { t.Fatal("bad parse") })
 	oldArgs := os.Args
@@ -719,8 +783,8 @@ func TestChangingArgs(t *testing.T) {
 	Parse()
 	args := Args()
 
-	if !*before || cmd != "subcmd" || !*after || len(args) != 1 || args[0] != "args" {
-		t.Fatalf("expected true subcmd true [args] got %v %v %v %v", *before, cmd, *after, args)
+	if !*before || cmd != "subcmd" || !*after || len(args) != 2 || args[0] != "subcmd" || args[1] != "args" {
+		t.Fatalf("expected true subcmd true [subcmd args] got %v %v %v %v", *before, cmd, *after, args)
 	}
 }
 
@@ -1039,8 +1103,8 @@ func TestPrintDefaults(t *testing.T) {
 	got := buf.String()
 	if got != defaultOutput {
 		fmt.Println("\n" + got)
-		fmt.Println("\n" + defaultOutput)
-		t.Errorf("got %q want %q\n", got, defaultOutput)
+		fmt.Print("\n" + defaultOutput + "\n")
+		t.Errorf("got %q want %q", got, defaultOutput)
 	}
 }
 
@@ -1083,3 +1147,509 @@ func TestVisitFlagOrder(t *testing.T) {
 		i++
 	})
 }
+
+const groupedOutput = `      --verbose             be verbose
+
+logging:
+      --log-format string   log output format (default "text")
+      --log-level string    minimum log level (default "info")
+
+network:
+      --port int            port to listen on (default 8080)
+`
+
+func TestFlagUsagesGrouped(t *testing.T) {
+	fs := NewFlagSet("grouped", ContinueOnError)
+	fs.Int("port", 8080, "port to listen on")
+	fs.String("log-level", "info", "minimum log level")
+	fs.Bool("verbose", false, "be verbose")
+	fs.String("log-format", "text", "log output format")
+
+	if err := fs.SetGroup("logging", "log-level", "log-format"); err != nil {
+		t.Fatal(err)
+	}
+	fs.Lookup("port").Group = "network"
+	if err := fs.SetGroup("logging", "nope"); err == nil {
+		t.Error("expected an error for an undefined flag")
+	}
+
+	if got := fs.FlagUsages(); got != groupedOutput {
+		t.Errorf("got %q want %q", got, groupedOutput)
+	}
+}
+
+func TestFlagUsagesWrapped(t *testing.T) {
+	out, err := ioutil.TempFile("", "usage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(out.Name())
+	defer out.Close()
+	fs := NewFlagSet("wrapped", ContinueOnError)
+	fs.SetOutput(out)
+	fs.String("name", "", "the name of the thing which is being described at quite some length here")
+	fs.Int("n", 0, "first line\nsecond line")
+
+	want := "      --n int         first line\n" +
+		"                      second line\n" +
+		"      --name string   the name of the thing which is being\n" +
+		"                      described at quite some length here\n"
+	if got := fs.FlagUsagesWrapped(70); got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+
+	os.Setenv("COLUMNS", "70")
+	defer os.Unsetenv("COLUMNS")
+	if got := fs.FlagUsagesWrapped(0); got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+	fs.PrintDefaults()
+	if got, err := ioutil.ReadFile(out.Name()); err != nil || string(got) != want {
+		t.Errorf("PrintDefaults printed %q, %v, want %q", got, err, want)
+	}
+	buf := new(bytes.Buffer)
+	fs.SetOutput(buf)
+	if fs.PrintDefaults(); buf.String() != fs.FlagUsages() {
+		t.Errorf("PrintDefaults wrapped its output to a buffer: %q", buf)
+	}
+	if got, want := fs.FlagUsagesWrapped(-1), fs.FlagUsages(); got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+}
+
+func TestSortFunc(t *testing.T) {
+	fs := NewFlagSet("TestSortFunc", ContinueOnError)
+	for _, name := range []string{"b", "important", "a", "c"} {
+		fs.Bool(name, false, "")
+	}
+	fs.VisitAll(func(*Flag) {}) // populate the sort cache
+	fs.SetSortFunc(func(a, b *Flag) bool {
+		return a.Name == "important" && b.Name != "important"
+	})
+
+	want := []string{"important", "a", "b", "c"}
+	i := 0
+	fs.VisitAll(func(f *Flag) {
+		if want[i] != f.Name {
+			t.Errorf("Incorrect order. Expected %v, got %v", want[i], f.Name)
+		}
+		i++
+	})
+}
+
+func TestSetArgName(t *testing.T) {
+	fs := NewFlagSet("TestSetArgName", ContinueOnError)
+	fs.StringP("output", "o", "", "write to the given file")
+	fs.String("input", "", "read from `path`")
+	fs.SetArgName("output", "FILE")
+	fs.SetArgName("input", "FILE")
+	if err := fs.SetArgName("nope", "FILE"); err == nil {
+		t.Error("expected an error for an undefined flag")
+	}
+
+	want := "      --input FILE    read from path\n" +
+		"  -o, --output FILE   write to the given file\n"
+	if got := fs.FlagUsages(); got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+}
+
+func TestDefaultText(t *testing.T) {
+	fs := NewFlagSet("TestDefaultText", ContinueOnError)
+	fs.String("config", "", "config directory")
+	fs.String("token", "s3cr3t", "API token")
+	fs.SetDefaultText("config", "$HOME/.config")
+	fs.MarkDefaultHidden("token")
+
+	want := "      --config string   config directory (default $HOME/.config)\n" +
+		"      --token string    API token\n"
+	if got := fs.FlagUsages(); got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+	if err := fs.MarkDefaultHidden("nope"); err == nil {
+		t.Error("expected an error for an undefined flag")
+	}
+}
+
+func TestSetExample(t *testing.T) {
+	fs := NewFlagSet("TestSetExample", ContinueOnError)
+	fs.StringP("header", "H", "", "add a request header")
+	fs.Bool("verbose", false, "be verbose")
+	fs.SetExample("header", `-H "Accept: application/json"`)
+
+	want := "  -H, --header string   add a request header\n" +
+		"                        Example: -H \"Accept: application/json\"\n" +
+		"      --verbose         be verbose\n"
+	if got := fs.FlagUsages(); got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+
+	var buf bytes.Buffer
+	GenMarkdownDocs(fs, &buf)
+	if !strings.Contains(buf.String(), "add a request header<br>Example: `-H \"Accept: application/json\"`") {
+		t.Errorf("example missing from markdown: %q", buf.String())
+	}
+}
+
+func TestVisitInOrder(t *testing.T) {
+	fs := NewFlagSet("TestVisitInOrder", ContinueOnError)
+	fs.StringSliceP("filter", "f", nil, "")
+	fs.BoolP("verbose", "v", false, "")
+	fs.BoolP("quiet", "q", false, "")
+	fs.Parse([]string{"--filter=b", "arg", "-vq", "-f", "a", "--verbose"})
+
+	type occurrence struct {
+		name     string
+		position int
+	}
+	want := []occurrence{{"filter", 0}, {"verbose", 2}, {"quiet", 2}, {"filter", 3}, {"verbose", 5}}
+	var got []occurrence
+	fs.VisitInOrder(func(f *Flag, position int) {
+		got = append(got, occurrence{f.Name, position})
+	})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestRemoveAndReplace(t *testing.T) {
+	fs := NewFlagSet("TestRemoveAndReplace", ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.BoolP("debug", "d", false, "")
+	fs.String("level", "info", "")
+	fs.Int("n", 0, "")
+	fs.Set("debug", "true")
+
+	if err := fs.Remove("debug"); err != nil {
+		t.Fatal(err)
+	}
+	if fs.Lookup("debug") != nil || fs.ShorthandLookup("d") != nil || fs.NFlag() != 0 {
+		t.Error("debug flag was not removed")
+	}
+	if err := fs.Parse([]string{"-d"}); err == nil {
+		t.Error("expected an error using a removed flag")
+	}
+	if err := fs.Remove("debug"); err == nil {
+		t.Error("expected an error removing an undefined flag")
+	}
+
+	var level int
+	if err := fs.Replace("level", &Flag{Name: "level", Shorthand: "l", Value: newIntValue(2, &level), DefValue: "2"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Parse([]string{"-l", "3"}); err != nil {
+		t.Fatal(err)
+	}
+	if level != 3 {
+		t.Errorf("got level %d want 3", level)
+	}
+	if got, want := fs.FlagUsages(), "  -l, --level int    (default 2)\n      --n int       \n"; got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+
+	if err := fs.Replace("level", &Flag{Name: "n", Value: newIntValue(0, new(int))}); err == nil {
+		t.Error("expected an error replacing a flag by one with a used name")
+	}
+	if err := fs.Replace("n", &Flag{Name: "n", Shorthand: "l", Value: newIntValue(0, new(int))}); err == nil {
+		t.Error("expected an error replacing a flag by one with a used shorthand")
+	}
+	if fs.Lookup("level") == nil || fs.Lookup("n") == nil || fs.ShorthandLookup("l") == nil {
+		t.Error("a failed Replace removed a flag")
+	}
+}
+
+func TestOnChanged(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.String("level", "info", "")
+	var calls []string
+	if err := f.OnChanged("level", func(old, new string) {
+		calls = append(calls, old+"->"+new)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.OnChanged("missing", func(old, new string) {}); err == nil {
+		t.Error("expected an error for a missing flag")
+	}
+	if err := f.Parse([]string{"--level=debug"}); err != nil {
+		t.Fatal(err)
+	}
+	f.Set("level", "error")
+	f.Set("level", "x")
+	if want := []string{"info->debug", "debug->error", "error->x"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("got calls %v want %v", calls, want)
+	}
+}
+
+func TestParseOne(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	name := f.StringP("name", "n", "", "")
+	f.BoolP("verbose", "v", false, "")
+
+	tests := []struct {
+		args     []string
+		consumed int
+		flag     string
+		err      bool
+	}{
+		{nil, 0, "", false},
+		{[]string{"arg", "--name=x"}, 0, "", false},
+		{[]string{"--", "--name=x"}, 1, "", false},
+		{[]string{"--name=x", "arg"}, 1, "name", false},
+		{[]string{"--name", "y", "arg"}, 2, "name", false},
+		{[]string{"-vn", "z"}, 2, "name", false},
+		{[]string{"-v"}, 1, "verbose", false},
+		{[]string{"--missing"}, 1, "", true},
+	}
+	for _, tt := range tests {
+		consumed, flag, err := f.ParseOne(tt.args)
+		if (err != nil) != tt.err {
+			t.Errorf("%v: unexpected error %v", tt.args, err)
+			continue
+		}
+		flagName := ""
+		if flag != nil {
+			flagName = flag.Name
+		}
+		if !tt.err && (consumed != tt.consumed || flagName != tt.flag) {
+			t.Errorf("%v: got %d %q want %d %q", tt.args, consumed, flagName, tt.consumed, tt.flag)
+		}
+	}
+	if *name != "z" {
+		t.Errorf("got name %q want z", *name)
+	}
+}
+
+func TestParseAccumulates(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	level := f.String("level", "info", "")
+	tags := f.StringSlice("tags", nil, "")
+	f.Int("port", 80, "")
+	if err := f.Parse([]string{"--level=debug", "--tags=a", "base"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Parse([]string{"--tags=b", "--port=8080", "profile"}); err != nil {
+		t.Fatal(err)
+	}
+	if *level != "debug" || !reflect.DeepEqual(*tags, []string{"a", "b"}) {
+		t.Errorf("unexpected values %q %v", *level, *tags)
+	}
+	if !f.Changed("level") || !f.Changed("port") || f.NFlag() != 3 {
+		t.Errorf("Changed should report the flags of both calls")
+	}
+	if want := []string{"base", "profile"}; !reflect.DeepEqual(f.Args(), want) {
+		t.Errorf("got args %v want %v", f.Args(), want)
+	}
+	var positions []int
+	f.VisitInOrder(func(flag *Flag, position int) { positions = append(positions, position) })
+	if want := []int{0, 1, 3, 4}; !reflect.DeepEqual(positions, want) {
+		t.Errorf("got positions %v want %v", positions, want)
+	}
+}
+
+func TestLazyDefault(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	host := f.String("host", "", "host name")
+	dirs := f.StringSlice("dirs", nil, "")
+	name := f.String("name", "", "")
+	calls := 0
+	f.SetLazyDefault("host", func() string { calls++; return "example.org" })
+	f.SetLazyDefault("dirs", func() string { return "/a,/b" })
+	f.SetLazyDefault("name", func() string { return "computed" })
+
+	if err := f.Parse([]string{"--name=x"}); err != nil {
+		t.Fatal(err)
+	}
+	if *host != "example.org" || calls != 1 {
+		t.Errorf("got host %q computed %d times", *host, calls)
+	}
+	if *name != "x" || f.Lookup("name").lazyDefault == nil {
+		t.Errorf("default of a set flag computed, got %q", *name)
+	}
+	if !reflect.DeepEqual(*dirs, []string{"/a", "/b"}) || f.Changed("dirs") {
+		t.Errorf("got dirs %v changed %v", *dirs, f.Changed("dirs"))
+	}
+	if err := f.Parse([]string{"--dirs=/c"}); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*dirs, []string{"/c"}) {
+		t.Errorf("got dirs %v want [/c]", *dirs)
+	}
+
+	usage := f.FlagUsages()
+	if !strings.Contains(usage, `host name (default "example.org")`) || !strings.Contains(usage, `(default "computed")`) {
+		t.Errorf("lazy defaults not shown in usage:\n%s", usage)
+	}
+	if *name != "x" || calls != 1 {
+		t.Errorf("got name %q, host default computed %d times", *name, calls)
+	}
+}
+
+// colorValue is a flag value which may be given without an argument.
+type colorValue string
+
+func (c *colorValue) String() string     { return string(*c) }
+func (c *colorValue) Set(s string) error { *c = colorValue(s); return nil }
+func (c *colorValue) Type() string       { return "when" }
+func (c *colorValue) DefaultArg() string { return "always" }
+
+func TestDefaultArgValue(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	color := colorValue("never")
+	f.Var(&color, "color", "colorize `when`")
+	level := f.IntP("level", "l", 1, "level")
+	if err := f.SetNoOptDefVal("level", "5"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.SetNoOptDefVal("missing", "1"); err == nil {
+		t.Error("expected an error for a missing flag")
+	}
+
+	if err := f.Parse([]string{"--color", "-l", "arg"}); err != nil {
+		t.Fatal(err)
+	}
+	if color != "always" || *level != 5 || f.Arg(0) != "arg" {
+		t.Errorf("got %q %d %v", color, *level, f.Args())
+	}
+	if err := f.Parse([]string{"--color=auto", "-l=3"}); err != nil {
+		t.Fatal(err)
+	}
+	if color != "auto" || *level != 3 {
+		t.Errorf("got %q %d", color, *level)
+	}
+	usage := f.FlagUsages()
+	if !strings.Contains(usage, "--color[=always]") || !strings.Contains(usage, "--level[=5]") {
+		t.Errorf("optional arguments not documented:\n%s", usage)
+	}
+}
+
+func TestAttachedShortValues(t *testing.T) {
+	tests := []struct {
+		args     []string
+		num      int
+		tristate string
+		verbose  bool
+		rest     []string
+	}{
+		{[]string{"-n5"}, 5, "", false, []string{}},
+		{[]string{"-n", "5"}, 5, "", false, []string{}},
+		{[]string{"-t=maybe"}, 0, "maybe", false, []string{}},
+		{[]string{"-t"}, 0, "yes", false, []string{}},
+		{[]string{"-t="}, 0, "", false, []string{}},
+		{[]string{"-vn5"}, 5, "", true, []string{}},
+		{[]string{"-vn", "5", "arg"}, 5, "", true, []string{"arg"}},
+		{[]string{"-vt=maybe"}, 0, "maybe", true, []string{}},
+		{[]string{"-vt", "arg"}, 0, "yes", true, []string{"arg"}},
+	}
+	for _, tt := range tests {
+		f := NewFlagSet("test", ContinueOnError)
+		num := f.IntP("num", "n", 0, "")
+		tristate := f.StringP("tristate", "t", "", "")
+		f.Lookup("tristate").NoOptDefVal = "yes"
+		verbose := f.BoolP("verbose", "v", false, "")
+		if err := f.Parse(tt.args); err != nil {
+			t.Errorf("%v: %v", tt.args, err)
+			continue
+		}
+		if *num != tt.num || *tristate != tt.tristate || *verbose != tt.verbose || !reflect.DeepEqual(f.Args(), tt.rest) {
+			t.Errorf("%v: got %d %q %v %v", tt.args, *num, *tristate, *verbose, f.Args())
+		}
+	}
+}
+
+func TestNegativeNumbers(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	offset := f.Int("offset", 0, "")
+	f.BoolP("verbose", "v", false, "")
+	if err := f.Parse([]string{"-5", "5"}); err == nil {
+		t.Error("expected -5 to be an unknown shorthand by default")
+	}
+
+	f.Reset()
+	f.SetNegativeNumbers(true)
+	if err := f.Parse([]string{"-5", "-v", "--offset", "-3", "-2.5", "1e3"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"-5", "-2.5", "1e3"}; !reflect.DeepEqual(f.Args(), want) || *offset != -3 {
+		t.Errorf("got args %v offset %d", f.Args(), *offset)
+	}
+
+	f.Reset()
+	f.BoolP("one", "1", false, "")
+	if err := f.Parse([]string{"-1"}); err != nil || len(f.Args()) != 0 {
+		t.Errorf("digit shorthand not parsed as a flag: %v %v", err, f.Args())
+	}
+}
+
+func TestShorthandGroups(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.BoolP("recursive", "r", false, "")
+	verbose := f.BoolP("verbose", "v", false, "")
+	file := f.StringP("file", "f", "", "")
+	tests := []struct {
+		args []string
+		file string
+		rest []string
+	}{
+		{[]string{"-rvf", "archive.tar", "x"}, "archive.tar", []string{"x"}},
+		{[]string{"-rvf=archive.tar"}, "archive.tar", nil},
+		{[]string{"-farchive.tar"}, "archive.tar", nil},
+		{[]string{"-rfv", "archive.tar"}, "v", []string{"archive.tar"}},
+	}
+	for _, test := range tests {
+		f.Reset()
+		if err := f.Parse(test.args); err != nil {
+			t.Errorf("%q: %v", test.args, err)
+			continue
+		}
+		if *file != test.file || len(f.Args()) != len(test.rest) || len(test.rest) > 0 && !reflect.DeepEqual(f.Args(), test.rest) {
+			t.Errorf("%q: got file %q args %q", test.args, *file, f.Args())
+		}
+	}
+
+	if *verbose {
+		t.Error("-v should be the value of -f in -rfv")
+	}
+
+	f.SetStrictShorthandGroups(true)
+	for _, test := range tests[:3] {
+		f.Reset()
+		if err := f.Parse(test.args); err != nil || *file != test.file {
+			t.Errorf("%q: got file %q error %v", test.args, *file, err)
+		}
+	}
+	f.Reset()
+	err := f.Parse([]string{"-rfv", "archive.tar"})
+	if want := "flag -f takes a value and must be last in -rfv"; err == nil || err.Error() != want {
+		t.Errorf("got error %v, want %s", err, want)
+	}
+}
+
+func TestSortedCacheInvalidation(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.Bool("b", false, "")
+	f.Bool("d", false, "")
+	visit := func() []string {
+		var names []string
+		f.VisitAll(func(flag *Flag) { names = append(names, flag.Name) })
+		return names
+	}
+	visit()
+	f.Remove("d")
+	f.Bool("a", false, "")
+	if got, want := visit(), []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+
+	f.SortFlags = false
+	f.Set("b", "true")
+	f.Set("b", "false")
+	f.Set("a", "true")
+	var set []string
+	f.Visit(func(flag *Flag) { set = append(set, flag.Name) })
+	if want := []string{"b", "a"}; !reflect.DeepEqual(set, want) {
+		t.Errorf("got %v want %v", set, want)
+	}
+}
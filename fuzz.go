@@ -0,0 +1,73 @@
+package pflag
+
+import (
+	"bytes"
+	"io/ioutil"
+)
+
+// FuzzParse parses the command line encoded in data with a FlagSet defining
+// flags of the common types, for fuzzers like go-fuzz and the fuzzing
+// support of go test. It returns 1 if data parsed without error and 0
+// otherwise, so that inputs exercising the parser further are favoured.
+// Malformed input only ever results in a parse error: FuzzParse panicking
+// is a bug.
+//
+// The first byte of data selects parsing modes, one per bit, and the rest
+// holds the arguments separated by NUL bytes, e.g.
+// "\x00--name=x\x00-vvv\x00file" for no modes and the arguments --name=x,
+// -vvv and file.
+func FuzzParse(data []byte) int {
+	if len(data) == 0 {
+		return 0
+	}
+	f := newFuzzFlagSet(data[0])
+	var args []string
+	if len(data) > 1 {
+		for _, arg := range bytes.Split(data[1:], []byte{0}) {
+			args = append(args, string(arg))
+		}
+	}
+	if err := f.Parse(args); err != nil {
+		return 0
+	}
+	f.Visit(func(flag *Flag) { _ = flag.Value.String() })
+	_ = f.FlagUsages()
+	return 1
+}
+
+// newFuzzFlagSet returns the FlagSet FuzzParse parses with, in the modes
+// given by the bits of modes.
+func newFuzzFlagSet(modes byte) *FlagSet {
+	f := NewFlagSet("fuzz", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.SetErrOutput(ioutil.Discard)
+	f.Usage = func() {}
+	f.SetInterspersed(modes&1 == 0)
+	f.SetRequireEquals(modes&2 != 0)
+	f.SetNegativeNumbers(modes&4 != 0)
+	f.SetStrictShorthandGroups(modes&8 != 0)
+	f.SetAbbreviations(modes&16 != 0)
+	f.SetSuggestions(modes&32 != 0)
+	f.SetMultiCharShorthands(modes&64 != 0)
+
+	f.BoolP("verbose", "v", false, "")
+	f.CountP("debug", "d", "")
+	f.StringP("name", "n", "", "")
+	f.IntP("count", "c", 0, "")
+	f.Float64("ratio", 0, "")
+	f.Duration("timeout", 0, "")
+	f.IP("addr", nil, "")
+	f.StringSliceP("include", "i", nil, "")
+	f.IntSlice("ports", nil, "")
+	f.StringToString("labels", nil, "")
+	f.String("color", "auto", "")
+	f.Lookup("color").NoOptDefVal = "always"
+	if modes&64 != 0 {
+		f.StringP("output", "out", "", "")
+	}
+	if modes&128 != 0 {
+		f.SetAlias("-V", []string{"--verbose", "--debug"})
+		f.SetAlias("--all", []string{"--include=*", "--name"})
+	}
+	return f
+}
@@ -0,0 +1,29 @@
+//go:build go1.18
+// +build go1.18
+
+package pflag
+
+import "testing"
+
+// FuzzParseArgs runs FuzzParse with the fuzzing support of go test, which
+// needs Go 1.18.
+func FuzzParseArgs(f *testing.F) {
+	for _, seed := range []string{
+		"\x00--name=x\x00-vvv\x00file",
+		"\x00-\x00--\x00-\x00--=",
+		"\x00--color\x00--color=never\x00-c=3",
+		"\x01-n\x00\xff\xfe\x00--na\xffme=1",
+		"\x02--name\x00x\x00-nx\x00-n=x",
+		"\x04-1\x00--count=-2\x00-c\x00-3",
+		"\x10--verb\x00--na=x\x00--in=a,b",
+		"\x40-out=x\x00-outx\x00-vd",
+		"\x80-V\x00--all\x00x\x00--all=y",
+		"\x00--labels=a=b,c\x00--ports=1,,2\x00--addr=::1\x00--timeout=1h",
+		"\x00-=\x00-v=\x00--=x\x00---x\x00-\xc3",
+	} {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		FuzzParse(data)
+	})
+}
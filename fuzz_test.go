@@ -0,0 +1,40 @@
+package pflag
+
+import "testing"
+
+func TestParseMalformed(t *testing.T) {
+	tests := []struct {
+		modes byte
+		args  []string
+		ok    bool
+	}{
+		{0, []string{"-"}, true},
+		{0, []string{"--"}, true},
+		{0, []string{"---"}, false},
+		{0, []string{"--="}, false},
+		{0, []string{"--=x"}, false},
+		{0, []string{"-="}, false},
+		{0, []string{"-v="}, false},
+		{0, []string{"--name=="}, true},
+		{0, []string{"-n="}, true},
+		{0, []string{"--na\xffme=x"}, false},
+		{0, []string{"-\xc3"}, false},
+		{0, []string{"--name", "\xff\xfe"}, true},
+		{0, []string{"--labels==="}, true},
+		{2, []string{"--name"}, false},
+		{64, []string{"-\xc3\xa9"}, false},
+		{128, []string{"--all="}, true},
+	}
+	for _, test := range tests {
+		data := []byte{test.modes}
+		for i, arg := range test.args {
+			if i > 0 {
+				data = append(data, 0)
+			}
+			data = append(data, arg...)
+		}
+		if got := FuzzParse(data); (got == 1) != test.ok {
+			t.Errorf("%q in modes %#x: got %d", test.args, test.modes, got)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package pflag
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// GlobPattern matches file names against a pattern, as given to flags
+// defined with Glob.
+type GlobPattern struct {
+	Pattern string
+	// Doublestar makes a "**" path element match any number of elements,
+	// including none, see SetGlobDoublestar.
+	Doublestar bool
+}
+
+// Match returns true if name matches the pattern. Without Doublestar, it
+// matches as filepath.Match does. With Doublestar, names and patterns are
+// split into elements at slashes, after converting the separators of name
+// with filepath.ToSlash, and the elements other than "**" match as
+// path.Match does.
+func (g GlobPattern) Match(name string) bool {
+	if !g.Doublestar {
+		ok, _ := filepath.Match(g.Pattern, name)
+		return ok
+	}
+	return matchElements(strings.Split(g.Pattern, "/"), strings.Split(filepath.ToSlash(name), "/"))
+}
+
+// matchElements returns true if the elements of a name match the ones of a
+// pattern of which "**" matches any number of elements.
+func matchElements(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchElements(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// String returns the pattern.
+func (g GlobPattern) String() string { return g.Pattern }
+
+// validate returns an error if the pattern is malformed.
+func (g GlobPattern) validate() error {
+	var err error
+	if !g.Doublestar {
+		_, err = filepath.Match(g.Pattern, "")
+	} else {
+		for _, elem := range strings.Split(g.Pattern, "/") {
+			if _, err = path.Match(elem, ""); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("invalid glob pattern %q: %v", g.Pattern, err)
+	}
+	return nil
+}
+
+// -- GlobPattern Value
+type globValue GlobPattern
+
+func newGlobValue(val string, p *GlobPattern) *globValue {
+	*p = GlobPattern{Pattern: val}
+	return (*globValue)(p)
+}
+
+func (g *globValue) Set(val string) error {
+	v := GlobPattern{Pattern: val, Doublestar: g.Doublestar}
+	if err := v.validate(); err != nil {
+		return err
+	}
+	*g = globValue(v)
+	return nil
+}
+
+func (g *globValue) Type() string {
+	return "glob"
+}
+
+func (g *globValue) String() string { return g.Pattern }
+
+// SetGlobDoublestar sets whether the pattern of the named glob flag can use
+// "**" to match any number of path elements, see GlobPattern.
+func (f *FlagSet) SetGlobDoublestar(name string, doublestar bool) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	g, ok := flag.Value.(*globValue)
+	if !ok {
+		return fmt.Errorf("flag %q is not a glob", name)
+	}
+	g.Doublestar = doublestar
+	return nil
+}
+
+// GetGlob return the GlobPattern value of a flag with the given name
+func (f *FlagSet) GetGlob(name string) (GlobPattern, error) {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return GlobPattern{}, fmt.Errorf("flag accessed but not defined: %s", name)
+	}
+	g, ok := flag.Value.(*globValue)
+	if !ok {
+		return GlobPattern{}, fmt.Errorf("trying to get glob value of flag of type %s", flag.Value.Type())
+	}
+	return GlobPattern(*g), nil
+}
+
+// GlobVar defines a glob pattern flag with specified name, default value, and usage string.
+// The pattern is checked when the flag is set, so that malformed ones are reported as invalid
+// arguments, and can be matched with the Match method of the GlobPattern.
+// The argument p points to a GlobPattern variable in which to store the value of the flag.
+func (f *FlagSet) GlobVar(p *GlobPattern, name string, value string, usage string) {
+	f.VarP(newGlobValue(value, p), name, "", usage)
+}
+
+// GlobVarP is like GlobVar, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) GlobVarP(p *GlobPattern, name, shorthand string, value string, usage string) {
+	f.VarP(newGlobValue(value, p), name, shorthand, usage)
+}
+
+// GlobVar defines a glob pattern flag with specified name, default value, and usage string.
+// The pattern is checked when the flag is set, so that malformed ones are reported as invalid
+// arguments, and can be matched with the Match method of the GlobPattern.
+// The argument p points to a GlobPattern variable in which to store the value of the flag.
+func GlobVar(p *GlobPattern, name string, value string, usage string) {
+	CommandLine.VarP(newGlobValue(value, p), name, "", usage)
+}
+
+// GlobVarP is like GlobVar, but accepts a shorthand letter that can be used after a single dash.
+func GlobVarP(p *GlobPattern, name, shorthand string, value string, usage string) {
+	CommandLine.VarP(newGlobValue(value, p), name, shorthand, usage)
+}
+
+// Glob defines a glob pattern flag with specified name, default value, and usage string.
+// The pattern is checked when the flag is set, so that malformed ones are reported as invalid
+// arguments, and can be matched with the Match method of the GlobPattern.
+// The return value is the address of a GlobPattern variable that stores the value of the flag.
+func (f *FlagSet) Glob(name string, value string, usage string) *GlobPattern {
+	p := new(GlobPattern)
+	f.GlobVarP(p, name, "", value, usage)
+	return p
+}
+
+// GlobP is like Glob, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) GlobP(name, shorthand string, value string, usage string) *GlobPattern {
+	p := new(GlobPattern)
+	f.GlobVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// Glob defines a glob pattern flag with specified name, default value, and usage string.
+// The pattern is checked when the flag is set, so that malformed ones are reported as invalid
+// arguments, and can be matched with the Match method of the GlobPattern.
+// The return value is the address of a GlobPattern variable that stores the value of the flag.
+func Glob(name string, value string, usage string) *GlobPattern {
+	return CommandLine.GlobP(name, "", value, usage)
+}
+
+// GlobP is like Glob, but accepts a shorthand letter that can be used after a single dash.
+func GlobP(name, shorthand string, value string, usage string) *GlobPattern {
+	return CommandLine.GlobP(name, shorthand, value, usage)
+}
@@ -0,0 +1,54 @@
+package pflag
+
+import (
+	"testing"
+)
+
+func TestGlob(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	include := f.GlobP("include", "i", "*.go", "files to `include`")
+	exclude := f.Glob("exclude", "", "files to exclude")
+	if err := f.SetGlobDoublestar("exclude", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Parse([]string{"-i", "*_test.go", "--exclude", "vendor/**/*.go"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		g     *GlobPattern
+		name  string
+		match bool
+	}{
+		{include, "flag_test.go", true},
+		{include, "flag.go", false},
+		{include, "dir/flag_test.go", false},
+		{exclude, "vendor/a.go", true},
+		{exclude, "vendor/a/b/c.go", true},
+		{exclude, "src/vendor/a.go", false},
+		{exclude, "vendor/a/b.txt", false},
+	}
+	for _, test := range tests {
+		if got := test.g.Match(test.name); got != test.match {
+			t.Errorf("%s matching %s: got %v, want %v", test.g, test.name, got, test.match)
+		}
+	}
+	if g, err := f.GetGlob("exclude"); err != nil || g.Pattern != "vendor/**/*.go" || !g.Doublestar {
+		t.Errorf("GetGlob returned %+v, %v", g, err)
+	}
+
+	for _, name := range []string{"include", "exclude"} {
+		if err := f.Set(name, "[a-"); err == nil {
+			t.Errorf("--%s: expected an error for a malformed pattern", name)
+		}
+	}
+	if err := f.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if include.Pattern != "*.go" || !exclude.Doublestar {
+		t.Errorf("got %+v and %+v after Reset", *include, *exclude)
+	}
+	if usage, want := f.FlagUsages(), "      --exclude glob      files to exclude\n  -i, --include include   files to include (default *.go)\n"; usage != want {
+		t.Errorf("got usage %q, want %q", usage, want)
+	}
+}
@@ -26,6 +26,17 @@ type goBoolFlag interface {
 	IsBoolFlag() bool
 }
 
+// goBoolFlagWrapper is a flagValueWrapper around a boolean flag.Value. It
+// keeps the IsBoolFlag method, so that the flag is handled as a boolean
+// flag in usage messages too.
+type goBoolFlagWrapper struct {
+	*flagValueWrapper
+}
+
+func (v goBoolFlagWrapper) IsBoolFlag() bool {
+	return true
+}
+
 func wrapFlagValue(v goflag.Value) Value {
 	// If the flag.Value happens to also be a pflag.Value, just use it directly.
 	if pv, ok := v.(Value); ok {
@@ -36,6 +47,11 @@ func wrapFlagValue(v goflag.Value) Value {
 		inner: v,
 	}
 
+	if fv, ok := v.(goBoolFlag); ok && fv.IsBoolFlag() {
+		pv.flagType = "bool"
+		return goBoolFlagWrapper{pv}
+	}
+
 	t := reflect.TypeOf(v)
 	if t.Kind() == reflect.Interface || t.Kind() == reflect.Ptr {
 		t = t.Elem()
@@ -99,3 +115,32 @@ func (f *FlagSet) AddGoFlagSet(newSet *goflag.FlagSet) {
 		f.AddGoFlag(goflag)
 	})
 }
+
+// goValue returns the flag.Value to use for the pflag.Value v, unwrapping
+// values which came from the flag package in the first place.
+func goValue(v Value) goflag.Value {
+	switch v := v.(type) {
+	case *flagValueWrapper:
+		return v.inner
+	case goBoolFlagWrapper:
+		return v.inner
+	}
+	return v
+}
+
+// CopyToGoFlagSet defines all flags of the pflag.FlagSet in the given
+// *flag.FlagSet, bound to the same values, so that setting them through
+// either FlagSet sets both. Shorthands are defined as additional flags
+// sharing the value of their flag. Names which are already defined in
+// newSet are skipped.
+func (f *FlagSet) CopyToGoFlagSet(newSet *goflag.FlagSet) {
+	f.VisitAll(func(flag *Flag) {
+		value := goValue(flag.Value)
+		if newSet.Lookup(flag.Name) == nil {
+			newSet.Var(value, flag.Name, flag.Usage)
+		}
+		if flag.Shorthand != "" && newSet.Lookup(flag.Shorthand) == nil {
+			newSet.Var(value, flag.Shorthand, flag.Usage)
+		}
+	})
+}
@@ -6,6 +6,7 @@ package pflag
 
 import (
 	goflag "flag"
+	"fmt"
 	"testing"
 )
 
@@ -37,3 +38,49 @@ func TestGoflags(t *testing.T) {
 		t.Fatalf("expected getBool=true but got getBool=%v", getBool)
 	}
 }
+
+type goVerbosity bool
+
+func (v *goVerbosity) String() string     { return fmt.Sprint(bool(*v)) }
+func (v *goVerbosity) Set(s string) error { *v = s == "true"; return nil }
+func (v *goVerbosity) IsBoolFlag() bool   { return true }
+
+func TestGoBoolFlag(t *testing.T) {
+	gs := goflag.NewFlagSet("go", goflag.ContinueOnError)
+	var v goVerbosity
+	gs.Var(&v, "verbosity", "be verbose")
+
+	f := NewFlagSet("test", ContinueOnError)
+	f.AddGoFlagSet(gs)
+	if got, want := f.FlagUsages(), "      --verbosity   be verbose\n"; got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+	if err := f.Parse([]string{"--verbosity"}); err != nil {
+		t.Fatal(err)
+	}
+	if !v {
+		t.Error("expected verbosity to be set")
+	}
+}
+
+func TestCopyToGoFlagSet(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	n := f.IntP("num", "n", 1, "a number")
+	b := f.Bool("bool", false, "a bool")
+	gs := goflag.NewFlagSet("go", goflag.ContinueOnError)
+	var v goVerbosity
+	gs.Var(&v, "verbosity", "be verbose")
+	f.AddGoFlagSet(gs)
+
+	out := goflag.NewFlagSet("out", goflag.ContinueOnError)
+	f.CopyToGoFlagSet(out)
+	if err := out.Parse([]string{"-num", "2", "-bool", "-verbosity", "-n=3"}); err != nil {
+		t.Fatal(err)
+	}
+	if *n != 3 || !*b || !bool(v) {
+		t.Errorf("unexpected values num=%d bool=%v verbosity=%v", *n, *b, v)
+	}
+	if out.Lookup("num").DefValue != "1" {
+		t.Errorf("unexpected default %q", out.Lookup("num").DefValue)
+	}
+}
@@ -0,0 +1,43 @@
+package pflag
+
+import "fmt"
+
+// SetGreedy makes the named slice or array flag consume the arguments
+// following its value, up to the next flag, as in "--include a b c", like
+// nargs='+' in Python's argparse. Every argument consumed is handled as if
+// the flag were given again with it, so "--include a b" is the same as
+// "--include a --include b", except that it is a single occurrence of the
+// flag, see SetMaxOccurrences. Values attached with '=', as in --include=a,
+// are not followed by others. Use "--" or another flag to end the list
+// before positional arguments.
+func (f *FlagSet) SetGreedy(name string) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	if !accumulates(flag) || flag.NoOptDefVal != "" {
+		return fmt.Errorf("flag %q does not take several values", name)
+	}
+	flag.greedy = true
+	return nil
+}
+
+// consumeGreedy sets flag to the arguments at the start of args which aren't
+// flags, and returns the remaining ones. The values are part of the
+// occurrence of the flag they follow, so they don't count as occurrences of
+// their own for SetMaxOccurrences and the repeat policies.
+func (f *FlagSet) consumeGreedy(flag *Flag, args []string, fn parseFunc) ([]string, error) {
+	f.inGreedy = true
+	defer func() { f.inGreedy = false }()
+	for len(args) > 0 {
+		s := args[0]
+		if len(s) > 1 && s[0] == '-' && !f.isNegativeNumber(s) {
+			break
+		}
+		if err := fn(flag, s, SourceCommandLine); err != nil {
+			return args, err
+		}
+		args = args[1:]
+	}
+	return args, nil
+}
@@ -0,0 +1,67 @@
+package pflag
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGreedy(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	include := f.StringSliceP("include", "i", nil, "")
+	f.BoolP("verbose", "v", false, "")
+	f.Int("n", 0, "")
+	if err := f.SetGreedy("include"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.SetGreedy("verbose"); err == nil {
+		t.Error("expected an error for a bool flag")
+	}
+	if err := f.SetGreedy("n"); err == nil {
+		t.Error("expected an error for an int flag")
+	}
+
+	tests := []struct {
+		args    []string
+		include []string
+		rest    []string
+	}{
+		{[]string{"--include", "a", "b,c", "-v", "x"}, []string{"a", "b", "c"}, []string{"x"}},
+		{[]string{"-i", "a", "b", "--", "x"}, []string{"a", "b"}, []string{"x"}},
+		{[]string{"-vi", "a", "b"}, []string{"a", "b"}, nil},
+		{[]string{"--include=a", "b"}, []string{"a"}, []string{"b"}},
+		{[]string{"-i", "a", "-i", "b", "-", "c"}, []string{"a", "b", "-", "c"}, nil},
+	}
+	for _, test := range tests {
+		f.Reset()
+		if err := f.Parse(test.args); err != nil {
+			t.Errorf("%q: %v", test.args, err)
+			continue
+		}
+		if !reflect.DeepEqual(*include, test.include) || len(f.Args()) != len(test.rest) || len(test.rest) > 0 && !reflect.DeepEqual(f.Args(), test.rest) {
+			t.Errorf("%q: got include %q args %q", test.args, *include, f.Args())
+		}
+	}
+}
+
+func TestGreedyOccurrences(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	include := f.StringSlice("include", nil, "")
+	f.SetGreedy("include")
+	f.SetMaxOccurrences("include", 1)
+	f.SetFlagRepeatPolicy("include", RepeatError)
+
+	if err := f.Parse([]string{"--include", "a", "b", "c"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(*include) != 3 {
+		t.Errorf("got include %q", *include)
+	}
+	n := 0
+	f.VisitInOrder(func(*Flag, int) { n++ })
+	if n != 1 {
+		t.Errorf("got %d occurrences, want 1", n)
+	}
+	if err := f.Parse([]string{"--include", "d"}); err == nil {
+		t.Error("expected an error for a second occurrence")
+	}
+}
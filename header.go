@@ -0,0 +1,182 @@
+package pflag
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// isHeaderToken returns true if s is a valid header field name, a token as
+// defined by RFC 7230.
+func isHeaderToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0 {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// parseHeader parses a "Name: value" header field, returning its name in
+// canonical form and its value without surrounding whitespace.
+func parseHeader(val string) (string, string, error) {
+	i := strings.IndexByte(val, ':')
+	if i < 0 {
+		return "", "", fmt.Errorf("header %q must be formatted as \"Name: value\"", val)
+	}
+	name, value := strings.TrimSpace(val[:i]), strings.TrimSpace(val[i+1:])
+	if !isHeaderToken(name) {
+		return "", "", fmt.Errorf("invalid header name %q", name)
+	}
+	for i := 0; i < len(value); i++ {
+		if c := value[i]; c < ' ' && c != '\t' || c == 0x7f {
+			return "", "", fmt.Errorf("invalid character %q in the value of header %s", c, name)
+		}
+	}
+	return http.CanonicalHeaderKey(name), value, nil
+}
+
+// -- http.Header Value
+type headerValue struct {
+	value   *http.Header
+	changed bool
+}
+
+func newHeaderValue(val http.Header, p *http.Header) *headerValue {
+	hv := new(headerValue)
+	hv.value = p
+	*hv.value = val
+	return hv
+}
+
+// Format: Name: value
+func (s *headerValue) Set(val string) error {
+	name, value, err := parseHeader(val)
+	if err != nil {
+		return err
+	}
+	if !s.changed || *s.value == nil {
+		// The default value is replaced, not modified in place.
+		*s.value = http.Header{}
+	}
+	s.value.Add(name, value)
+	s.changed = true
+	return nil
+}
+
+func (s *headerValue) Type() string {
+	return "header"
+}
+
+// fields returns the "Name: value" fields of the header, sorted by name.
+func (s *headerValue) fields() []string {
+	names := make([]string, 0, len(*s.value))
+	for name := range *s.value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var fields []string
+	for _, name := range names {
+		for _, value := range (*s.value)[name] {
+			fields = append(fields, name+": "+value)
+		}
+	}
+	return fields
+}
+
+func (s *headerValue) String() string {
+	str, _ := writeAsCSV(s.fields())
+	return "[" + str + "]"
+}
+
+func headerConv(val string) (interface{}, error) {
+	val = strings.Trim(val, "[]")
+	h := http.Header{}
+	// An empty string would cause an empty header
+	if len(val) == 0 {
+		return h, nil
+	}
+	fields, err := readAsCSV(val)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range fields {
+		name, value, err := parseHeader(field)
+		if err != nil {
+			return nil, err
+		}
+		h.Add(name, value)
+	}
+	return h, nil
+}
+
+// GetHeader return the http.Header value of a flag with the given name
+func (f *FlagSet) GetHeader(name string) (http.Header, error) {
+	val, err := f.getFlagType(name, "header", headerConv)
+	if err != nil {
+		return http.Header{}, err
+	}
+	return val.(http.Header), nil
+}
+
+// HeaderVar defines an http.Header flag with specified name, default value, and usage string.
+// Each occurrence of the flag adds a header field given as "Name: value", whose name is
+// canonicalized as by http.CanonicalHeaderKey; the first one replaces the default value.
+// The argument p points to an http.Header variable in which to store the value of the flag.
+func (f *FlagSet) HeaderVar(p *http.Header, name string, value http.Header, usage string) {
+	f.VarP(newHeaderValue(value, p), name, "", usage)
+}
+
+// HeaderVarP is like HeaderVar, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) HeaderVarP(p *http.Header, name, shorthand string, value http.Header, usage string) {
+	f.VarP(newHeaderValue(value, p), name, shorthand, usage)
+}
+
+// HeaderVar defines an http.Header flag with specified name, default value, and usage string.
+// Each occurrence of the flag adds a header field given as "Name: value", whose name is
+// canonicalized as by http.CanonicalHeaderKey; the first one replaces the default value.
+// The argument p points to an http.Header variable in which to store the value of the flag.
+func HeaderVar(p *http.Header, name string, value http.Header, usage string) {
+	CommandLine.VarP(newHeaderValue(value, p), name, "", usage)
+}
+
+// HeaderVarP is like HeaderVar, but accepts a shorthand letter that can be used after a single dash.
+func HeaderVarP(p *http.Header, name, shorthand string, value http.Header, usage string) {
+	CommandLine.VarP(newHeaderValue(value, p), name, shorthand, usage)
+}
+
+// Header defines an http.Header flag with specified name, default value, and usage string.
+// Each occurrence of the flag adds a header field given as "Name: value", whose name is
+// canonicalized as by http.CanonicalHeaderKey; the first one replaces the default value.
+// The return value is the address of an http.Header variable that stores the value of the flag.
+func (f *FlagSet) Header(name string, value http.Header, usage string) *http.Header {
+	p := http.Header{}
+	f.HeaderVarP(&p, name, "", value, usage)
+	return &p
+}
+
+// HeaderP is like Header, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) HeaderP(name, shorthand string, value http.Header, usage string) *http.Header {
+	p := http.Header{}
+	f.HeaderVarP(&p, name, shorthand, value, usage)
+	return &p
+}
+
+// Header defines an http.Header flag with specified name, default value, and usage string.
+// Each occurrence of the flag adds a header field given as "Name: value", whose name is
+// canonicalized as by http.CanonicalHeaderKey; the first one replaces the default value.
+// The return value is the address of an http.Header variable that stores the value of the flag.
+func Header(name string, value http.Header, usage string) *http.Header {
+	return CommandLine.HeaderP(name, "", value, usage)
+}
+
+// HeaderP is like Header, but accepts a shorthand letter that can be used after a single dash.
+func HeaderP(name, shorthand string, value http.Header, usage string) *http.Header {
+	return CommandLine.HeaderP(name, shorthand, value, usage)
+}
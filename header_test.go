@@ -0,0 +1,50 @@
+package pflag
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestHeader(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	def := http.Header{"User-Agent": {"pflag"}}
+	h := f.HeaderP("header", "H", def, "extra header")
+	args := []string{"-H", "accept: text/html, application/json", "--header", "X-Trace-Id:abc", "-H", "Accept:  */* "}
+	if err := f.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	want := http.Header{
+		"Accept":     {"text/html, application/json", "*/*"},
+		"X-Trace-Id": {"abc"},
+	}
+	if !reflect.DeepEqual(*h, want) {
+		t.Errorf("got %v, want %v", *h, want)
+	}
+	if !reflect.DeepEqual(def, http.Header{"User-Agent": {"pflag"}}) {
+		t.Errorf("the default was modified to %v", def)
+	}
+	if got, err := f.GetHeader("header"); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("GetHeader returned %v, %v", got, err)
+	}
+	wantArgs := []string{"--header=Accept: text/html, application/json", "--header=Accept: */*", "--header=X-Trace-Id: abc"}
+	if got := f.ToArgs(true); !reflect.DeepEqual(got, wantArgs) {
+		t.Errorf("got args %q, want %q", got, wantArgs)
+	}
+
+	for _, in := range []string{"Accept", ": value", "Bad Name: x", "X-A: a\r\nX-B: b"} {
+		if err := f.Set("header", in); err == nil {
+			t.Errorf("%q: expected an error", in)
+		}
+	}
+
+	if err := f.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*h, def) {
+		t.Errorf("got %v after Reset, want %v", *h, def)
+	}
+	if usage, want := f.FlagUsages(), "  -H, --header header   extra header (default [User-Agent: pflag])\n"; usage != want {
+		t.Errorf("got usage %q, want %q", usage, want)
+	}
+}
@@ -0,0 +1,56 @@
+package pflag
+
+import "fmt"
+
+// SetHelpFlag renames the implicit help flag, --help and -h by default,
+// which prints the usage and makes Parse return ErrHelp when given but not
+// defined. An empty name disables it, e.g. for programs using -h for a
+// host, and an empty shorthand disables only the shorthand.
+func (f *FlagSet) SetHelpFlag(name, shorthand string) error {
+	if len(shorthand) > 1 {
+		return fmt.Errorf("help shorthand %q is more than one ASCII character", shorthand)
+	}
+	if name == "" {
+		shorthand = ""
+	}
+	f.helpConfigured = true
+	f.helpName, f.helpShorthand = name, shorthand
+	return nil
+}
+
+// SetHelpHandler sets a function called when the implicit help flag is
+// given, see SetHelpFlag, instead of printing the usage. Parse returns the
+// error it returns, and if it is nil, prints the usage and returns ErrHelp;
+// returning ErrHelp replaces the usage by the output of fn.
+func (f *FlagSet) SetHelpHandler(fn func(f *FlagSet) error) {
+	f.helpHandler = fn
+}
+
+// isHelp returns true if name is the one of the implicit help flag.
+func (f *FlagSet) isHelp(name string) bool {
+	if !f.helpConfigured {
+		return name == "help"
+	}
+	return name != "" && name == f.helpName
+}
+
+// isHelpShorthand returns true if c is the shorthand of the implicit help
+// flag.
+func (f *FlagSet) isHelpShorthand(c string) bool {
+	if !f.helpConfigured {
+		return c == "h"
+	}
+	return f.helpShorthand != "" && c == f.helpShorthand
+}
+
+// help handles the implicit help flag, calling the help handler or printing
+// the usage.
+func (f *FlagSet) help() error {
+	if f.helpHandler != nil {
+		if err := f.helpHandler(f); err != nil {
+			return err
+		}
+	}
+	f.usage()
+	return ErrHelp
+}
@@ -0,0 +1,67 @@
+package pflag
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSetHelpFlag(t *testing.T) {
+	var out bytes.Buffer
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(&out)
+	f.Bool("verbose", false, "verbose output")
+	if err := f.SetHelpFlag("aide", "?"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Parse([]string{"--aide"}); err != ErrHelp || !strings.Contains(out.String(), "--verbose") {
+		t.Errorf("got %v and output %q", err, out.String())
+	}
+	if err := f.Parse([]string{"-?"}); err != ErrHelp {
+		t.Errorf("expected ErrHelp for -?, got %v", err)
+	}
+	for _, arg := range []string{"--help", "-h"} {
+		if err := f.Parse([]string{arg}); err == nil || err == ErrHelp {
+			t.Errorf("expected %s to be unknown, got %v", arg, err)
+		}
+	}
+
+	if err := f.SetHelpFlag("", "h"); err != nil {
+		t.Fatal(err)
+	}
+	for _, arg := range []string{"--aide", "-h"} {
+		if err := f.Parse([]string{arg}); err == nil || err == ErrHelp {
+			t.Errorf("expected %s to be unknown, got %v", arg, err)
+		}
+	}
+	if err := f.SetHelpFlag("help", "hh"); err == nil {
+		t.Error("expected an error for a long shorthand")
+	}
+}
+
+func TestSetHelpHandler(t *testing.T) {
+	var out bytes.Buffer
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(&out)
+	f.Bool("verbose", false, "verbose output")
+	called := 0
+	var result error
+	f.SetHelpHandler(func(fs *FlagSet) error {
+		called++
+		return result
+	})
+
+	if err := f.Parse([]string{"-h"}); err != ErrHelp || called != 1 || out.Len() == 0 {
+		t.Errorf("got %v, %d calls and output %q", err, called, out.String())
+	}
+	out.Reset()
+	result = ErrHelp
+	if err := f.Parse([]string{"--help"}); err != ErrHelp || called != 2 || out.Len() != 0 {
+		t.Errorf("got %v, %d calls and output %q", err, called, out.String())
+	}
+	result = errors.New("no help")
+	if err := f.Parse([]string{"--help"}); err != result {
+		t.Errorf("got %v", err)
+	}
+}
@@ -0,0 +1,36 @@
+package pflag
+
+// PreParse adds fn to the functions which rewrite the arguments before Parse
+// and ParseAll parse them, e.g. to expand aliases or read argument files.
+// The functions are called in the order they were added, each one with the
+// arguments returned by the previous one.
+func (f *FlagSet) PreParse(fn func(args []string) []string) {
+	f.preParse = append(f.preParse, fn)
+}
+
+// PostParse adds fn to the functions called once Parse or ParseAll parsed the
+// arguments successfully, e.g. to validate flags which depend on each other.
+// The functions are called in the order they were added, until one returns an
+// error, which is then handled like a parse error according to the
+// ErrorHandling of the FlagSet.
+func (f *FlagSet) PostParse(fn func(f *FlagSet) error) {
+	f.postParse = append(f.postParse, fn)
+}
+
+// runPreParse returns arguments rewritten by the PreParse functions.
+func (f *FlagSet) runPreParse(arguments []string) []string {
+	for _, fn := range f.preParse {
+		arguments = fn(arguments)
+	}
+	return arguments
+}
+
+// runPostParse calls the PostParse functions.
+func (f *FlagSet) runPostParse() error {
+	for _, fn := range f.postParse {
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
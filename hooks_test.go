@@ -0,0 +1,44 @@
+package pflag
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseHooks(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	verbose := f.Bool("verbose", false, "")
+	quiet := f.Bool("quiet", false, "")
+	f.PreParse(func(args []string) []string {
+		for i, arg := range args {
+			if arg == "--loud" {
+				args[i] = "--verbose"
+			}
+		}
+		return args
+	})
+	f.PreParse(func(args []string) []string {
+		return append(args, "extra")
+	})
+	conflict := errors.New("--verbose and --quiet are exclusive")
+	f.PostParse(func(f *FlagSet) error {
+		if *verbose && *quiet {
+			return conflict
+		}
+		return nil
+	})
+
+	if err := f.Parse([]string{"--loud", "arg"}); err != nil {
+		t.Fatal(err)
+	}
+	if !*verbose {
+		t.Error("alias not expanded")
+	}
+	if want := []string{"arg", "extra"}; !reflect.DeepEqual(f.Args(), want) {
+		t.Errorf("got args %v want %v", f.Args(), want)
+	}
+	if err := f.Parse([]string{"--quiet"}); err != conflict {
+		t.Errorf("got error %v want %v", err, conflict)
+	}
+}
@@ -0,0 +1,101 @@
+package pflag
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// -- net.HardwareAddr value
+type hardwareAddrValue net.HardwareAddr
+
+func newHardwareAddrValue(val net.HardwareAddr, p *net.HardwareAddr) *hardwareAddrValue {
+	*p = val
+	return (*hardwareAddrValue)(p)
+}
+
+func (h *hardwareAddrValue) String() string { return net.HardwareAddr(*h).String() }
+func (h *hardwareAddrValue) Set(s string) error {
+	addr, err := net.ParseMAC(strings.TrimSpace(s))
+	if err != nil {
+		return fmt.Errorf("failed to parse MAC address: %q", s)
+	}
+	*h = hardwareAddrValue(addr)
+	return nil
+}
+
+func (h *hardwareAddrValue) Type() string {
+	return "mac"
+}
+
+func hardwareAddrConv(sval string) (interface{}, error) {
+	if sval == "" {
+		return net.HardwareAddr(nil), nil
+	}
+	addr, err := net.ParseMAC(sval)
+	if err != nil {
+		return nil, fmt.Errorf("invalid string being converted to MAC address: %s", sval)
+	}
+	return addr, nil
+}
+
+// GetHardwareAddr return the net.HardwareAddr value of a flag with the given name
+func (f *FlagSet) GetHardwareAddr(name string) (net.HardwareAddr, error) {
+	val, err := f.getFlagType(name, "mac", hardwareAddrConv)
+	if err != nil {
+		return nil, err
+	}
+	return val.(net.HardwareAddr), nil
+}
+
+// HardwareAddrVar defines a net.HardwareAddr flag with specified name, default value, and usage string.
+// The value is parsed with net.ParseMAC, as in "00:00:5e:00:53:01".
+// The argument p points to a net.HardwareAddr variable in which to store the value of the flag.
+func (f *FlagSet) HardwareAddrVar(p *net.HardwareAddr, name string, value net.HardwareAddr, usage string) {
+	f.VarP(newHardwareAddrValue(value, p), name, "", usage)
+}
+
+// HardwareAddrVarP is like HardwareAddrVar, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) HardwareAddrVarP(p *net.HardwareAddr, name, shorthand string, value net.HardwareAddr, usage string) {
+	f.VarP(newHardwareAddrValue(value, p), name, shorthand, usage)
+}
+
+// HardwareAddrVar defines a net.HardwareAddr flag with specified name, default value, and usage string.
+// The value is parsed with net.ParseMAC, as in "00:00:5e:00:53:01".
+// The argument p points to a net.HardwareAddr variable in which to store the value of the flag.
+func HardwareAddrVar(p *net.HardwareAddr, name string, value net.HardwareAddr, usage string) {
+	CommandLine.VarP(newHardwareAddrValue(value, p), name, "", usage)
+}
+
+// HardwareAddrVarP is like HardwareAddrVar, but accepts a shorthand letter that can be used after a single dash.
+func HardwareAddrVarP(p *net.HardwareAddr, name, shorthand string, value net.HardwareAddr, usage string) {
+	CommandLine.VarP(newHardwareAddrValue(value, p), name, shorthand, usage)
+}
+
+// HardwareAddr defines a net.HardwareAddr flag with specified name, default value, and usage string.
+// The value is parsed with net.ParseMAC, as in "00:00:5e:00:53:01".
+// The return value is the address of a net.HardwareAddr variable that stores the value of the flag.
+func (f *FlagSet) HardwareAddr(name string, value net.HardwareAddr, usage string) *net.HardwareAddr {
+	p := new(net.HardwareAddr)
+	f.HardwareAddrVarP(p, name, "", value, usage)
+	return p
+}
+
+// HardwareAddrP is like HardwareAddr, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) HardwareAddrP(name, shorthand string, value net.HardwareAddr, usage string) *net.HardwareAddr {
+	p := new(net.HardwareAddr)
+	f.HardwareAddrVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// HardwareAddr defines a net.HardwareAddr flag with specified name, default value, and usage string.
+// The value is parsed with net.ParseMAC, as in "00:00:5e:00:53:01".
+// The return value is the address of a net.HardwareAddr variable that stores the value of the flag.
+func HardwareAddr(name string, value net.HardwareAddr, usage string) *net.HardwareAddr {
+	return CommandLine.HardwareAddrP(name, "", value, usage)
+}
+
+// HardwareAddrP is like HardwareAddr, but accepts a shorthand letter that can be used after a single dash.
+func HardwareAddrP(name, shorthand string, value net.HardwareAddr, usage string) *net.HardwareAddr {
+	return CommandLine.HardwareAddrP(name, shorthand, value, usage)
+}
@@ -0,0 +1,46 @@
+package pflag
+
+import (
+	"net"
+	"testing"
+)
+
+func TestHardwareAddr(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	mac := f.HardwareAddrP("mac", "m", nil, "hardware `address`")
+	if got, want := f.FlagUsages(), "  -m, --mac address   hardware address\n"; got != want {
+		t.Errorf("got usage %q, want %q", got, want)
+	}
+	if args := f.ToArgs(false); len(args) != 0 {
+		t.Errorf("an unset address should not be in %q", args)
+	}
+
+	for _, in := range []string{"00:00:5e:00:53:01", "00-00-5E-00-53-01", "0000.5e00.5301"} {
+		if err := f.Parse([]string{"-m", in}); err != nil {
+			t.Errorf("%s: %v", in, err)
+			continue
+		}
+		if got := mac.String(); got != "00:00:5e:00:53:01" {
+			t.Errorf("%s: got %s", in, got)
+		}
+	}
+	if v, err := f.GetHardwareAddr("mac"); err != nil || v.String() != "00:00:5e:00:53:01" {
+		t.Errorf("GetHardwareAddr returned %v, %v", v, err)
+	}
+	if err := f.Set("mac", "00:00:5e:00:53"); err == nil {
+		t.Error("expected an error for a truncated address")
+	}
+
+	if err := f.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if *mac != nil || f.Changed("mac") {
+		t.Errorf("got %v after Reset, want nil", *mac)
+	}
+
+	def, _ := net.ParseMAC("02:00:00:00:00:01")
+	f.HardwareAddr("gateway", def, "")
+	if got, want := f.Lookup("gateway").DefValue, "02:00:00:00:00:01"; got != want {
+		t.Errorf("got default %q, want %q", got, want)
+	}
+}
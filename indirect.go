@@ -0,0 +1,89 @@
+package pflag
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// AllowFileValue lets the value of the named flag be read from a file at
+// parse time, by giving its path prefixed with '@' or "file://" on the
+// command line, as in --token=@/run/secrets/token. This keeps secrets and
+// large payloads out of ps output and shell history. A single trailing
+// newline is removed from the content of the file.
+func (f *FlagSet) AllowFileValue(name string) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	flag.fileValue = true
+	return nil
+}
+
+// AllowStdinValue lets the value of the named flag be read from the standard
+// input at parse time, by giving "-" as its value on the command line, as in
+// --body -. The standard input is read up to its end, so only one flag can
+// read it; parsing fails if another one requests it too. A single trailing
+// newline is removed, as for AllowFileValue.
+func (f *FlagSet) AllowStdinValue(name string) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	flag.stdinValue = true
+	return nil
+}
+
+// resolveValue returns the value of flag given on the command line, with
+// environment variables expanded and read from the file or the standard input
+// it refers to if allowed.
+func (f *FlagSet) resolveValue(flag *Flag, value string, src ValueSource) (string, error) {
+	if src != SourceCommandLine {
+		return value, nil
+	}
+	value = f.expandValue(flag, value)
+	if flag.stdinValue && value == "-" {
+		return f.readStdinValue(flag)
+	}
+	if !flag.fileValue {
+		return value, nil
+	}
+	var path string
+	switch {
+	case strings.HasPrefix(value, "@"):
+		path = value[1:]
+	case strings.HasPrefix(value, "file://"):
+		path = strings.TrimPrefix(value, "file://")
+	default:
+		return value, nil
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", f.failf(f.msg(MsgValueFile), flag.Name, err)
+	}
+	return trimNewline(content), nil
+}
+
+// readStdinValue reads the value of flag from the standard input.
+func (f *FlagSet) readStdinValue(flag *Flag) (string, error) {
+	if f.stdinFlag != nil {
+		return "", f.failf(f.msg(MsgStdinTwice), f.stdinFlag.Name, flag.Name)
+	}
+	f.stdinFlag = flag
+	in := f.stdin
+	if in == nil {
+		in = os.Stdin
+	}
+	content, err := ioutil.ReadAll(in)
+	if err != nil {
+		return "", f.failf(f.msg(MsgValueFile), flag.Name, err)
+	}
+	return trimNewline(content), nil
+}
+
+// trimNewline returns content without its trailing newline, if any.
+func trimNewline(content []byte) string {
+	s := strings.TrimSuffix(string(content), "\n")
+	return strings.TrimSuffix(s, "\r")
+}
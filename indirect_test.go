@@ -0,0 +1,61 @@
+package pflag
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileValue(t *testing.T) {
+	path := writeTempFile(t, "token", "s3cr3t\n")
+	defer os.RemoveAll(filepath.Dir(path))
+
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	token := f.String("token", "", "")
+	other := f.String("other", "", "")
+	if err := f.AllowFileValue("token"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.AllowFileValue("missing"); err == nil {
+		t.Error("expected an error for a missing flag")
+	}
+
+	for _, arg := range []string{"--token=@" + path, "--token=file://" + path} {
+		*token = ""
+		if err := f.Parse([]string{arg, "--other=@" + path}); err != nil {
+			t.Fatal(err)
+		}
+		if *token != "s3cr3t" || *other != "@"+path {
+			t.Errorf("%s: got %q %q", arg, *token, *other)
+		}
+	}
+
+	err := f.Parse([]string{"--token=@" + path + ".missing"})
+	if err == nil || !strings.HasPrefix(err.Error(), "can not read value of flag --token: ") {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestStdinValue(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	body := f.StringP("body", "b", "", "")
+	token := f.String("token", "", "")
+	f.AllowStdinValue("body")
+	f.AllowStdinValue("token")
+	f.stdin = strings.NewReader("{\"a\": 1}\n")
+	if err := f.Parse([]string{"-b", "-", "-"}); err != nil {
+		t.Fatal(err)
+	}
+	if *body != `{"a": 1}` || *token != "" || f.Arg(0) != "-" {
+		t.Errorf("got %q %q %v", *body, *token, f.Args())
+	}
+
+	want := "flags --body and --token both read their value from stdin"
+	if err := f.Parse([]string{"--token", "-"}); err == nil || err.Error() != want {
+		t.Errorf("got error %v want %s", err, want)
+	}
+}
@@ -0,0 +1,102 @@
+package pflag
+
+import "fmt"
+
+// SetParent makes parent the parent of the FlagSet, as for a subcommand
+// whose FlagSet inherits the global flags of the program. Lookup then falls
+// back to the flags of parent, and of its own parent, for the names the
+// FlagSet does not define: its own flags shadow the inherited ones. See
+// SetParseInherited to accept the inherited flags when parsing. A nil
+// parent removes the parent; making a FlagSet its own ancestor panics.
+func (f *FlagSet) SetParent(parent *FlagSet) {
+	for p := parent; p != nil; p = p.parent {
+		if p == f {
+			msg := fmt.Sprintf("%s flag set can not be its own ancestor", f.name)
+			fmt.Fprintln(f.errOut(), msg)
+			panic(msg)
+		}
+	}
+	f.parent = parent
+}
+
+// Parent returns the parent of the FlagSet set with SetParent, or nil.
+func (f *FlagSet) Parent() *FlagSet {
+	return f.parent
+}
+
+// SetParseInherited sets whether Parse accepts the flags inherited from the
+// ancestors of the FlagSet, see SetParent, in addition to its own. They are
+// set on the FlagSet which defines them, so that it reports them as changed,
+// and are listed separately in the usage message, under "Inherited flags".
+func (f *FlagSet) SetParseInherited(enabled bool) {
+	f.parseInherited = enabled
+}
+
+// parseLookup returns the flag name refers to on the command line, with its
+// inherited flags if SetParseInherited is enabled.
+func (f *FlagSet) parseLookup(name string) (*Flag, bool) {
+	normalName := f.normalizeFlagName(name)
+	if flag, ok := f.formal[normalName]; ok || !f.parseInherited || f.parent == nil {
+		return flag, ok
+	}
+	flag := f.parent.Lookup(string(normalName))
+	return flag, flag != nil
+}
+
+// flagSetOf returns the FlagSet which defines flag: f or one of its
+// ancestors.
+func (f *FlagSet) flagSetOf(flag *Flag) *FlagSet {
+	for p := f; p != nil; p = p.parent {
+		if p.formal[NormalizedName(flag.Name)] == flag {
+			return p
+		}
+	}
+	return f
+}
+
+// inheritedFlagUsages returns the presentation of the flags inherited from
+// the ancestors of f which are shown in help and not shadowed by its own
+// flags, in VisitAll order of each ancestor, the closest first. The
+// shorthands shadowed by those of f are left out.
+func (f *FlagSet) inheritedFlagUsages() []*FlagUsage {
+	var usages []*FlagUsage
+	seen := make(map[NormalizedName]bool)
+	for name := range f.formal {
+		seen[name] = true
+	}
+	for p := f.parent; p != nil; p = p.parent {
+		for _, u := range p.flagUsages() {
+			name := f.normalizeFlagName(u.Name)
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			if _, ok := f.shorthands[u.Shorthand]; ok {
+				u.Shorthand = ""
+			}
+			usages = append(usages, u)
+		}
+	}
+	return usages
+}
+
+// InheritedFlagUsages returns a string containing the usage information for
+// the flags inherited from the ancestors of the FlagSet, see SetParent,
+// formatted like FlagUsages.
+func (f *FlagSet) InheritedFlagUsages() string {
+	return f.formatFlagUsages(f.inheritedFlagUsages(), 0)
+}
+
+// printInherited prints the inherited flags accepted by Parse, as part of the
+// usage message.
+func (f *FlagSet) printInherited() {
+	if !f.parseInherited {
+		return
+	}
+	usages := f.formatFlagUsages(f.inheritedFlagUsages(), f.outputWidth())
+	if usages == "" {
+		return
+	}
+	fmt.Fprint(f.out(), f.msg(MsgInheritedFlags))
+	fmt.Fprint(f.out(), usages)
+}
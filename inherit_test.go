@@ -0,0 +1,64 @@
+package pflag
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestParent(t *testing.T) {
+	root := NewFlagSet("app", ContinueOnError)
+	verbose := root.BoolP("verbose", "v", false, "verbose output")
+	config := root.StringP("config", "c", "", "config file")
+	root.Int("port", 0, "shadowed port")
+	serve := NewFlagSet("serve", ContinueOnError)
+	serve.SetOutput(ioutil.Discard)
+	port := serve.IntP("port", "c", 80, "port to listen on")
+	serve.SetParent(root)
+
+	if serve.Parent() != root || serve.Lookup("verbose") != root.Lookup("verbose") || serve.Lookup("port") == root.Lookup("port") {
+		t.Error("lookup does not fall back to the parent")
+	}
+	if err := serve.Parse([]string{"--verbose"}); err == nil {
+		t.Error("expected an error for an inherited flag")
+	}
+
+	serve.SetParseInherited(true)
+	if err := serve.Parse([]string{"-v", "--config=app.yaml", "-c", "8080"}); err != nil {
+		t.Fatal(err)
+	}
+	if !*verbose || *config != "app.yaml" || *port != 8080 {
+		t.Errorf("got %v %q %d", *verbose, *config, *port)
+	}
+	if !root.Changed("verbose") || root.NFlag() != 2 || serve.NFlag() != 1 {
+		t.Error("inherited flags not set on the parent")
+	}
+	if err := serve.Set("verbose", "false"); err != nil || *verbose {
+		t.Errorf("setting an inherited flag: %v", err)
+	}
+	if err := serve.Set("nope", "x"); err == nil {
+		t.Error("expected an error for an undefined flag")
+	}
+
+	want := "      --config string   config file\n  -v, --verbose         verbose output\n"
+	if got := serve.InheritedFlagUsages(); got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+	usage := serve.UsageString()
+	if !strings.Contains(usage, "\nInherited flags:\n"+want) {
+		t.Errorf("inherited flags not in usage:\n%s", usage)
+	}
+	if data := serve.UsageData(); len(data.Inherited) != 2 {
+		t.Errorf("got %d inherited flags in usage data", len(data.Inherited))
+	}
+
+	var buf bytes.Buffer
+	root.SetErrOutput(&buf)
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a cycle")
+		}
+	}()
+	root.SetParent(serve)
+}
@@ -10,6 +10,7 @@ import (
 type intSliceValue struct {
 	value   *[]int
 	changed bool
+	sliceOptions
 }
 
 func newIntSliceValue(val []int, p *[]int) *intSliceValue {
@@ -20,7 +21,10 @@ func newIntSliceValue(val []int, p *[]int) *intSliceValue {
 }
 
 func (s *intSliceValue) Set(val string) error {
-	ss := strings.Split(val, ",")
+	ss, err := s.split(val, splitComma)
+	if err != nil {
+		return err
+	}
 	out := make([]int, len(ss))
 	for i, d := range ss {
 		var err error
@@ -30,10 +34,8 @@ func (s *intSliceValue) Set(val string) error {
 		}
 
 	}
-	if !s.changed {
-		*s.value = out
-	} else {
-		*s.value = append(*s.value, out...)
+	if err := s.assign(s.value, out, s.changed || s.appendDefault); err != nil {
+		return err
 	}
 	s.changed = true
 	return nil
@@ -43,6 +45,46 @@ func (s *intSliceValue) Type() string {
 	return "intSlice"
 }
 
+// parseIntSliceElement parses an element of the value of a intSlice flag.
+func parseIntSliceElement(val string) (int, error) {
+	return strconv.Atoi(val)
+}
+
+func (s *intSliceValue) Append(val string) error {
+	v, err := parseIntSliceElement(val)
+	if err != nil {
+		return err
+	}
+	if err := s.assign(s.value, []int{v}, true); err != nil {
+		return err
+	}
+	s.changed = true
+	return nil
+}
+
+func (s *intSliceValue) Replace(val []string) error {
+	out := make([]int, len(val))
+	for i, d := range val {
+		var err error
+		if out[i], err = parseIntSliceElement(d); err != nil {
+			return err
+		}
+	}
+	if err := s.assign(s.value, out, false); err != nil {
+		return err
+	}
+	s.changed = true
+	return nil
+}
+
+func (s *intSliceValue) GetSlice() []string {
+	out := make([]string, len(*s.value))
+	for i, d := range *s.value {
+		out[i] = strconv.Itoa(d)
+	}
+	return out
+}
+
 func (s *intSliceValue) String() string {
 	out := make([]string, len(*s.value))
 	for i, d := range *s.value {
@@ -11,6 +11,7 @@ import (
 type ipSliceValue struct {
 	value   *[]net.IP
 	changed bool
+	sliceOptions
 }
 
 func newIPSliceValue(val []net.IP, p *[]net.IP) *ipSliceValue {
@@ -28,7 +29,9 @@ func (s *ipSliceValue) Set(val string) error {
 	rmQuote := strings.NewReplacer(`"`, "", `'`, "", "`", "")
 
 	// read flag arguments with CSV parser
-	ipStrSlice, err := readAsCSV(rmQuote.Replace(val))
+	ipStrSlice, err := s.split(val, func(val string) ([]string, error) {
+		return readAsCSV(rmQuote.Replace(val))
+	})
 	if err != nil && err != io.EOF {
 		return err
 	}
@@ -43,10 +46,8 @@ func (s *ipSliceValue) Set(val string) error {
 		out = append(out, ip)
 	}
 
-	if !s.changed {
-		*s.value = out
-	} else {
-		*s.value = append(*s.value, out...)
+	if err := s.assign(s.value, out, s.changed || s.appendDefault); err != nil {
+		return err
 	}
 
 	s.changed = true
@@ -59,6 +60,50 @@ func (s *ipSliceValue) Type() string {
 	return "ipSlice"
 }
 
+// parseIPSliceElement parses an element of the value of a ipSlice flag.
+func parseIPSliceElement(val string) (net.IP, error) {
+	ip := net.ParseIP(strings.TrimSpace(val))
+	if ip == nil {
+		return nil, fmt.Errorf("invalid string being converted to IP address: %s", val)
+	}
+	return ip, nil
+}
+
+func (s *ipSliceValue) Append(val string) error {
+	v, err := parseIPSliceElement(val)
+	if err != nil {
+		return err
+	}
+	if err := s.assign(s.value, []net.IP{v}, true); err != nil {
+		return err
+	}
+	s.changed = true
+	return nil
+}
+
+func (s *ipSliceValue) Replace(val []string) error {
+	out := make([]net.IP, len(val))
+	for i, d := range val {
+		var err error
+		if out[i], err = parseIPSliceElement(d); err != nil {
+			return err
+		}
+	}
+	if err := s.assign(s.value, out, false); err != nil {
+		return err
+	}
+	s.changed = true
+	return nil
+}
+
+func (s *ipSliceValue) GetSlice() []string {
+	out := make([]string, len(*s.value))
+	for i, d := range *s.value {
+		out[i] = d.String()
+	}
+	return out
+}
+
 // String defines a "native" format for this net.IP slice flag value.
 func (s *ipSliceValue) String() string {
 
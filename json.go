@@ -0,0 +1,81 @@
+package pflag
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// jsonFlag is the JSON representation of a Flag.
+type jsonFlag struct {
+	Name                string              `json:"name"`
+	Shorthand           string              `json:"shorthand,omitempty"`
+	Type                string              `json:"type"`
+	Usage               string              `json:"usage"`
+	Default             string              `json:"default"`
+	Value               string              `json:"value"`
+	Changed             bool                `json:"changed"`
+	NoOptDefVal         string              `json:"noOptDefVal,omitempty"`
+	Hidden              bool                `json:"hidden,omitempty"`
+	Sensitive           bool                `json:"sensitive,omitempty"`
+	Deprecated          string              `json:"deprecated,omitempty"`
+	ShorthandDeprecated string              `json:"shorthandDeprecated,omitempty"`
+	Group               string              `json:"group,omitempty"`
+	Example             string              `json:"example,omitempty"`
+	AddedIn             string              `json:"addedIn,omitempty"`
+	DeprecatedIn        string              `json:"deprecatedIn,omitempty"`
+	RemovalAfter        string              `json:"removalAfter,omitempty"`
+	Annotations         map[string][]string `json:"annotations,omitempty"`
+}
+
+// jsonFlagSet is the JSON representation of a FlagSet.
+type jsonFlagSet struct {
+	Name  string     `json:"name"`
+	Flags []jsonFlag `json:"flags"`
+}
+
+func newJSONFlag(flag *Flag) jsonFlag {
+	flag.resolveDefault()
+	return jsonFlag{
+		Name:                flag.Name,
+		Shorthand:           flag.Shorthand,
+		Type:                flag.Value.Type(),
+		Usage:               flag.Usage,
+		Default:             redact(flag, flag.DefValue),
+		Value:               redact(flag, flag.Value.String()),
+		Changed:             flag.Changed,
+		NoOptDefVal:         flag.NoOptDefVal,
+		Hidden:              flag.Hidden,
+		Sensitive:           flag.Sensitive,
+		Deprecated:          flag.Deprecated,
+		ShorthandDeprecated: flag.ShorthandDeprecated,
+		Group:               flag.Group,
+		Example:             flag.Example,
+		AddedIn:             flag.AddedIn,
+		DeprecatedIn:        flag.DeprecatedIn,
+		RemovalAfter:        flag.RemovalAfter,
+		Annotations:         flag.Annotations,
+	}
+}
+
+// MarshalJSON implements json.Marshaler. It describes every flag of the
+// FlagSet, including hidden and deprecated ones, in VisitAll order.
+func (f *FlagSet) MarshalJSON() ([]byte, error) {
+	fs := jsonFlagSet{
+		Name:  f.name,
+		Flags: make([]jsonFlag, 0, len(f.formal)),
+	}
+	f.VisitAll(func(flag *Flag) {
+		fs.Flags = append(fs.Flags, newJSONFlag(flag))
+	})
+	return json.Marshal(fs)
+}
+
+// DumpJSON writes the JSON description of the FlagSet to w, see MarshalJSON.
+func (f *FlagSet) DumpJSON(w io.Writer) error {
+	b, err := f.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
@@ -0,0 +1,28 @@
+package pflag
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDumpJSON(t *testing.T) {
+	fs := NewFlagSet("dump", ContinueOnError)
+	fs.StringP("output", "o", "json", "output format")
+	fs.Int("retries", 0, "number of retries")
+	fs.MarkDeprecated("retries", "don't")
+	fs.SetAnnotation("output", "choices", []string{"json", "yaml"})
+	if err := fs.Parse([]string{"-o", "yaml"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := fs.DumpJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"name":"dump","flags":[` +
+		`{"name":"output","shorthand":"o","type":"string","usage":"output format","default":"json","value":"yaml","changed":true,"annotations":{"choices":["json","yaml"]}},` +
+		`{"name":"retries","type":"int","usage":"number of retries","default":"0","value":"0","changed":false,"deprecated":"don't"}]}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %s want %s", got, want)
+	}
+}
@@ -0,0 +1,197 @@
+// Package jsonschema defines the flags of a pflag.FlagSet from a JSON Schema,
+// for programs whose configuration is already described by one.
+//
+// The schema must describe an object. Each of its properties of type
+// "string", "integer", "number" or "boolean", or an array of those except
+// numbers, defines a flag of the corresponding type, with the description of
+// the property as usage message and its default value, if any. Properties
+// which are objects themselves define the flags of their own properties,
+// with the names joined with ".": {"db": {"properties": {"host": ...}}}
+// defines the flag --db.host. Required properties define required flags.
+//
+//	if err := jsonschema.AddFlags(fs, schema); err != nil {
+//		return err
+//	}
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/pflag"
+)
+
+// schema is the subset of a JSON Schema used to define flags.
+type schema struct {
+	Type        interface{}        `json:"type"` // a type name, or a list of them
+	Description string             `json:"description"`
+	Default     json.RawMessage    `json:"default"`
+	Properties  map[string]*schema `json:"properties"`
+	Required    []string           `json:"required"`
+	Items       *schema            `json:"items"`
+}
+
+// typeName returns the type of s, ignoring "null" in lists of types.
+func (s *schema) typeName() (string, error) {
+	switch t := s.Type.(type) {
+	case nil:
+		if s.Properties != nil {
+			return "object", nil
+		}
+		return "", fmt.Errorf("no type")
+	case string:
+		return t, nil
+	case []interface{}:
+		var name string
+		for _, v := range t {
+			if v == "null" {
+				continue
+			}
+			n, ok := v.(string)
+			if !ok || name != "" {
+				return "", fmt.Errorf("unsupported type %v", t)
+			}
+			name = n
+		}
+		if name == "" {
+			return "", fmt.Errorf("unsupported type %v", t)
+		}
+		return name, nil
+	}
+	return "", fmt.Errorf("unsupported type %v", s.Type)
+}
+
+// AddFlags defines the flags described by the JSON Schema data in fs, see the
+// package documentation. The flags are defined in the sorted order of their
+// names; an error is returned for properties of unsupported types.
+func AddFlags(fs *pflag.FlagSet, data []byte) error {
+	var s schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("jsonschema: %v", err)
+	}
+	if t, err := s.typeName(); err != nil || t != "object" {
+		return fmt.Errorf("jsonschema: schema does not describe an object")
+	}
+	return addProperties(fs, "", &s)
+}
+
+func addProperties(fs *pflag.FlagSet, prefix string, s *schema) error {
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		prop := s.Properties[name]
+		flagName := prefix + name
+		t, err := prop.typeName()
+		if err != nil {
+			return fmt.Errorf("jsonschema: property %q: %v", flagName, err)
+		}
+		if t == "object" {
+			if err := addProperties(fs, flagName+".", prop); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := addFlag(fs, flagName, t, prop); err != nil {
+			return fmt.Errorf("jsonschema: property %q: %v", flagName, err)
+		}
+	}
+
+	for _, name := range s.Required {
+		if prop, ok := s.Properties[name]; ok {
+			if t, _ := prop.typeName(); t == "object" {
+				continue
+			}
+		}
+		if err := fs.MarkRequired(prefix + name); err != nil {
+			return fmt.Errorf("jsonschema: required property %q: %v", prefix+name, err)
+		}
+	}
+	return nil
+}
+
+// addFlag defines the flag name of type t described by prop.
+func addFlag(fs *pflag.FlagSet, name, t string, prop *schema) error {
+	usage := prop.Description
+	switch t {
+	case "string":
+		var def string
+		if err := decodeDefault(prop, &def); err != nil {
+			return err
+		}
+		fs.String(name, def, usage)
+	case "integer":
+		var def int
+		if err := decodeDefault(prop, &def); err != nil {
+			return err
+		}
+		fs.Int(name, def, usage)
+	case "number":
+		var def float64
+		if err := decodeDefault(prop, &def); err != nil {
+			return err
+		}
+		fs.Float64(name, def, usage)
+	case "boolean":
+		var def bool
+		if err := decodeDefault(prop, &def); err != nil {
+			return err
+		}
+		fs.Bool(name, def, usage)
+	case "array":
+		if prop.Items == nil {
+			return fmt.Errorf("array without items")
+		}
+		items, err := prop.Items.typeName()
+		if err != nil {
+			return fmt.Errorf("items: %v", err)
+		}
+		return addSliceFlag(fs, name, items, prop)
+	default:
+		return fmt.Errorf("unsupported type %q", t)
+	}
+	return nil
+}
+
+// addSliceFlag defines the flag name holding a list of items of type t.
+func addSliceFlag(fs *pflag.FlagSet, name, t string, prop *schema) error {
+	usage := prop.Description
+	switch t {
+	case "string":
+		var def []string
+		if err := decodeDefault(prop, &def); err != nil {
+			return err
+		}
+		fs.StringSlice(name, def, usage)
+	case "integer":
+		var def []int
+		if err := decodeDefault(prop, &def); err != nil {
+			return err
+		}
+		fs.IntSlice(name, def, usage)
+	case "boolean":
+		var def []bool
+		if err := decodeDefault(prop, &def); err != nil {
+			return err
+		}
+		fs.BoolSlice(name, def, usage)
+	default:
+		return fmt.Errorf("unsupported array of %q", t)
+	}
+	return nil
+}
+
+// decodeDefault decodes the default value of prop, if any, into v.
+func decodeDefault(prop *schema, v interface{}) error {
+	if len(prop.Default) == 0 || string(prop.Default) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(prop.Default, v); err != nil {
+		return fmt.Errorf("invalid default %s", prop.Default)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestAddFlags(t *testing.T) {
+	schema := `{
+		"type": "object",
+		"properties": {
+			"port": {"type": "integer", "description": "port to listen on", "default": 8080},
+			"host": {"type": "string", "description": "host name"},
+			"ratio": {"type": ["number", "null"], "default": 0.5},
+			"debug": {"type": "boolean"},
+			"tags": {"type": "array", "items": {"type": "string"}, "default": ["a", "b"]},
+			"db": {
+				"type": "object",
+				"properties": {"user": {"type": "string", "default": "admin"}},
+				"required": ["user"]
+			}
+		},
+		"required": ["host", "db"]
+	}`
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	if err := AddFlags(fs, []byte(schema)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name, typ, def, usage string
+		required              bool
+	}{
+		{"port", "int", "8080", "port to listen on", false},
+		{"host", "string", "", "host name", true},
+		{"ratio", "float64", "0.5", "", false},
+		{"debug", "bool", "false", "", false},
+		{"tags", "stringSlice", "[a,b]", "", false},
+		{"db.user", "string", "admin", "", true},
+	}
+	for _, test := range tests {
+		flag := fs.Lookup(test.name)
+		if flag == nil {
+			t.Errorf("flag --%s not defined", test.name)
+			continue
+		}
+		if flag.Value.Type() != test.typ || flag.DefValue != test.def || flag.Usage != test.usage || flag.Required != test.required {
+			t.Errorf("--%s: got %s %q %q %v", test.name, flag.Value.Type(), flag.DefValue, flag.Usage, flag.Required)
+		}
+	}
+	if fs.Lookup("db") != nil {
+		t.Error("objects should not define flags")
+	}
+
+	for _, schema := range []string{
+		`{"type": "string"}`,
+		`{"type": "object", "properties": {"x": {"type": "null"}}}`,
+		`{"type": "object", "properties": {"x": {"type": "integer", "default": "one"}}}`,
+		`{"type": "object", "properties": {"x": {"type": "array", "items": {"type": "object"}}}}`,
+	} {
+		if err := AddFlags(pflag.NewFlagSet("test", pflag.ContinueOnError), []byte(schema)); err == nil {
+			t.Errorf("expected an error for %s", schema)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package pflag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// isAlpha returns true if s is made of ASCII letters only.
+func isAlpha(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i] | 0x20; c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// isDigits returns true if s is made of ASCII digits only.
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isAlnum returns true if s is made of ASCII letters and digits only.
+func isAlnum(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i] | 0x20; (c < 'a' || c > 'z') && (s[i] < '0' || s[i] > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// parseLanguageTag checks that s is a well-formed BCP 47 language tag, as in
+// "en-US", "zh-Hant-TW" or "de-CH-1996", and returns it in its canonical
+// case: lower case but for scripts in title case and regions in upper case.
+// Underscores are taken for hyphens, as in the "en_US" of POSIX locales.
+// Whether the subtags are registered is not checked.
+func parseLanguageTag(s string) (string, error) {
+	invalid := fmt.Errorf("invalid language tag %q", s)
+	subtags := strings.Split(strings.ToLower(strings.Replace(strings.TrimSpace(s), "_", "-", -1)), "-")
+	for _, sub := range subtags {
+		if len(sub) == 0 || len(sub) > 8 || !isAlnum(sub) {
+			return "", invalid
+		}
+	}
+
+	// language, with up to 3 extended language subtags, unless private use.
+	i := 0
+	if subtags[0] != "x" {
+		if len(subtags[0]) < 2 || !isAlpha(subtags[0]) {
+			return "", invalid
+		}
+		i = 1
+		if len(subtags[0]) <= 3 {
+			for j := 0; j < 3 && i < len(subtags) && len(subtags[i]) == 3 && isAlpha(subtags[i]); j++ {
+				i++
+			}
+		}
+		// script
+		if i < len(subtags) && len(subtags[i]) == 4 && isAlpha(subtags[i]) {
+			subtags[i] = strings.ToUpper(subtags[i][:1]) + subtags[i][1:]
+			i++
+		}
+		// region
+		if i < len(subtags) && (len(subtags[i]) == 2 && isAlpha(subtags[i]) || len(subtags[i]) == 3 && isDigits(subtags[i])) {
+			subtags[i] = strings.ToUpper(subtags[i])
+			i++
+		}
+		// variants
+		for i < len(subtags) && (len(subtags[i]) >= 5 || len(subtags[i]) == 4 && isDigits(subtags[i][:1])) {
+			i++
+		}
+		// extensions, each a singleton other than x followed by subtags
+		for i < len(subtags) && len(subtags[i]) == 1 && subtags[i] != "x" {
+			i++
+			start := i
+			for i < len(subtags) && len(subtags[i]) >= 2 {
+				i++
+			}
+			if i == start {
+				return "", invalid
+			}
+		}
+	}
+	// private use
+	if i < len(subtags) && subtags[i] == "x" {
+		if i == len(subtags)-1 {
+			return "", invalid
+		}
+		i = len(subtags)
+	}
+	if i < len(subtags) {
+		return "", invalid
+	}
+	return strings.Join(subtags, "-"), nil
+}
+
+// -- BCP 47 language tag Value
+type languageValue string
+
+func newLanguageValue(val string, p *string) *languageValue {
+	*p = val
+	return (*languageValue)(p)
+}
+
+func (l *languageValue) Set(val string) error {
+	tag, err := parseLanguageTag(val)
+	if err != nil {
+		return err
+	}
+	*l = languageValue(tag)
+	return nil
+}
+
+func (l *languageValue) Type() string {
+	return "language"
+}
+
+func (l *languageValue) String() string { return string(*l) }
+
+func languageConv(sval string) (interface{}, error) {
+	if sval == "" {
+		return "", nil
+	}
+	return parseLanguageTag(sval)
+}
+
+// GetLanguage return the language tag of a flag with the given name
+func (f *FlagSet) GetLanguage(name string) (string, error) {
+	val, err := f.getFlagType(name, "language", languageConv)
+	if err != nil {
+		return "", err
+	}
+	return val.(string), nil
+}
+
+// LanguageVar defines a BCP 47 language tag flag with specified name, default value, and usage string.
+// The value must be a well-formed tag, as in "en-US", and is stored in its canonical case.
+// The argument p points to a string variable in which to store the value of the flag.
+func (f *FlagSet) LanguageVar(p *string, name string, value string, usage string) {
+	f.VarP(newLanguageValue(value, p), name, "", usage)
+}
+
+// LanguageVarP is like LanguageVar, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) LanguageVarP(p *string, name, shorthand string, value string, usage string) {
+	f.VarP(newLanguageValue(value, p), name, shorthand, usage)
+}
+
+// LanguageVar defines a BCP 47 language tag flag with specified name, default value, and usage string.
+// The value must be a well-formed tag, as in "en-US", and is stored in its canonical case.
+// The argument p points to a string variable in which to store the value of the flag.
+func LanguageVar(p *string, name string, value string, usage string) {
+	CommandLine.VarP(newLanguageValue(value, p), name, "", usage)
+}
+
+// LanguageVarP is like LanguageVar, but accepts a shorthand letter that can be used after a single dash.
+func LanguageVarP(p *string, name, shorthand string, value string, usage string) {
+	CommandLine.VarP(newLanguageValue(value, p), name, shorthand, usage)
+}
+
+// Language defines a BCP 47 language tag flag with specified name, default value, and usage string.
+// The value must be a well-formed tag, as in "en-US", and is stored in its canonical case.
+// The return value is the address of a string variable that stores the value of the flag.
+func (f *FlagSet) Language(name string, value string, usage string) *string {
+	p := new(string)
+	f.LanguageVarP(p, name, "", value, usage)
+	return p
+}
+
+// LanguageP is like Language, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) LanguageP(name, shorthand string, value string, usage string) *string {
+	p := new(string)
+	f.LanguageVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// Language defines a BCP 47 language tag flag with specified name, default value, and usage string.
+// The value must be a well-formed tag, as in "en-US", and is stored in its canonical case.
+// The return value is the address of a string variable that stores the value of the flag.
+func Language(name string, value string, usage string) *string {
+	return CommandLine.LanguageP(name, "", value, usage)
+}
+
+// LanguageP is like Language, but accepts a shorthand letter that can be used after a single dash.
+func LanguageP(name, shorthand string, value string, usage string) *string {
+	return CommandLine.LanguageP(name, shorthand, value, usage)
+}
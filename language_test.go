@@ -0,0 +1,61 @@
+package pflag
+
+import (
+	"testing"
+)
+
+func TestLanguage(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"en", "en"},
+		{"en-US", "en-US"},
+		{"en_us", "en-US"},
+		{"ZH-hant-tw", "zh-Hant-TW"},
+		{"es-419", "es-419"},
+		{"de-CH-1996", "de-CH-1996"},
+		{"sl-rozaj-biske", "sl-rozaj-biske"},
+		{"zh-yue-HK", "zh-yue-HK"},
+		{"en-US-u-ca-gregory", "en-US-u-ca-gregory"},
+		{"en-x-private", "en-x-private"},
+		{"x-whatever", "x-whatever"},
+	}
+	for _, test := range tests {
+		f := NewFlagSet("test", ContinueOnError)
+		locale := f.LanguageP("locale", "L", "", "")
+		if err := f.Parse([]string{"-L", test.in}); err != nil {
+			t.Errorf("%s: %v", test.in, err)
+			continue
+		}
+		if *locale != test.want {
+			t.Errorf("%s: got %q, want %q", test.in, *locale, test.want)
+		}
+		if v, err := f.GetLanguage("locale"); err != nil || v != test.want {
+			t.Errorf("%s: GetLanguage returned %q, %v", test.in, v, err)
+		}
+	}
+
+	for _, in := range []string{"", "e", "en-", "en--US", "en-US-u", "en-x", "1en", "en-US-toolongsubtag", "en-Ü", "en-US-US"} {
+		f := NewFlagSet("test", ContinueOnError)
+		f.Language("locale", "", "")
+		if err := f.Set("locale", in); err == nil {
+			t.Errorf("%q: expected an error", in)
+		}
+	}
+
+	f := NewFlagSet("test", ContinueOnError)
+	f.Language("locale", "", "")
+	f.Language("fallback", "en-GB", "")
+	if args := f.ToArgs(false); len(args) != 1 || args[0] != "--fallback=en-GB" {
+		t.Errorf("got args %q", args)
+	}
+	if err := f.Set("locale", "fr"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := f.GetLanguage("locale"); v != "" {
+		t.Errorf("got %q after Reset, want none", v)
+	}
+}
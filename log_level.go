@@ -0,0 +1,173 @@
+//go:build go1.21
+// +build go1.21
+
+package pflag
+
+import (
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// parseLogLevel parses s as a slog.Level, either a level name in any case
+// with an optional offset, as in "debug", "WARN" or "debug-4", or a number.
+func parseLogLevel(s string) (slog.Level, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.Atoi(s); err == nil {
+		return slog.Level(n), nil
+	}
+	var l slog.Level
+	err := l.UnmarshalText([]byte(s))
+	return l, err
+}
+
+// formatLogLevel returns the name of l in lower case, as in "debug-4".
+func formatLogLevel(l slog.Level) string {
+	return strings.ToLower(l.String())
+}
+
+// -- slog.Level Value
+type logLevelValue slog.Level
+
+func newLogLevelValue(val slog.Level, p *slog.Level) *logLevelValue {
+	*p = val
+	return (*logLevelValue)(p)
+}
+
+func (l *logLevelValue) Set(s string) error {
+	v, err := parseLogLevel(s)
+	if err != nil {
+		return err
+	}
+	*l = logLevelValue(v)
+	return nil
+}
+
+func (l *logLevelValue) Type() string {
+	return "level"
+}
+
+func (l *logLevelValue) String() string { return formatLogLevel(slog.Level(*l)) }
+
+// -- *slog.LevelVar Value
+type levelVarValue struct {
+	value *slog.LevelVar
+}
+
+func (l levelVarValue) Set(s string) error {
+	v, err := parseLogLevel(s)
+	if err != nil {
+		return err
+	}
+	l.value.Set(v)
+	return nil
+}
+
+func (l levelVarValue) Type() string {
+	return "level"
+}
+
+func (l levelVarValue) String() string { return formatLogLevel(l.value.Level()) }
+
+// CloneValue returns a Value setting a copy of the LevelVar, so that
+// parsing the clone doesn't change the level of the original logger.
+func (l levelVarValue) CloneValue() Value {
+	c := new(slog.LevelVar)
+	c.Set(l.value.Level())
+	return levelVarValue{c}
+}
+
+func logLevelConv(sval string) (interface{}, error) {
+	return parseLogLevel(sval)
+}
+
+// GetLogLevel return the slog.Level value of a flag with the given name
+func (f *FlagSet) GetLogLevel(name string) (slog.Level, error) {
+	val, err := f.getFlagType(name, "level", logLevelConv)
+	if err != nil {
+		return 0, err
+	}
+	return val.(slog.Level), nil
+}
+
+// LogLevelVar defines a slog.Level flag with specified name, default value, and usage string.
+// The value is a level name, as in "debug", "info", "warn" or "error", with an optional
+// offset as in "debug-4", or a number.
+// The argument p points to a slog.Level variable in which to store the value of the flag.
+func (f *FlagSet) LogLevelVar(p *slog.Level, name string, value slog.Level, usage string) {
+	f.VarP(newLogLevelValue(value, p), name, "", usage)
+}
+
+// LogLevelVarP is like LogLevelVar, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) LogLevelVarP(p *slog.Level, name, shorthand string, value slog.Level, usage string) {
+	f.VarP(newLogLevelValue(value, p), name, shorthand, usage)
+}
+
+// LogLevelVar defines a slog.Level flag with specified name, default value, and usage string.
+// The value is a level name, as in "debug", "info", "warn" or "error", with an optional
+// offset as in "debug-4", or a number.
+// The argument p points to a slog.Level variable in which to store the value of the flag.
+func LogLevelVar(p *slog.Level, name string, value slog.Level, usage string) {
+	CommandLine.VarP(newLogLevelValue(value, p), name, "", usage)
+}
+
+// LogLevelVarP is like LogLevelVar, but accepts a shorthand letter that can be used after a single dash.
+func LogLevelVarP(p *slog.Level, name, shorthand string, value slog.Level, usage string) {
+	CommandLine.VarP(newLogLevelValue(value, p), name, shorthand, usage)
+}
+
+// LogLevel defines a slog.Level flag with specified name, default value, and usage string.
+// The value is a level name, as in "debug", "info", "warn" or "error", with an optional
+// offset as in "debug-4", or a number.
+// The return value is the address of a slog.Level variable that stores the value of the flag.
+func (f *FlagSet) LogLevel(name string, value slog.Level, usage string) *slog.Level {
+	p := new(slog.Level)
+	f.LogLevelVarP(p, name, "", value, usage)
+	return p
+}
+
+// LogLevelP is like LogLevel, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) LogLevelP(name, shorthand string, value slog.Level, usage string) *slog.Level {
+	p := new(slog.Level)
+	f.LogLevelVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// LogLevel defines a slog.Level flag with specified name, default value, and usage string.
+// The value is a level name, as in "debug", "info", "warn" or "error", with an optional
+// offset as in "debug-4", or a number.
+// The return value is the address of a slog.Level variable that stores the value of the flag.
+func LogLevel(name string, value slog.Level, usage string) *slog.Level {
+	return CommandLine.LogLevelP(name, "", value, usage)
+}
+
+// LogLevelP is like LogLevel, but accepts a shorthand letter that can be used after a single dash.
+func LogLevelP(name, shorthand string, value slog.Level, usage string) *slog.Level {
+	return CommandLine.LogLevelP(name, shorthand, value, usage)
+}
+
+// LevelVar defines a flag setting the level of lv, with specified name and usage string,
+// whose default value is the current level of lv. Since handlers read the level of a
+// slog.LevelVar as they log, setting the flag immediately affects the loggers using lv.
+// The value is given as for LogLevelVar.
+func (f *FlagSet) LevelVar(lv *slog.LevelVar, name string, usage string) {
+	f.VarP(levelVarValue{lv}, name, "", usage)
+}
+
+// LevelVarP is like LevelVar, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) LevelVarP(lv *slog.LevelVar, name, shorthand string, usage string) {
+	f.VarP(levelVarValue{lv}, name, shorthand, usage)
+}
+
+// LevelVar defines a flag setting the level of lv, with specified name and usage string,
+// whose default value is the current level of lv. Since handlers read the level of a
+// slog.LevelVar as they log, setting the flag immediately affects the loggers using lv.
+// The value is given as for LogLevelVar.
+func LevelVar(lv *slog.LevelVar, name string, usage string) {
+	CommandLine.VarP(levelVarValue{lv}, name, "", usage)
+}
+
+// LevelVarP is like LevelVar, but accepts a shorthand letter that can be used after a single dash.
+func LevelVarP(lv *slog.LevelVar, name, shorthand string, usage string) {
+	CommandLine.VarP(levelVarValue{lv}, name, shorthand, usage)
+}
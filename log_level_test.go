@@ -0,0 +1,81 @@
+//go:build go1.21
+// +build go1.21
+
+package pflag
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogLevel(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	level := f.LogLevelP("log-level", "l", slog.LevelInfo, "minimum `level` of the logs")
+	tests := []struct {
+		in   string
+		want slog.Level
+	}{
+		{"debug", slog.LevelDebug},
+		{"WARN", slog.LevelWarn},
+		{"error", slog.LevelError},
+		{"debug-4", slog.LevelDebug - 4},
+		{"info+2", slog.LevelInfo + 2},
+		{"-8", slog.Level(-8)},
+	}
+	for _, test := range tests {
+		if err := f.Parse([]string{"-l", test.in}); err != nil {
+			t.Errorf("%s: %v", test.in, err)
+			continue
+		}
+		if *level != test.want {
+			t.Errorf("%s: got %v, want %v", test.in, *level, test.want)
+		}
+	}
+	if got := f.Lookup("log-level").Value.String(); got != "debug-4" {
+		t.Errorf("got string %q, want debug-4", got)
+	}
+	if v, err := f.GetLogLevel("log-level"); err != nil || v != slog.LevelDebug-4 {
+		t.Errorf("GetLogLevel returned %v, %v", v, err)
+	}
+	if err := f.Set("log-level", "verbose"); err == nil {
+		t.Error("expected an error for an unknown level")
+	}
+	if got, want := f.FlagUsages(), "  -l, --log-level level   minimum level of the logs (default info)\n"; got != want {
+		t.Errorf("got usage %q, want %q", got, want)
+	}
+}
+
+func TestLevelVar(t *testing.T) {
+	lv := new(slog.LevelVar)
+	lv.Set(slog.LevelWarn)
+	buf := new(bytes.Buffer)
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: lv}))
+
+	f := NewFlagSet("test", ContinueOnError)
+	f.LevelVar(lv, "log-level", "")
+	if got := f.Lookup("log-level").DefValue; got != "warn" {
+		t.Errorf("got default %q, want warn", got)
+	}
+
+	c, err := f.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Parse([]string{"--log-level=error"}); err != nil {
+		t.Fatal(err)
+	}
+	if lv.Level() != slog.LevelWarn {
+		t.Errorf("parsing a clone changed the level to %v", lv.Level())
+	}
+
+	logger.Debug("hidden")
+	if err := f.Parse([]string{"--log-level=debug"}); err != nil {
+		t.Fatal(err)
+	}
+	logger.Debug("shown")
+	if got := buf.String(); strings.Contains(got, "hidden") || !strings.Contains(got, "shown") {
+		t.Errorf("got logs %q", got)
+	}
+}
@@ -0,0 +1,33 @@
+//go:build go1.21
+// +build go1.21
+
+package pflag
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SetLogger sets a logger receiving debug events about parsing, to diagnose
+// why a flag ends up with its value: the arguments consumed, the flags
+// matched, the values set from every source with that source, aliases
+// expanded, unknown flags and deprecation warnings. Values of sensitive
+// flags are redacted. The events are logged at slog.LevelDebug, with the
+// name of the FlagSet under the "flagset" key. A nil logger, the default,
+// disables them.
+func (f *FlagSet) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		f.logDebug = nil
+		return
+	}
+	logger = logger.With("flagset", f.name)
+	f.logDebug = func(msg string, args ...interface{}) {
+		ctx := f.ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		if logger.Enabled(ctx, slog.LevelDebug) {
+			logger.Log(ctx, slog.LevelDebug, "pflag: "+msg, args...)
+		}
+	}
+}
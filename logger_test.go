@@ -0,0 +1,61 @@
+//go:build go1.21
+// +build go1.21
+
+package pflag
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSetLogger(t *testing.T) {
+	f := NewFlagSet("app", ContinueOnError)
+	f.SetErrOutput(new(bytes.Buffer))
+	f.String("name", "", "")
+	f.String("token", "", "")
+	f.MarkSensitive("token")
+	f.Bool("old", false, "")
+	f.MarkDeprecated("old", "use --name")
+	f.Bool("gone", false, "")
+	f.MarkDeprecatedUntil("gone", "2000-01-01", "use --name")
+
+	var buf bytes.Buffer
+	f.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	if err := f.Parse([]string{"--name", "x", "--token=secret", "--old", "file", "--", "rest"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Parse([]string{"--gone"}); err == nil {
+		t.Error("expected an error setting a removed flag")
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`msg="pflag: flag matched" flagset=app flag=name value=x source="command line" position=0`,
+		`msg="pflag: value set" flagset=app flag=name value=x source="command line"`,
+		`msg="pflag: deprecated flag" flagset=app flag=old message="use --name"`,
+		`msg="pflag: positional argument" flagset=app arg=file position=4`,
+		`msg="pflag: end of flags" flagset=app position=5`,
+		`msg="pflag: removed flag" flagset=app flag=gone removal=2000-01-01`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %s in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "secret") {
+		t.Errorf("sensitive value logged:\n%s", out)
+	}
+
+	buf.Reset()
+	f.SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+	if err := f.Parse([]string{"--name", "y"}); err != nil {
+		t.Fatal(err)
+	}
+	f.SetLogger(nil)
+	if err := f.Parse([]string{"--name", "z"}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("got events above the debug level:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,169 @@
+package pflag
+
+import (
+	"fmt"
+	"sync"
+)
+
+// MessageID identifies one of the messages printed or returned by the
+// parser and the usage output.
+type MessageID int
+
+// The built-in messages. Each is a fmt format string; translations must
+// keep the verbs, in the same order.
+const (
+	MsgUsageOf                MessageID = iota // "Usage of %s:\n" with the FlagSet's name
+	MsgDefault                                 // "(default %s)" with the default value
+	MsgBadFlagSyntax                           // "bad flag syntax: %s" with the argument
+	MsgUnknownFlag                             // "unknown flag: --%s" with the flag name
+	MsgUnknownShorthand                        // "unknown shorthand flag: %q in -%s" with the shorthand and the argument
+	MsgNeedsArgument                           // "flag needs an argument: %s" with the argument
+	MsgShorthandNeedsArgument                  // "flag needs an argument: %q in -%s" with the shorthand and the argument
+	MsgInvalidArgument                         // "invalid argument %q for %q flag: %v" with the value, the flag and the error
+	MsgNoSuchFlag                              // "no such flag -%v" with the flag name
+	MsgDeprecated                              // "Flag --%s has been deprecated, %s\n" with the flag name and the message
+	MsgShorthandDeprecated                     // "Flag shorthand -%s has been deprecated, %s\n" with the shorthand and the message
+	MsgExample                                 // "Example: %s" with the example of the flag
+	MsgCommands                                // "\nCommands:\n" heading the subcommands in usage messages
+	MsgTooManyOccurrences                      // "flag --%s may be given at most %d times" with the flag name and the limit
+	MsgTooFewOccurrences                       // "flag --%s must be given at least %d times" with the flag name and the limit
+	MsgRepeated                                // "flag --%s given more than once" with the flag name
+	MsgRequired                                // "required flag --%s not set" with the flag name
+	MsgPrompt                                  // "value for --%s: " prompting for a required flag
+	MsgValueFile                               // "can not read value of flag --%s: %v" with the flag name and the error
+	MsgStdinTwice                              // "flags --%s and --%s both read their value from stdin" with the flag names
+	MsgAddedIn                                 // "(added in %s)" with the release, in verbose help
+	MsgDeprecatedIn                            // "(deprecated in %s)" with the release, in verbose help
+	MsgDeprecatedFlag                          // "(deprecated)" for deprecated flags in verbose help
+	MsgChoices                                 // "must be one of %s" with the comma separated choices
+	MsgShorthandMidGroup                       // "flag -%c takes a value and must be last in -%s" with the shorthand and the argument
+	MsgAppendsDefault                          // "(appends to the default)" for slice flags, see SetSliceAppendDefault
+	MsgGivenOnce                               // "(may be given once)" for slice flags which can't be repeated
+	MsgDeprecatedUntil                         // "Flag --%s has been deprecated and will be removed after %s, %s\n" with the flag name, the removal and the message
+	MsgRemoved                                 // "flag --%s was removed after %s, %s" with the flag name, the removal and the message
+	MsgRemovalAfter                            // "(to be removed after %s)" with the removal, in verbose help
+	MsgVersionUsage                            // "print the version and exit" usage of the flag defined by SetVersion
+	MsgRequiresEquals                          // "flag --%s requires its value after '=', as in --%s=%s" with the flag name, again, and the next argument
+	MsgUsageLine                               // "Usage: %s\n" with the usage line, see SetUsageLine
+	MsgArguments                               // "\nArguments:\n" heading the positional arguments in usage messages
+	MsgMissingArgument                         // "missing argument %s" with the name of the positional argument
+	MsgInvalidPositional                       // "invalid argument %q for %s: %v" with the value, the name of the positional argument and the error
+	MsgUnexpectedArgument                      // "unexpected argument %q" with the first argument left over
+	MsgArgCount                                // "expected %d arguments, got %d" with the expected and actual numbers of arguments
+	MsgTooFewArgs                              // "expected at least %d arguments, got %d" with the minimum and actual numbers of arguments
+	MsgTooManyArgs                             // "expected at most %d arguments, got %d" with the maximum and actual numbers of arguments
+	MsgRange                                   // "(between %s and %s)" with the bounds of range flags, see IntRange
+	MsgBitmask                                 // "(any of %s)" with the comma separated names of the bits of bitmask flags
+	MsgAmbiguousFlag                           // "ambiguous flag: --%s could be %s" with the abbreviation and the comma separated flags
+	MsgSuggestion                              // ", did you mean %s?" appended to unknown flag errors with the comma separated flags
+	MsgInheritedFlags                          // "\nInherited flags:\n" heading the flags of the parent FlagSets in usage messages
+	MsgEnvVar                                  // "(env: %s)" with the environment variable bound to the flag, see BindFlagToEnv
+	MsgOneOf                                   // "(one of %s)" with the comma separated values allowed by RegisterEnum
+)
+
+// Messages is a catalog of messages, indexed by MessageID.
+type Messages map[MessageID]string
+
+var defaultMessages = Messages{
+	MsgUsageOf:                "Usage of %s:\n",
+	MsgDefault:                "(default %s)",
+	MsgBadFlagSyntax:          "bad flag syntax: %s",
+	MsgUnknownFlag:            "unknown flag: --%s",
+	MsgUnknownShorthand:       "unknown shorthand flag: %q in -%s",
+	MsgNeedsArgument:          "flag needs an argument: %s",
+	MsgShorthandNeedsArgument: "flag needs an argument: %q in -%s",
+	MsgInvalidArgument:        "invalid argument %q for %q flag: %v",
+	MsgNoSuchFlag:             "no such flag -%v",
+	MsgDeprecated:             "Flag --%s has been deprecated, %s\n",
+	MsgShorthandDeprecated:    "Flag shorthand -%s has been deprecated, %s\n",
+	MsgExample:                "Example: %s",
+	MsgCommands:               "\nCommands:\n",
+	MsgTooManyOccurrences:     "flag --%s may be given at most %d times",
+	MsgTooFewOccurrences:      "flag --%s must be given at least %d times",
+	MsgRepeated:               "flag --%s given more than once",
+	MsgRequired:               "required flag --%s not set",
+	MsgPrompt:                 "value for --%s: ",
+	MsgValueFile:              "can not read value of flag --%s: %v",
+	MsgStdinTwice:             "flags --%s and --%s both read their value from stdin",
+	MsgAddedIn:                "(added in %s)",
+	MsgDeprecatedIn:           "(deprecated in %s)",
+	MsgDeprecatedFlag:         "(deprecated)",
+	MsgChoices:                "must be one of %s",
+	MsgShorthandMidGroup:      "flag -%c takes a value and must be last in -%s",
+	MsgAppendsDefault:         "(appends to the default)",
+	MsgGivenOnce:              "(may be given once)",
+	MsgDeprecatedUntil:        "Flag --%s has been deprecated and will be removed after %s, %s\n",
+	MsgRemoved:                "flag --%s was removed after %s, %s",
+	MsgRemovalAfter:           "(to be removed after %s)",
+	MsgVersionUsage:           "print the version and exit",
+	MsgRequiresEquals:         "flag --%s requires its value after '=', as in --%s=%s",
+	MsgUsageLine:              "Usage: %s\n",
+	MsgArguments:              "\nArguments:\n",
+	MsgMissingArgument:        "missing argument %s",
+	MsgInvalidPositional:      "invalid argument %q for %s: %v",
+	MsgUnexpectedArgument:     "unexpected argument %q",
+	MsgArgCount:               "expected %d arguments, got %d",
+	MsgTooFewArgs:             "expected at least %d arguments, got %d",
+	MsgTooManyArgs:            "expected at most %d arguments, got %d",
+	MsgRange:                  "(between %s and %s)",
+	MsgBitmask:                "(any of %s)",
+	MsgAmbiguousFlag:          "ambiguous flag: --%s could be %s",
+	MsgSuggestion:             ", did you mean %s?",
+	MsgInheritedFlags:         "\nInherited flags:\n",
+	MsgEnvVar:                 "(env: %s)",
+	MsgOneOf:                  "(one of %s)",
+}
+
+// locales holds the catalogs registered with RegisterLocale, guarded by
+// localesMu.
+var (
+	localesMu sync.RWMutex
+	locales   = map[string]Messages{
+		"en": defaultMessages,
+	}
+)
+
+// RegisterLocale registers a catalog of messages for the given locale, to be
+// selected with SetLocale. Messages missing from the catalog fall back to
+// English. Registering a locale again replaces its catalog. RegisterLocale
+// is safe for concurrent use, but is meant to be called from init functions,
+// before the catalogs are selected.
+func RegisterLocale(locale string, msgs Messages) {
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	locales[locale] = msgs
+}
+
+// SetLocale selects the registered catalog of messages used by the FlagSet.
+func (f *FlagSet) SetLocale(locale string) error {
+	localesMu.RLock()
+	msgs, ok := locales[locale]
+	localesMu.RUnlock()
+	if !ok {
+		return fmt.Errorf("no messages registered for locale %q", locale)
+	}
+	f.locale = msgs
+	return nil
+}
+
+// SetMessages overrides individual messages of the FlagSet. They take
+// precedence over the catalog selected with SetLocale.
+func (f *FlagSet) SetMessages(msgs Messages) {
+	if f.messages == nil {
+		f.messages = make(Messages)
+	}
+	for id, msg := range msgs {
+		f.messages[id] = msg
+	}
+}
+
+// msg returns the format string of the message id.
+func (f *FlagSet) msg(id MessageID) string {
+	if msg, ok := f.messages[id]; ok {
+		return msg
+	}
+	if msg, ok := f.locale[id]; ok {
+		return msg
+	}
+	return defaultMessages[id]
+}
@@ -0,0 +1,48 @@
+package pflag
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLocale(t *testing.T) {
+	RegisterLocale("de", Messages{
+		MsgUsageOf:     "Aufruf von %s:\n",
+		MsgDefault:     "(Standard %s)",
+		MsgUnknownFlag: "unbekannte Option: --%s",
+	})
+	defer func() {
+		localesMu.Lock()
+		delete(locales, "de")
+		localesMu.Unlock()
+	}()
+
+	fs := NewFlagSet("prog", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.Int("n", 3, "Anzahl")
+	if err := fs.SetLocale("de"); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.SetLocale("xx"); err == nil {
+		t.Error("expected an error for an unknown locale")
+	}
+	fs.SetMessages(Messages{MsgNeedsArgument: "Argument fehlt: %s"})
+
+	if err := fs.Parse([]string{"--nope"}); err == nil || err.Error() != "unbekannte Option: --nope" {
+		t.Errorf("unexpected error %v", err)
+	}
+	want := "unbekannte Option: --nope\nAufruf von prog:\n      --n int   Anzahl (Standard 3)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+
+	buf.Reset()
+	if err := fs.Parse([]string{"--n"}); err == nil || err.Error() != "Argument fehlt: --n" {
+		t.Errorf("unexpected error %v", err)
+	}
+	// Messages missing from the catalog fall back to English.
+	if err := fs.Parse([]string{"--n=x"}); err == nil || err.Error() != `invalid argument "x" for "--n" flag: strconv.ParseInt: parsing "x": invalid syntax` {
+		t.Errorf("unexpected error %v", err)
+	}
+}
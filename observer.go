@@ -0,0 +1,43 @@
+package pflag
+
+// Observer receives events about the use of the flags of a FlagSet, e.g. to
+// measure which flags are used in the field before removing them, see
+// SetObserver. Its methods are called synchronously, as the events happen.
+type Observer interface {
+	// FlagSet is called when the value of flag was set successfully, by
+	// Parse or from another source, which Flag.Source tells. The value is
+	// redacted if the flag is sensitive.
+	FlagSet(flag *Flag, value string)
+	// UnknownFlag is called when an unknown flag is found in the arguments
+	// given to Parse, with the flag as written, like "--colour" or "-x".
+	UnknownFlag(arg string)
+	// DeprecatedFlag is called when a deprecated flag is set, or when a
+	// flag is given by its deprecated shorthand if shorthand is true.
+	DeprecatedFlag(flag *Flag, shorthand bool)
+	// ValidationFailed is called when the value given to flag is rejected,
+	// with the error returned by its Value or its validation. The value is
+	// redacted if the flag is sensitive.
+	ValidationFailed(flag *Flag, value string, err error)
+}
+
+// NopObserver is an Observer which ignores all events, to be embedded by
+// Observers interested in some events only.
+type NopObserver struct{}
+
+// FlagSet implements Observer.
+func (NopObserver) FlagSet(flag *Flag, value string) {}
+
+// UnknownFlag implements Observer.
+func (NopObserver) UnknownFlag(arg string) {}
+
+// DeprecatedFlag implements Observer.
+func (NopObserver) DeprecatedFlag(flag *Flag, shorthand bool) {}
+
+// ValidationFailed implements Observer.
+func (NopObserver) ValidationFailed(flag *Flag, value string, err error) {}
+
+// SetObserver sets the Observer receiving events about the use of the flags
+// of f. A nil Observer, the default, receives nothing.
+func (f *FlagSet) SetObserver(o Observer) {
+	f.observer = o
+}
@@ -0,0 +1,104 @@
+package pflag
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+// recordingObserver records the events it receives.
+type recordingObserver struct {
+	NopObserver
+	events []string
+}
+
+func (o *recordingObserver) FlagSet(flag *Flag, value string) {
+	o.events = append(o.events, fmt.Sprintf("set %s=%s from %s", flag.Name, value, flag.Source()))
+}
+
+func (o *recordingObserver) UnknownFlag(arg string) {
+	o.events = append(o.events, "unknown "+arg)
+}
+
+func (o *recordingObserver) DeprecatedFlag(flag *Flag, shorthand bool) {
+	o.events = append(o.events, fmt.Sprintf("deprecated %s (shorthand %v)", flag.Name, shorthand))
+}
+
+func TestObserver(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	o := new(recordingObserver)
+	f.SetObserver(o)
+	f.IntP("port", "p", 80, "")
+	f.StringP("old", "o", "", "")
+	f.String("token", "", "")
+	f.String("gone", "", "")
+	if err := f.MarkDeprecated("old", "use --new"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.MarkDeprecatedUntil("gone", "2000-01-01", "use --new"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.MarkShorthandDeprecated("port", "use --port"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.MarkSensitive("token"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.Parse([]string{"-p", "8080", "--old=x", "--token", "secret"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Set("port", "9090"); err != nil {
+		t.Fatal(err)
+	}
+	f.Parse([]string{"--colour"})
+	f.Parse([]string{"-x"})
+	if err := f.Parse([]string{"--gone=y"}); err == nil {
+		t.Error("expected an error setting a removed flag")
+	}
+	want := []string{
+		"deprecated port (shorthand true)",
+		"set port=8080 from command line",
+		"deprecated old (shorthand false)",
+		"set old=x from command line",
+		"set token=**** from command line",
+		"set port=9090 from set",
+		"unknown --colour",
+		"unknown -x",
+		"deprecated gone (shorthand false)",
+	}
+	if !reflect.DeepEqual(o.events, want) {
+		t.Errorf("got events\n%q\nwant\n%q", o.events, want)
+	}
+}
+
+// validationObserver records the validation failures it receives.
+type validationObserver struct {
+	NopObserver
+	flag  *Flag
+	value string
+	err   error
+}
+
+func (o *validationObserver) ValidationFailed(flag *Flag, value string, err error) {
+	o.flag, o.value, o.err = flag, value, err
+}
+
+func TestObserverValidationFailed(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	o := new(validationObserver)
+	f.SetObserver(o)
+	f.String("mode", "fast", "")
+	if err := f.SetChoices("mode", "fast", "slow"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Parse([]string{"--mode=medium"}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if o.flag == nil || o.flag.Name != "mode" || o.value != "medium" || o.err == nil {
+		t.Errorf("got %v, %q, %v", o.flag, o.value, o.err)
+	}
+}
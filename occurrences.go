@@ -0,0 +1,121 @@
+package pflag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RepeatPolicy tells what happens when a flag is given several times in the
+// arguments of a call to Parse.
+type RepeatPolicy int
+
+const (
+	// RepeatDefault means a flag follows the policy of its FlagSet, and a
+	// FlagSet RepeatLastWins.
+	RepeatDefault RepeatPolicy = iota
+	// RepeatLastWins means the flag keeps the last value given.
+	RepeatLastWins
+	// RepeatFirstWins means the flag keeps the first value given, later
+	// ones being ignored.
+	RepeatFirstWins
+	// RepeatError means giving the flag more than once is an error.
+	RepeatError
+)
+
+// SetRepeatPolicy sets the policy of the flags of the FlagSet which don't
+// have their own, see SetFlagRepeatPolicy.
+func (f *FlagSet) SetRepeatPolicy(policy RepeatPolicy) {
+	f.repeatPolicy = policy
+}
+
+// SetFlagRepeatPolicy sets the policy of the named flag for being given more
+// than once, overriding the one of the FlagSet. Flags which accumulate their
+// values, like slices and counts, only follow their own policy, so that e.g.
+// RepeatError makes a slice flag take a single list.
+func (f *FlagSet) SetFlagRepeatPolicy(name string, policy RepeatPolicy) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	flag.repeatPolicy = policy
+	return nil
+}
+
+// repeatPolicyOf returns the policy which applies to flag.
+func (f *FlagSet) repeatPolicyOf(flag *Flag) RepeatPolicy {
+	if flag.repeatPolicy != RepeatDefault {
+		return flag.repeatPolicy
+	}
+	if accumulates(flag) {
+		return RepeatLastWins
+	}
+	if f.repeatPolicy != RepeatDefault {
+		return f.repeatPolicy
+	}
+	return RepeatLastWins
+}
+
+// accumulates returns true if setting the flag several times accumulates
+// values rather than replacing them.
+func accumulates(flag *Flag) bool {
+	if _, ok := unwrapValue(flag.Value).(SliceValue); ok {
+		return true
+	}
+	typ := flag.Value.Type()
+	return typ == "count" || typ == "header" || typ == "bitmask" || strings.HasSuffix(typ, "Slice") || strings.HasSuffix(typ, "Array") || strings.HasPrefix(typ, "stringTo")
+}
+
+// SetMinOccurrences requires the named flag to be given at least n times on
+// the command line, e.g. "at least one --input". The limit is checked once
+// Parse has parsed the arguments.
+func (f *FlagSet) SetMinOccurrences(name string, n int) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	flag.minOccurrences = n
+	return nil
+}
+
+// SetMaxOccurrences allows the named flag to be given at most n times on the
+// command line, e.g. "at most 5 --header". Parse fails as soon as the flag is
+// given once more. A limit of 0 or less means no limit.
+func (f *FlagSet) SetMaxOccurrences(name string, n int) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	flag.maxOccurrences = n
+	return nil
+}
+
+// countOccurrence records that flag was found in the arguments, and fails if
+// it was given more often than allowed. repeated tells whether the flag was
+// already given in the arguments of the current call to Parse; skip is then
+// true if the value must be ignored according to the RepeatPolicy.
+func (f *FlagSet) countOccurrence(flag *Flag, repeated bool) (skip bool, err error) {
+	flag.occurrences++
+	if flag.maxOccurrences > 0 && flag.occurrences > flag.maxOccurrences {
+		return false, f.failf(f.msg(MsgTooManyOccurrences), flag.Name, flag.maxOccurrences)
+	}
+	if !repeated {
+		return false, nil
+	}
+	switch f.repeatPolicyOf(flag) {
+	case RepeatFirstWins:
+		return true, nil
+	case RepeatError:
+		return false, f.failf(f.msg(MsgRepeated), flag.Name)
+	}
+	return false, nil
+}
+
+// checkMinOccurrences fails if a flag was not given as often as required.
+func (f *FlagSet) checkMinOccurrences() error {
+	for _, flag := range f.orderedFormal {
+		if flag.occurrences < flag.minOccurrences {
+			return f.failf(f.msg(MsgTooFewOccurrences), flag.Name, flag.minOccurrences)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package pflag
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestOccurrences(t *testing.T) {
+	newFlagSet := func() *FlagSet {
+		f := NewFlagSet("test", ContinueOnError)
+		f.SetOutput(ioutil.Discard)
+		f.StringSlice("input", nil, "")
+		f.StringArrayP("header", "H", nil, "")
+		if err := f.SetMinOccurrences("input", 1); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.SetMaxOccurrences("header", 2); err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	tests := []struct {
+		args []string
+		err  string
+	}{
+		{[]string{"--input=a"}, ""},
+		{[]string{"--input=a", "-H", "x", "--header=y"}, ""},
+		{[]string{"-H", "x"}, "flag --input must be given at least 1 times"},
+		{[]string{"--input=a", "-H", "x", "-H", "y", "-H", "z"}, "flag --header may be given at most 2 times"},
+	}
+	for _, tt := range tests {
+		err := newFlagSet().Parse(tt.args)
+		if tt.err == "" && err != nil || tt.err != "" && (err == nil || err.Error() != tt.err) {
+			t.Errorf("%v: got error %v want %q", tt.args, err, tt.err)
+		}
+	}
+
+	f := newFlagSet()
+	if err := f.SetMaxOccurrences("missing", 1); err == nil {
+		t.Error("expected an error for a missing flag")
+	}
+	if err := f.Parse([]string{"--input=a", "-H", "x"}); err != nil {
+		t.Fatal(err)
+	}
+	f.Reset()
+	if err := f.Parse([]string{"--input=a", "-H", "x", "-H", "y"}); err != nil {
+		t.Errorf("occurrences not cleared by Reset: %v", err)
+	}
+}
+
+func TestRepeatPolicy(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	output := f.StringP("output", "o", "", "")
+	level := f.Int("level", 0, "")
+	tags := f.StringSlice("tags", nil, "")
+	list := &listValue{}
+	f.Var(list, "list", "")
+	f.SetRepeatPolicy(RepeatFirstWins)
+	if err := f.SetFlagRepeatPolicy("output", RepeatError); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.Parse([]string{"--level=1", "--level=2", "--tags=a", "--tags=b", "-o", "x", "--list=c", "--list=d"}); err != nil {
+		t.Fatal(err)
+	}
+	if *level != 1 || len(*tags) != 2 || *output != "x" || len(*list) != 2 {
+		t.Errorf("unexpected values %d %v %q %v", *level, *tags, *output, *list)
+	}
+	if err := f.Parse([]string{"-o", "y", "--level=3"}); err != nil {
+		t.Fatalf("flags given again by a later Parse: %v", err)
+	}
+	if *level != 3 || *output != "y" {
+		t.Errorf("later Parse did not layer on top: %d %q", *level, *output)
+	}
+	want := "flag --output given more than once"
+	if err := f.Parse([]string{"-o", "y", "--output=z"}); err == nil || err.Error() != want {
+		t.Errorf("got error %v want %s", err, want)
+	}
+}
+
+func TestRepeatPolicySharedFlag(t *testing.T) {
+	lib := NewFlagSet("lib", ContinueOnError)
+	lib.String("output", "", "")
+	lib.SetRepeatPolicy(RepeatError)
+	app := NewFlagSet("app", ContinueOnError)
+	app.AddFlagSet(lib)
+	app.SetRepeatPolicy(RepeatError)
+
+	if err := lib.Parse([]string{"--output=a"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Parse([]string{"--output=b"}); err != nil {
+		t.Errorf("flag given once reported as repeated: %v", err)
+	}
+}
@@ -0,0 +1,232 @@
+package pflag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParsePartial sets the named flags, or all the flags of f if no names are
+// given, from their occurrences in arguments, ignoring any other argument,
+// including unknown flags, without error. It is meant to extract bootstrap
+// flags, like --config or --profile, before the other flags are defined,
+// typically with a FlagSet of its own; the arguments and f.Args are left
+// untouched. The values of the other flags of f are skipped as Parse would,
+// so that they aren't taken for flags. Scanning stops at "--". Since a full
+// Parse sets the flags again, extracting slices with ParsePartial into the
+// FlagSet later parsed makes them hold their values twice.
+func (f *FlagSet) ParsePartial(arguments []string, names ...string) error {
+	var wanted map[*Flag]bool
+	if len(names) > 0 {
+		wanted = make(map[*Flag]bool, len(names))
+		for _, name := range names {
+			flag := f.Lookup(name)
+			if flag == nil {
+				return fmt.Errorf("flag %q does not exist", name)
+			}
+			wanted[flag] = true
+		}
+	}
+	extract := func(flag *Flag) bool { return wanted == nil || wanted[flag] }
+
+	for i := 0; i < len(arguments); i++ {
+		s := arguments[i]
+		if s == "--" {
+			break
+		}
+		if len(s) < 2 || s[0] != '-' {
+			continue
+		}
+
+		if s[1] == '-' {
+			name, value, hasValue := s[2:], "", false
+			for j := 0; j < len(name); j++ {
+				if name[j] == '=' {
+					name, value, hasValue = name[:j], name[j+1:], true
+					break
+				}
+			}
+			flag, ok := f.formal[f.normalizeFlagName(name)]
+			if !ok {
+				continue
+			}
+			// The value of a flag which isn't extracted is skipped too.
+			src := SourceCommandLine
+			switch {
+			case hasValue:
+			case flag.NoOptDefVal != "":
+				value, src = flag.NoOptDefVal, SourceDefaultArg
+			case i+1 < len(arguments):
+				i++
+				value = arguments[i]
+			case !extract(flag):
+				continue
+			default:
+				return fmt.Errorf(f.msg(MsgNeedsArgument), s)
+			}
+			if !extract(flag) {
+				continue
+			}
+			if err := f.set(flag.Name, value, src); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// A group of shorthands, like -vc file, ends at the first one which
+		// takes a value or is unknown.
+		for j := 1; j < len(s); {
+			flag, n := f.shorthandAt(s[j:])
+			if flag == nil {
+				break
+			}
+			k := j + n
+			value, src := "", SourceCommandLine
+			switch {
+			case k < len(s) && s[k] == '=':
+				value = s[k+1:]
+			case flag.NoOptDefVal != "":
+				value, src = flag.NoOptDefVal, SourceDefaultArg
+			case k < len(s):
+				value = s[k:]
+			case i+1 < len(arguments):
+				i++
+				value = arguments[i]
+			case !extract(flag):
+				// Only the flags extracted need their value.
+			default:
+				return fmt.Errorf(f.msg(MsgShorthandNeedsArgument), shorthandArg(s[j:k]), s[1:])
+			}
+			if extract(flag) {
+				if err := f.set(flag.Name, value, src); err != nil {
+					return err
+				}
+			}
+			if src != SourceDefaultArg {
+				break
+			}
+			j = k
+		}
+	}
+	return nil
+}
+
+// ParseKnown sets the flags of f from their occurrences in arguments and
+// returns the other arguments, untouched and in order, so that a wrapper
+// program can handle its own flags and pass the rest on verbatim to the
+// program it runs. Positional arguments, unknown flags and groups of
+// shorthands holding an unknown one are returned as is; since the value of an
+// unknown flag, given in the next argument, can not be told apart from a
+// positional argument, it is returned too. A "--" ends the flags of f: it is
+// dropped and the arguments following it are returned. Unlike Parse, it
+// leaves Args untouched and doesn't check for required flags.
+func (f *FlagSet) ParseKnown(arguments []string) (rest []string, err error) {
+	f.parsed = true
+	rest = make([]string, 0, len(arguments))
+	for i := 0; i < len(arguments); i++ {
+		s := arguments[i]
+		if s == "--" {
+			return append(rest, arguments[i+1:]...), nil
+		}
+		if len(s) < 2 || s[0] != '-' || f.isNegativeNumber(s) {
+			rest = append(rest, s)
+			continue
+		}
+		var n int
+		if s[1] == '-' {
+			n, err = f.parseKnownLong(s, arguments[i+1:])
+		} else {
+			n, err = f.parseKnownShorthands(s, arguments[i+1:])
+		}
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			rest = append(rest, s)
+			continue
+		}
+		i += n
+	}
+	return rest, nil
+}
+
+// parseKnownLong sets the flag the long flag s names, if known, and returns
+// the number of the next arguments its value took, or -1 if it is unknown.
+func (f *FlagSet) parseKnownLong(s string, next []string) (int, error) {
+	name, value := s[2:], ""
+	eq := strings.IndexByte(name, '=')
+	if eq >= 0 {
+		name, value = name[:eq], name[eq+1:]
+	}
+	flag, ok := f.parseLookup(name)
+	if !ok {
+		return -1, nil
+	}
+	n, src := 0, SourceCommandLine
+	switch {
+	case eq >= 0:
+	case flag.NoOptDefVal != "":
+		value, src = flag.NoOptDefVal, SourceDefaultArg
+	case len(next) > 0 && f.requiresEquals(flag):
+		return 0, fmt.Errorf(f.msg(MsgRequiresEquals), flag.Name, flag.Name, next[0])
+	case len(next) > 0:
+		value, n = next[0], 1
+	default:
+		return 0, fmt.Errorf(f.msg(MsgNeedsArgument), s)
+	}
+	return n, f.setKnown(flag, value, src)
+}
+
+// knownShorthand is the occurrence of a flag in a group of shorthands.
+type knownShorthand struct {
+	flag  *Flag
+	value string
+	src   ValueSource
+}
+
+// parseKnownShorthands sets the flags of the group of shorthands s if they
+// are all known, and returns the number of the next arguments the value of
+// the last one took, or -1 if one of them is unknown.
+func (f *FlagSet) parseKnownShorthands(s string, next []string) (int, error) {
+	var group []knownShorthand
+	n := 0
+	for j := 1; j < len(s); {
+		flag, l := f.shorthandAt(s[j:])
+		if flag == nil {
+			return -1, nil
+		}
+		k := j + l
+		o := knownShorthand{flag: flag, src: SourceCommandLine}
+		switch {
+		case k < len(s) && s[k] == '=':
+			o.value, k = s[k+1:], len(s)
+		case flag.NoOptDefVal != "":
+			o.value, o.src = flag.NoOptDefVal, SourceDefaultArg
+		case k < len(s):
+			o.value, k = s[k:], len(s)
+		case len(next) > 0 && f.requiresEquals(flag):
+			return 0, fmt.Errorf(f.msg(MsgRequiresEquals), flag.Name, flag.Name, next[0])
+		case len(next) > 0:
+			o.value, n = next[0], 1
+		default:
+			return 0, fmt.Errorf(f.msg(MsgShorthandNeedsArgument), shorthandArg(s[j:k]), s[1:])
+		}
+		group = append(group, o)
+		j = k
+	}
+	for _, o := range group {
+		if err := f.setKnown(o.flag, o.value, o.src); err != nil {
+			return 0, err
+		}
+	}
+	return n, nil
+}
+
+// setKnown sets flag, which may be inherited from an ancestor of f, to value
+// given on the command line.
+func (f *FlagSet) setKnown(flag *Flag, value string, src ValueSource) error {
+	value, err := f.resolveValue(flag, value, src)
+	if err != nil {
+		return err
+	}
+	return f.flagSetOf(flag).set(flag.Name, value, src)
+}
@@ -0,0 +1,97 @@
+package pflag
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParsePartial(t *testing.T) {
+	args := []string{"serve", "--unknown", "x", "--config", "app.yaml", "-vp", "prod", "--port=80", "--", "--config=ignored"}
+	f := NewFlagSet("bootstrap", ContinueOnError)
+	config := f.String("config", "", "")
+	profile := f.StringP("profile", "p", "", "")
+	verbose := f.BoolP("verbose", "v", false, "")
+	port := f.Int("port", 0, "")
+	if err := f.ParsePartial(args, "config", "profile"); err != nil {
+		t.Fatal(err)
+	}
+	if *config != "app.yaml" || *profile != "prod" {
+		t.Errorf("got --config=%q and --profile=%q", *config, *profile)
+	}
+	if *verbose || *port != 0 {
+		t.Errorf("flags which were not asked for were set: --verbose=%v --port=%d", *verbose, *port)
+	}
+	if !f.Changed("config") || f.Lookup("config").Source() != SourceCommandLine {
+		t.Error("--config should be changed from the command line")
+	}
+	if len(f.Args()) != 0 {
+		t.Errorf("got args %q", f.Args())
+	}
+
+	all := NewFlagSet("all", ContinueOnError)
+	allVerbose := all.BoolP("verbose", "v", false, "")
+	allPort := all.Int("port", 0, "")
+	allProfile := all.StringP("profile", "p", "", "")
+	if err := all.ParsePartial(args); err != nil {
+		t.Fatal(err)
+	}
+	if !*allVerbose || *allPort != 80 || *allProfile != "prod" {
+		t.Errorf("got --verbose=%v --port=%d --profile=%q", *allVerbose, *allPort, *allProfile)
+	}
+
+	if err := f.ParsePartial(args, "nope"); err == nil {
+		t.Error("expected an error for an undefined flag")
+	}
+	if err := f.ParsePartial([]string{"--config"}); err == nil {
+		t.Error("expected an error for a missing value")
+	}
+	for _, args := range [][]string{{"--profile", "--config", "--config", "c.yaml"}, {"-p", "--config", "--config", "c.yaml", "--port"}} {
+		if err := f.ParsePartial(args, "config"); err != nil || *config != "c.yaml" {
+			t.Errorf("%q: got --config=%q, %v", args, *config, err)
+		}
+	}
+	if err := f.ParsePartial([]string{"--port=x"}); err == nil {
+		t.Error("expected an error for an invalid value")
+	}
+	original := []string{"--config", "b.yaml"}
+	if err := f.ParsePartial(original); err != nil || !reflect.DeepEqual(original, []string{"--config", "b.yaml"}) {
+		t.Errorf("got %v, %q", err, original)
+	}
+}
+
+func TestParseKnown(t *testing.T) {
+	f := NewFlagSet("wrapper", ContinueOnError)
+	verbose := f.BoolP("verbose", "v", false, "")
+	timeout := f.DurationP("timeout", "t", 0, "")
+	name := f.StringP("name", "n", "", "")
+
+	args := []string{"-v", "--child", "x", "--timeout", "5s", "-vx", "-n=job", "file", "--other=1", "--", "--name", "y"}
+	rest, err := f.ParseKnown(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"--child", "x", "-vx", "file", "--other=1", "--name", "y"}
+	if !reflect.DeepEqual(rest, want) {
+		t.Errorf("got rest %q want %q", rest, want)
+	}
+	if !*verbose || *timeout != 5*time.Second || *name != "job" {
+		t.Errorf("got --verbose=%v --timeout=%v --name=%q", *verbose, *timeout, *name)
+	}
+	if len(f.Args()) != 0 || !f.Parsed() {
+		t.Errorf("got args %q", f.Args())
+	}
+
+	if _, err := f.ParseKnown([]string{"--timeout"}); err == nil {
+		t.Error("expected an error for a missing value")
+	}
+	if _, err := f.ParseKnown([]string{"-vt"}); err == nil {
+		t.Error("expected an error for a missing shorthand value")
+	}
+	if _, err := f.ParseKnown([]string{"--timeout=x"}); err == nil {
+		t.Error("expected an error for an invalid value")
+	}
+	if rest, err := f.ParseKnown(nil); err != nil || len(rest) != 0 {
+		t.Errorf("got %q, %v", rest, err)
+	}
+}
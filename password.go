@@ -0,0 +1,93 @@
+package pflag
+
+import "fmt"
+
+// -- password Value
+type passwordValue string
+
+func newPasswordValue(p *string) *passwordValue {
+	*p = ""
+	return (*passwordValue)(p)
+}
+
+func (s *passwordValue) Set(val string) error {
+	*s = passwordValue(val)
+	return nil
+}
+func (s *passwordValue) Type() string {
+	return "password"
+}
+
+// String masks the password, so that it never appears in usage messages or
+// dumps of the flags.
+func (s *passwordValue) String() string {
+	if *s == "" {
+		return ""
+	}
+	return redactedValue
+}
+
+// GetPassword return the password of a flag with the given name
+func (f *FlagSet) GetPassword(name string) (string, error) {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return "", fmt.Errorf("flag accessed but not defined: %s", name)
+	}
+	v, ok := flag.Value.(*passwordValue)
+	if !ok {
+		return "", fmt.Errorf("trying to get password value of flag of type %s", flag.Value.Type())
+	}
+	return string(*v), nil
+}
+
+// PasswordVar defines a password flag with specified name and usage string.
+// The argument p points to a string variable in which to store the value of
+// the flag. A password flag is like a string flag, without default value,
+// whose value is masked in usage messages, dumps and ToArgs. If it is marked
+// promptable with MarkPromptable and not set, the password is read from the
+// terminal with echo disabled.
+func (f *FlagSet) PasswordVar(p *string, name string, usage string) {
+	f.PasswordVarP(p, name, "", usage)
+}
+
+// PasswordVarP is like PasswordVar, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) PasswordVarP(p *string, name, shorthand string, usage string) {
+	f.VarPF(newPasswordValue(p), name, shorthand, usage).Sensitive = true
+}
+
+// PasswordVar defines a password flag with specified name and usage string.
+// The argument p points to a string variable in which to store the value of the flag.
+func PasswordVar(p *string, name string, usage string) {
+	CommandLine.PasswordVarP(p, name, "", usage)
+}
+
+// PasswordVarP is like PasswordVar, but accepts a shorthand letter that can be used after a single dash.
+func PasswordVarP(p *string, name, shorthand string, usage string) {
+	CommandLine.PasswordVarP(p, name, shorthand, usage)
+}
+
+// Password defines a password flag with specified name and usage string.
+// The return value is the address of a string variable that stores the value of the flag.
+func (f *FlagSet) Password(name string, usage string) *string {
+	p := new(string)
+	f.PasswordVarP(p, name, "", usage)
+	return p
+}
+
+// PasswordP is like Password, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) PasswordP(name, shorthand string, usage string) *string {
+	p := new(string)
+	f.PasswordVarP(p, name, shorthand, usage)
+	return p
+}
+
+// Password defines a password flag with specified name and usage string.
+// The return value is the address of a string variable that stores the value of the flag.
+func Password(name string, usage string) *string {
+	return CommandLine.PasswordP(name, "", usage)
+}
+
+// PasswordP is like Password, but accepts a shorthand letter that can be used after a single dash.
+func PasswordP(name, shorthand string, usage string) *string {
+	return CommandLine.PasswordP(name, shorthand, usage)
+}
@@ -0,0 +1,56 @@
+package pflag
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPassword(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	password := f.PasswordP("password", "p", "the `secret`")
+	if err := f.Parse([]string{"-p", "hunter2"}); err != nil {
+		t.Fatal(err)
+	}
+	if *password != "hunter2" {
+		t.Errorf("got password %q", *password)
+	}
+	if v, err := f.GetPassword("password"); err != nil || v != "hunter2" {
+		t.Errorf("GetPassword returned %q, %v", v, err)
+	}
+
+	var dump bytes.Buffer
+	f.PrintSources(&dump)
+	json, err := f.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, out := range []string{f.FlagUsages(), dump.String(), string(json), strings.Join(f.ToArgs(false), " ")} {
+		if strings.Contains(out, "hunter2") {
+			t.Errorf("password leaked in %s", out)
+		}
+	}
+}
+
+func TestPasswordPrompt(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	var out bytes.Buffer
+	f.SetOutput(&out)
+	password := f.Password("password", "")
+	f.MarkPromptable("password")
+	f.stdin = strings.NewReader("hunter2\n")
+	if err := f.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	if *password != "hunter2" || out.String() != "value for --password: " {
+		t.Errorf("got password %q, prompt %q", *password, out.String())
+	}
+
+	f = NewFlagSet("test", ContinueOnError)
+	f.Password("password", "")
+	f.MarkPromptable("password")
+	f.stdin = strings.NewReader("")
+	if err := f.Parse(nil); err != nil {
+		t.Errorf("unanswered prompt for an optional flag: %v", err)
+	}
+}
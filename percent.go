@@ -0,0 +1,145 @@
+package pflag
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// -- percentage Value, stored as a fraction in [0, 1]
+type percentValue struct {
+	value *float64
+	// fraction makes numbers given without a percent sign be read as
+	// fractions, as in 0.85, rather than percentages, see SetPercentFraction.
+	fraction bool
+}
+
+func newPercentValue(val float64, p *float64) *percentValue {
+	*p = val
+	return &percentValue{value: p}
+}
+
+func (p *percentValue) Set(s string) error {
+	v, err := parsePercent(s, p.fraction)
+	if err != nil {
+		return err
+	}
+	*p.value = v
+	return nil
+}
+
+func (p *percentValue) Type() string {
+	return "percent"
+}
+
+func (p *percentValue) String() string {
+	// Rounding hides the error of the multiplication, as in 7.000000000000001%.
+	return strconv.FormatFloat(*p.value*100, 'g', 12, 64) + "%"
+}
+
+// parsePercent parses s as a percentage, as in "85%", and returns it as a
+// fraction in [0, 1]. Without a percent sign, s is read as a percentage too
+// unless fraction is true.
+func parsePercent(s string, fraction bool) (float64, error) {
+	num := strings.TrimSpace(s)
+	percent := strings.HasSuffix(num, "%")
+	if percent {
+		num = strings.TrimSpace(strings.TrimSuffix(num, "%"))
+	}
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid percentage %q", s)
+	}
+	if percent || !fraction {
+		v /= 100
+	}
+	if !(v >= 0 && v <= 1) {
+		return 0, fmt.Errorf("percentage %q is out of range, it should be between 0%% and 100%%", s)
+	}
+	return v, nil
+}
+
+func percentConv(sval string) (interface{}, error) {
+	return parsePercent(sval, false)
+}
+
+// SetPercentFraction sets whether the values of the named percentage flag
+// given without a percent sign are read as fractions, so that 0.85 means 85%,
+// rather than as percentages, so that 85 does.
+func (f *FlagSet) SetPercentFraction(name string, fraction bool) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	p, ok := flag.Value.(*percentValue)
+	if !ok {
+		return fmt.Errorf("flag %q is not a percentage", name)
+	}
+	p.fraction = fraction
+	return nil
+}
+
+// GetPercent return the fraction in [0, 1] of a percentage flag with the given name
+func (f *FlagSet) GetPercent(name string) (float64, error) {
+	val, err := f.getFlagType(name, "percent", percentConv)
+	if err != nil {
+		return 0, err
+	}
+	return val.(float64), nil
+}
+
+// PercentVar defines a percentage flag with specified name, default value, and usage string.
+// The value is given as in "85%" or "85", or "0.85" after SetPercentFraction, and stored as
+// a fraction in [0, 1]; values out of this range are rejected.
+// The argument p points to a float64 variable in which to store the value of the flag.
+func (f *FlagSet) PercentVar(p *float64, name string, value float64, usage string) {
+	f.VarP(newPercentValue(value, p), name, "", usage)
+}
+
+// PercentVarP is like PercentVar, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) PercentVarP(p *float64, name, shorthand string, value float64, usage string) {
+	f.VarP(newPercentValue(value, p), name, shorthand, usage)
+}
+
+// PercentVar defines a percentage flag with specified name, default value, and usage string.
+// The value is given as in "85%" or "85", or "0.85" after SetPercentFraction, and stored as
+// a fraction in [0, 1]; values out of this range are rejected.
+// The argument p points to a float64 variable in which to store the value of the flag.
+func PercentVar(p *float64, name string, value float64, usage string) {
+	CommandLine.VarP(newPercentValue(value, p), name, "", usage)
+}
+
+// PercentVarP is like PercentVar, but accepts a shorthand letter that can be used after a single dash.
+func PercentVarP(p *float64, name, shorthand string, value float64, usage string) {
+	CommandLine.VarP(newPercentValue(value, p), name, shorthand, usage)
+}
+
+// Percent defines a percentage flag with specified name, default value, and usage string.
+// The value is given as in "85%" or "85", or "0.85" after SetPercentFraction, and stored as
+// a fraction in [0, 1]; values out of this range are rejected.
+// The return value is the address of a float64 variable that stores the value of the flag.
+func (f *FlagSet) Percent(name string, value float64, usage string) *float64 {
+	p := new(float64)
+	f.PercentVarP(p, name, "", value, usage)
+	return p
+}
+
+// PercentP is like Percent, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) PercentP(name, shorthand string, value float64, usage string) *float64 {
+	p := new(float64)
+	f.PercentVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// Percent defines a percentage flag with specified name, default value, and usage string.
+// The value is given as in "85%" or "85", or "0.85" after SetPercentFraction, and stored as
+// a fraction in [0, 1]; values out of this range are rejected.
+// The return value is the address of a float64 variable that stores the value of the flag.
+func Percent(name string, value float64, usage string) *float64 {
+	return CommandLine.PercentP(name, "", value, usage)
+}
+
+// PercentP is like Percent, but accepts a shorthand letter that can be used after a single dash.
+func PercentP(name, shorthand string, value float64, usage string) *float64 {
+	return CommandLine.PercentP(name, shorthand, value, usage)
+}
@@ -0,0 +1,52 @@
+package pflag
+
+import (
+	"testing"
+)
+
+func TestPercent(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	threshold := f.PercentP("threshold", "t", 0.5, "alert `threshold`")
+	ratio := f.Percent("ratio", 0, "sampling ratio")
+	if err := f.SetPercentFraction("ratio", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Parse([]string{"-t", "85", "--ratio", "0.07"}); err != nil {
+		t.Fatal(err)
+	}
+	if *threshold != 0.85 || *ratio != 0.07 {
+		t.Errorf("got %v and %v, want 0.85 and 0.07", *threshold, *ratio)
+	}
+	if got := f.Lookup("ratio").Value.String(); got != "7%" {
+		t.Errorf("got string %q, want 7%%", got)
+	}
+	if v, err := f.GetPercent("ratio"); err != nil || v != 0.07 {
+		t.Errorf("GetPercent returned %v, %v", v, err)
+	}
+	if err := f.Parse([]string{"-t", "12.5 %", "--ratio", "40%"}); err != nil {
+		t.Fatal(err)
+	}
+	if *threshold != 0.125 || *ratio != 0.4 {
+		t.Errorf("got %v and %v, want 0.125 and 0.4", *threshold, *ratio)
+	}
+
+	for _, in := range []string{"", "%", "x", "101", "-1%", "NaN"} {
+		if err := f.Set("threshold", in); err == nil {
+			t.Errorf("%q: expected an error", in)
+		}
+	}
+	if err := f.Set("ratio", "1.5"); err == nil {
+		t.Error("expected an error for a fraction above 1")
+	}
+
+	if got, want := f.FlagUsages(), "      --ratio percent         sampling ratio\n  -t, --threshold threshold   alert threshold (default 50%)\n"; got != want {
+		t.Errorf("got usage %q, want %q", got, want)
+	}
+	if err := f.SetPercentFraction("threshold", true); err != nil {
+		t.Error(err)
+	}
+	f.Bool("verbose", false, "")
+	if err := f.SetPercentFraction("verbose", true); err == nil {
+		t.Error("expected an error for a flag which is not a percentage")
+	}
+}
@@ -0,0 +1,134 @@
+// Package pflagtest provides helpers for testing programs which define their
+// flags with pflag.
+//
+//	fs := pflagtest.NewFlagSet("test")
+//	port := fs.Int("port", 80, "")
+//	pflagtest.MustParse(t, fs, "--port=8080")
+//	pflagtest.AssertChanged(t, fs, "port")
+//	pflagtest.AssertUsageGolden(t, fs, "testdata/usage.golden")
+package pflagtest
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/pflag"
+)
+
+// TB is the subset of testing.TB used by the helpers, which lets them be
+// tested themselves.
+type TB interface {
+	Errorf(format string, args ...interface{})
+	Fatalf(format string, args ...interface{})
+}
+
+// UpdateEnv is the environment variable which, set to a non-empty value,
+// makes AssertUsageGolden write the golden files instead of comparing them,
+// as in PFLAGTEST_UPDATE=1 go test.
+const UpdateEnv = "PFLAGTEST_UPDATE"
+
+// helper marks the caller as a test helper, on versions of Go supporting it.
+func helper(t TB) {
+	if h, ok := t.(interface {
+		Helper()
+	}); ok {
+		h.Helper()
+	}
+}
+
+// NewFlagSet returns a FlagSet which returns errors rather than exiting and
+// discards its output.
+func NewFlagSet(name string) *pflag.FlagSet {
+	fs := pflag.NewFlagSet(name, pflag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	return fs
+}
+
+// MustParse parses args with fs and stops the test if it fails.
+func MustParse(t TB, fs *pflag.FlagSet, args ...string) {
+	helper(t)
+	if err := fs.Parse(args); err != nil {
+		t.Fatalf("parsing %q: %v", args, err)
+	}
+}
+
+// AssertParseError checks that parsing args with fs fails with the error
+// message want.
+func AssertParseError(t TB, fs *pflag.FlagSet, want string, args ...string) {
+	helper(t)
+	err := fs.Parse(args)
+	if err == nil {
+		t.Errorf("parsing %q: expected error %q", args, want)
+	} else if err.Error() != want {
+		t.Errorf("parsing %q: got error %q, want %q", args, err, want)
+	}
+}
+
+// lookup returns the flag name of fs, or stops the test if there is none.
+// It returns nil then, for TBs whose Fatalf returns.
+func lookup(t TB, fs *pflag.FlagSet, name string) *pflag.Flag {
+	helper(t)
+	flag := fs.Lookup(name)
+	if flag == nil {
+		t.Fatalf("flag --%s does not exist", name)
+	}
+	return flag
+}
+
+// AssertChanged checks that the flag name of fs has been set.
+func AssertChanged(t TB, fs *pflag.FlagSet, name string) {
+	helper(t)
+	if flag := lookup(t, fs, name); flag != nil && !flag.Changed {
+		t.Errorf("flag --%s not changed", name)
+	}
+}
+
+// AssertNotChanged checks that the flag name of fs has not been set.
+func AssertNotChanged(t TB, fs *pflag.FlagSet, name string) {
+	helper(t)
+	if flag := lookup(t, fs, name); flag != nil && flag.Changed {
+		t.Errorf("flag --%s changed to %q", name, flag.Value.String())
+	}
+}
+
+// AssertValue checks the string representation of the value of the flag
+// name of fs.
+func AssertValue(t TB, fs *pflag.FlagSet, name, want string) {
+	helper(t)
+	flag := lookup(t, fs, name)
+	if flag == nil {
+		return
+	}
+	if got := flag.Value.String(); got != want {
+		t.Errorf("flag --%s = %q, want %q", name, got, want)
+	}
+}
+
+// AssertUsageGolden compares the usage of the flags of fs, see
+// pflag.FlagSet.FlagUsages, to the content of the golden file path. The file
+// is written instead if the environment variable UpdateEnv is set.
+func AssertUsageGolden(t TB, fs *pflag.FlagSet, path string) {
+	helper(t)
+	AssertGolden(t, []byte(fs.FlagUsages()), path)
+}
+
+// AssertGolden compares got to the content of the golden file path, or
+// writes it if the environment variable UpdateEnv is set.
+func AssertGolden(t TB, got []byte, path string) {
+	helper(t)
+	if os.Getenv(UpdateEnv) != "" {
+		if err := ioutil.WriteFile(path, got, 0644); err != nil {
+			t.Fatalf("updating golden file: %v", err)
+		}
+		return
+	}
+	want, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file: %v (set %s=1 to create it)", err, UpdateEnv)
+		return
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s (set %s=1 to update it)\ngot:\n%s\nwant:\n%s", path, UpdateEnv, got, want)
+	}
+}
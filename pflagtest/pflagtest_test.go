@@ -0,0 +1,84 @@
+package pflagtest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// recorder is a TB recording the failures.
+type recorder struct {
+	errors []string
+	fatal  bool
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.Errorf(format, args...)
+	r.fatal = true
+}
+
+func TestHelpers(t *testing.T) {
+	fs := NewFlagSet("test")
+	fs.Int("port", 80, "port to listen on")
+	fs.String("host", "", "host name")
+	MustParse(t, fs, "--port=8080")
+	AssertChanged(t, fs, "port")
+	AssertNotChanged(t, fs, "host")
+	AssertValue(t, fs, "port", "8080")
+	AssertParseError(t, fs, "unknown flag: --nope", "--nope")
+	AssertUsageGolden(t, fs, "testdata/usage.golden")
+
+	r := &recorder{}
+	AssertChanged(r, fs, "host")
+	AssertValue(r, fs, "port", "80")
+	AssertParseError(r, fs, "x", "--port=1")
+	if len(r.errors) != 3 || r.fatal {
+		t.Errorf("unexpected failures %q", r.errors)
+	}
+
+	r = &recorder{}
+	MustParse(r, fs, "--port=x")
+	if !r.fatal {
+		t.Error("MustParse should stop the test")
+	}
+
+	r = &recorder{}
+	AssertChanged(r, fs, "nope")
+	AssertNotChanged(r, fs, "nope")
+	AssertValue(r, fs, "nope", "")
+	AssertGolden(r, nil, "testdata/nope.golden")
+	if len(r.errors) != 4 || !r.fatal {
+		t.Errorf("unexpected failures %q", r.errors)
+	}
+}
+
+func TestGoldenUpdate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pflagtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "out.golden")
+
+	r := &recorder{}
+	AssertGolden(r, []byte("new"), path)
+	if !r.fatal {
+		t.Error("a missing golden file should stop the test")
+	}
+
+	os.Setenv(UpdateEnv, "1")
+	AssertGolden(t, []byte("new"), path)
+	os.Unsetenv(UpdateEnv)
+	AssertGolden(t, []byte("new"), path)
+	r = &recorder{}
+	AssertGolden(r, []byte("old"), path)
+	if len(r.errors) != 1 {
+		t.Errorf("unexpected failures %q", r.errors)
+	}
+}
@@ -0,0 +1,144 @@
+package pflag
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PositionalArg is a positional argument bound to a variable, see
+// FlagSet.Positional.
+type PositionalArg struct {
+	Name     string // name of the argument in usage and error messages, e.g. "FILE"
+	Usage    string // help message
+	Value    Value  // value as set
+	optional bool
+	rest     bool // takes all the remaining arguments
+}
+
+// Optional makes the argument optional, so that Parse doesn't fail if it is
+// missing, together with the arguments following it.
+func (a *PositionalArg) Optional() *PositionalArg {
+	a.optional = true
+	return a
+}
+
+// spec returns the argument as shown in usage messages, e.g. "[FILE...]".
+func (a *PositionalArg) spec(optional bool) string {
+	spec := a.Name
+	if a.rest {
+		spec += "..."
+	}
+	if optional {
+		spec = "[" + spec + "]"
+	}
+	return spec
+}
+
+// Positional binds the next positional argument, the one following those
+// bound by the previous calls, to the variable p points to:
+//
+//	f.Positional("INPUT", &input, "file to read")
+//	f.Positional("OUTPUT", &output, "file to write").Optional()
+//
+// Once the flags are parsed, Parse sets the bound variables from the
+// remaining arguments, see Args, and fails if a required one is missing, if
+// a value is invalid or if there are arguments left over. The arguments are
+// listed in the usage message, under the flags.
+//
+// p points to a string, bool, int, int64, uint, float64 or time.Duration
+// variable, parsed like the flags of the same type, or to a []string which
+// takes all the remaining arguments and must be bound last. p may also be a
+// Value; a SliceValue takes all the remaining arguments. Positional panics
+// for other types, and if the argument can not follow the previous ones.
+func (f *FlagSet) Positional(name string, p interface{}, usage string) *PositionalArg {
+	a := &PositionalArg{Name: name, Usage: usage}
+	switch p := p.(type) {
+	case Value:
+		a.Value = p
+	case *string:
+		a.Value = newStringValue(*p, p)
+	case *bool:
+		a.Value = newBoolValue(*p, p)
+	case *int:
+		a.Value = newIntValue(*p, p)
+	case *int64:
+		a.Value = newInt64Value(*p, p)
+	case *uint:
+		a.Value = newUintValue(*p, p)
+	case *float64:
+		a.Value = newFloat64Value(*p, p)
+	case *time.Duration:
+		a.Value = newDurationValue(*p, p)
+	case *[]string:
+		a.Value = newStringArrayValue(*p, p)
+	default:
+		msg := fmt.Sprintf("positional argument %s: unsupported type %T", name, p)
+		fmt.Fprintln(f.errOut(), msg)
+		panic(msg)
+	}
+	_, a.rest = a.Value.(SliceValue)
+	if n := len(f.positionals); n > 0 && f.positionals[n-1].rest {
+		msg := fmt.Sprintf("positional argument %s follows %s, which takes all the remaining arguments", name, f.positionals[n-1].Name)
+		fmt.Fprintln(f.errOut(), msg)
+		panic(msg)
+	}
+	f.positionals = append(f.positionals, a)
+	return a
+}
+
+// bindPositionals sets the positional arguments from the remaining
+// arguments.
+func (f *FlagSet) bindPositionals() error {
+	if len(f.positionals) == 0 {
+		return nil
+	}
+	args := f.args
+	optional := false
+	for _, a := range f.positionals {
+		optional = optional || a.optional
+		if len(args) == 0 {
+			if !optional {
+				return f.failf(f.msg(MsgMissingArgument), a.Name)
+			}
+			continue
+		}
+		if a.rest {
+			if err := a.Value.(SliceValue).Replace(args); err != nil {
+				return f.failf(f.msg(MsgInvalidPositional), strings.Join(args, " "), a.Name, err)
+			}
+			args = nil
+			continue
+		}
+		if err := a.Value.Set(args[0]); err != nil {
+			return f.failf(f.msg(MsgInvalidPositional), args[0], a.Name, err)
+		}
+		args = args[1:]
+	}
+	if len(args) > 0 {
+		return f.failf(f.msg(MsgUnexpectedArgument), args[0])
+	}
+	return nil
+}
+
+// printPositionals prints the positional arguments, as part of the usage
+// message.
+func (f *FlagSet) printPositionals() {
+	if len(f.positionals) == 0 {
+		return
+	}
+	specs := make([]string, len(f.positionals))
+	optional := false
+	maxlen := 0
+	for i, a := range f.positionals {
+		optional = optional || a.optional
+		specs[i] = a.spec(optional)
+		if l := len(specs[i]); l > maxlen {
+			maxlen = l
+		}
+	}
+	fmt.Fprint(f.out(), f.msg(MsgArguments))
+	for i, a := range f.positionals {
+		fmt.Fprintf(f.out(), "  %s%s   %s\n", specs[i], strings.Repeat(" ", maxlen-len(specs[i])), a.Usage)
+	}
+}
@@ -0,0 +1,75 @@
+package pflag
+
+import (
+	"bytes"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPositional(t *testing.T) {
+	f := NewFlagSet("cp", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	verbose := f.Bool("verbose", false, "")
+	var (
+		count int
+		src   string
+		dests []string
+	)
+	f.Positional("COUNT", &count, "number of copies")
+	f.Positional("SRC", &src, "file to copy")
+	f.Positional("DEST", &dests, "destinations").Optional()
+
+	if err := f.Parse([]string{"2", "--verbose", "a.txt", "b.txt", "c.txt"}); err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 || src != "a.txt" || !reflect.DeepEqual(dests, []string{"b.txt", "c.txt"}) || !*verbose {
+		t.Errorf("got count %d, src %q and dests %v", count, src, dests)
+	}
+
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{
+		{[]string{"2"}, "missing argument SRC"},
+		{[]string{"two", "a.txt"}, `invalid argument "two" for COUNT`},
+	} {
+		f := NewFlagSet("cp", ContinueOnError)
+		f.SetOutput(ioutil.Discard)
+		f.Positional("COUNT", &count, "")
+		f.Positional("SRC", &src, "")
+		err := f.Parse(test.args)
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%q: got error %v, want %q", test.args, err, test.err)
+		}
+	}
+
+	f = NewFlagSet("rm", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.Positional("FILE", &src, "")
+	if err := f.Parse([]string{"a", "b"}); err == nil || !strings.Contains(err.Error(), `unexpected argument "b"`) {
+		t.Errorf("got error %v for an extra argument", err)
+	}
+}
+
+func TestPositionalUsage(t *testing.T) {
+	f := NewFlagSet("cp", ContinueOnError)
+	var buf bytes.Buffer
+	f.SetOutput(&buf)
+	var src, dest string
+	f.Positional("SRC", &src, "file to copy")
+	f.Positional("DEST", &dest, "destination").Optional()
+	f.usage()
+	if want := "\nArguments:\n  SRC      file to copy\n  [DEST]   destination\n"; !strings.HasSuffix(buf.String(), want) {
+		t.Errorf("got %q, want it to end with %q", buf.String(), want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for an unsupported type")
+		}
+	}()
+	f.SetErrOutput(ioutil.Discard)
+	f.Positional("N", new(complex128), "")
+}
@@ -0,0 +1,291 @@
+// Package protoflag defines the flags of a pflag.FlagSet from the fields of a
+// protocol buffers message, as commonly done for the configuration of gRPC
+// services.
+//
+// The messages are the structs generated by protoc-gen-go. They are
+// inspected with the reflect package through the protobuf struct tags of
+// their fields, so this package doesn't depend on a protobuf runtime. Scalar
+// and enum fields define flags named after the fields; fields holding
+// messages define the flags of their own fields, with the names joined with
+// ".":
+//
+//	cfg := &pb.Config{Server: &pb.Server{Port: 8080}}
+//	if err := protoflag.AddFlags(fs, cfg); err != nil {
+//		return err
+//	}
+//	fs.Parse(os.Args[1:]) // --server.port=9090 --server.tls.enabled
+//
+// Parsed values are written to the message, allocating the nested messages
+// which are nil when one of their fields is set. Repeated, map, oneof and
+// bytes fields are left out.
+package protoflag
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/spf13/pflag"
+)
+
+// enums holds the enum types registered with RegisterEnum, guarded by
+// enumsMu.
+var (
+	enumsMu sync.RWMutex
+	enums   = map[reflect.Type]enum{}
+)
+
+// enum holds the values of a registered enum type by name, and their names
+// by value.
+type enum struct {
+	values map[string]int32
+	names  map[int32]string
+}
+
+// RegisterEnum registers the names of the values of an enum type, so that
+// flags of that type are set and printed by name. zero is a value of the
+// type and values is the map generated for it, e.g.
+//
+//	protoflag.RegisterEnum(pb.Level(0), pb.Level_value)
+//
+// Flags of enum types which aren't registered take numbers. Values with
+// several names, from enums allowing aliases, are printed with the first of
+// their names in lexicographic order. RegisterEnum is safe for concurrent
+// use, but is meant to be called from init functions.
+func RegisterEnum(zero interface{}, values map[string]int32) {
+	names := make(map[int32]string, len(values))
+	for name, n := range values {
+		if prev, ok := names[n]; !ok || name < prev {
+			names[n] = name
+		}
+	}
+	enumsMu.Lock()
+	defer enumsMu.Unlock()
+	enums[reflect.TypeOf(zero)] = enum{values: values, names: names}
+}
+
+// lookupEnum returns the registered enum type t, if any.
+func lookupEnum(t reflect.Type) enum {
+	enumsMu.RLock()
+	defer enumsMu.RUnlock()
+	return enums[t]
+}
+
+// AddFlags defines flags in fs for the fields of msg, a pointer to a
+// generated message struct, see the package documentation. The current
+// values of the fields are the defaults of the flags.
+func AddFlags(fs *pflag.FlagSet, msg interface{}) error {
+	return AddFlagsWithPrefix(fs, "", msg)
+}
+
+// AddFlagsWithPrefix is like AddFlags, prefixing the names of the flags with
+// prefix and a ".", unless prefix is empty.
+func AddFlagsWithPrefix(fs *pflag.FlagSet, prefix string, msg interface{}) error {
+	root := reflect.ValueOf(msg)
+	if root.Kind() != reflect.Ptr || root.IsNil() || root.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("protoflag: %T is not a pointer to a message", msg)
+	}
+	if prefix != "" {
+		prefix += "."
+	}
+	a := adder{fs: fs, root: root}
+	return a.addFields(prefix, root.Elem().Type(), nil, map[reflect.Type]bool{})
+}
+
+type adder struct {
+	fs   *pflag.FlagSet
+	root reflect.Value
+}
+
+// addFields defines the flags of the fields of the message type t, found
+// at path from the root message. visiting holds the message types being
+// visited, to stop at recursive messages.
+func (a *adder) addFields(prefix string, t reflect.Type, path []int, visiting map[reflect.Type]bool) error {
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := field.Tag.Lookup("protobuf")
+		if !ok {
+			continue
+		}
+		name, enum := parseTag(tag)
+		if name == "" {
+			name = field.Name
+		}
+		fieldPath := append(path[:len(path):len(path)], i)
+
+		ft := field.Type
+		if ft.Kind() == reflect.Ptr && ft.Elem().Kind() == reflect.Struct {
+			if visiting[ft.Elem()] {
+				continue
+			}
+			if err := a.addFields(prefix+name+".", ft.Elem(), fieldPath, visiting); err != nil {
+				return err
+			}
+			continue
+		}
+		if ft.Kind() == reflect.Ptr {
+			// Scalars with explicit presence, like proto2 and optional fields.
+			ft = ft.Elem()
+		}
+		if !supported(ft, enum) {
+			continue
+		}
+		v := &fieldValue{root: a.root, path: fieldPath, typ: ft, enum: enum}
+		a.fs.Var(v, prefix+name, "")
+	}
+	return nil
+}
+
+// parseTag returns the name of the field described by a protobuf struct tag,
+// like "varint,1,opt,name=level,proto3,enum=pkg.Level", and whether it is an
+// enum.
+func parseTag(tag string) (name string, enum bool) {
+	for _, part := range strings.Split(tag, ",") {
+		switch {
+		case strings.HasPrefix(part, "name="):
+			name = strings.TrimPrefix(part, "name=")
+		case strings.HasPrefix(part, "enum="):
+			enum = true
+		}
+	}
+	return name, enum
+}
+
+// supported reports whether flags are defined for fields of type t.
+func supported(t reflect.Type, enum bool) bool {
+	switch t.Kind() {
+	case reflect.Int32:
+		return true
+	case reflect.Bool, reflect.Int64, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64, reflect.String:
+		return !enum
+	}
+	return false
+}
+
+// fieldValue is the pflag.Value of a field of a message.
+type fieldValue struct {
+	root reflect.Value // pointer to the root message
+	path []int         // indices of the fields leading to the field
+	typ  reflect.Type  // type of the field, without the pointer for optional fields
+	enum bool
+}
+
+// field returns the field, or the zero Value if one of the messages leading
+// to it is nil and alloc is false. With alloc, the nil messages and the
+// field itself, if it is a pointer, are allocated.
+func (v *fieldValue) field(alloc bool) reflect.Value {
+	cur := v.root
+	for _, i := range v.path {
+		if cur.Kind() == reflect.Ptr {
+			if cur.IsNil() {
+				if !alloc {
+					return reflect.Value{}
+				}
+				cur.Set(reflect.New(cur.Type().Elem()))
+			}
+			cur = cur.Elem()
+		}
+		cur = cur.Field(i)
+	}
+	if cur.Kind() == reflect.Ptr {
+		if cur.IsNil() {
+			if !alloc {
+				return reflect.Value{}
+			}
+			cur.Set(reflect.New(cur.Type().Elem()))
+		}
+		cur = cur.Elem()
+	}
+	return cur
+}
+
+func (v *fieldValue) String() string {
+	field := v.field(false)
+	if !field.IsValid() {
+		field = reflect.Zero(v.typ)
+	}
+	switch field.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(field.Bool())
+	case reflect.Int32, reflect.Int64:
+		if v.enum {
+			if name, ok := lookupEnum(v.typ).names[int32(field.Int())]; ok {
+				return name
+			}
+		}
+		return strconv.FormatInt(field.Int(), 10)
+	case reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(field.Uint(), 10)
+	case reflect.Float32:
+		return strconv.FormatFloat(field.Float(), 'g', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(field.Float(), 'g', -1, 64)
+	}
+	return field.String()
+}
+
+func (v *fieldValue) Set(s string) error {
+	var set func(field reflect.Value)
+	switch v.typ.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		set = func(field reflect.Value) { field.SetBool(b) }
+	case reflect.Int32, reflect.Int64:
+		n, ok := lookupEnum(v.typ).values[s]
+		i := int64(n)
+		if !v.enum || !ok {
+			var err error
+			if i, err = strconv.ParseInt(s, 0, v.typ.Bits()); err != nil {
+				return err
+			}
+		}
+		set = func(field reflect.Value) { field.SetInt(i) }
+	case reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 0, v.typ.Bits())
+		if err != nil {
+			return err
+		}
+		set = func(field reflect.Value) { field.SetUint(u) }
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.typ.Bits())
+		if err != nil {
+			return err
+		}
+		set = func(field reflect.Value) { field.SetFloat(f) }
+	default:
+		set = func(field reflect.Value) { field.SetString(s) }
+	}
+	set(v.field(true))
+	return nil
+}
+
+// Type returns the protobuf name of the type of the field, or the name of
+// the Go type for enums.
+func (v *fieldValue) Type() string {
+	if v.enum {
+		return v.typ.Name()
+	}
+	switch v.typ.Kind() {
+	case reflect.Float32:
+		return "float"
+	case reflect.Float64:
+		return "double"
+	}
+	return v.typ.Kind().String()
+}
+
+// DefaultArg makes boolean flags usable without an argument.
+func (v *fieldValue) DefaultArg() string {
+	if v.typ.Kind() == reflect.Bool {
+		return "true"
+	}
+	return ""
+}
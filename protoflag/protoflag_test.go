@@ -0,0 +1,80 @@
+package protoflag
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+// The types below mimic the code generated by protoc-gen-go.
+
+type Level int32
+
+var Level_value = map[string]int32{"INFO": 0, "DEBUG": 1, "WARNING": 2, "WARN": 2}
+
+type TLS struct {
+	Enabled bool   `protobuf:"varint,1,opt,name=enabled,proto3"`
+	Cert    string `protobuf:"bytes,2,opt,name=cert,proto3"`
+}
+
+type Server struct {
+	Port    uint32  `protobuf:"varint,1,opt,name=port,proto3"`
+	Tls     *TLS    `protobuf:"bytes,2,opt,name=tls,proto3"`
+	Timeout float64 `protobuf:"fixed64,3,opt,name=timeout,proto3"`
+}
+
+type Config struct {
+	state   int
+	Server  *Server  `protobuf:"bytes,1,opt,name=server,proto3"`
+	Level   Level    `protobuf:"varint,2,opt,name=level,proto3,enum=test.Level"`
+	Name    *string  `protobuf:"bytes,3,opt,name=name"`
+	Tags    []string `protobuf:"bytes,4,rep,name=tags,proto3"`
+	Data    []byte   `protobuf:"bytes,5,opt,name=data,proto3"`
+	Parent  *Config  `protobuf:"bytes,6,opt,name=parent,proto3"`
+	MaxSize int64    `protobuf:"varint,7,opt,name=max_size,json=maxSize,proto3"`
+}
+
+func TestAddFlags(t *testing.T) {
+	RegisterEnum(Level(0), Level_value)
+	cfg := &Config{Server: &Server{Port: 8080}}
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	if err := AddFlags(fs, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	fs.VisitAll(func(flag *pflag.Flag) {
+		names = append(names, flag.Name+":"+flag.Value.Type()+"="+flag.DefValue)
+	})
+	want := "[level:Level=INFO max_size:int64=0 name:string= server.port:uint32=8080 server.timeout:double=0 server.tls.cert:string= server.tls.enabled:bool=false]"
+	if got := fmt.Sprint(names); got != want {
+		t.Errorf("got flags %s, want %s", got, want)
+	}
+
+	err := fs.Parse([]string{"--server.port=9090", "--server.tls.enabled", "--level=DEBUG", "--name", "api", "--max_size=10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server.Port != 9090 || cfg.Server.Tls == nil || !cfg.Server.Tls.Enabled || cfg.Level != 1 || cfg.Name == nil || *cfg.Name != "api" || cfg.MaxSize != 10 {
+		t.Errorf("unexpected message %+v %+v", cfg, cfg.Server)
+	}
+	if got := fs.Lookup("level").Value.String(); got != "DEBUG" {
+		t.Errorf("level = %q, want DEBUG", got)
+	}
+	if err := fs.Set("level", "WARNING"); err != nil || cfg.Level != 2 {
+		t.Errorf("got level %d, %v", cfg.Level, err)
+	}
+	for i := 0; i < 10; i++ {
+		if got := fs.Lookup("level").Value.String(); got != "WARN" {
+			t.Fatalf("level = %q, want the first alias WARN", got)
+		}
+	}
+	if err := fs.Set("server.port", "-1"); err == nil {
+		t.Error("expected an error for a negative port")
+	}
+
+	if err := AddFlags(fs, Config{}); err == nil {
+		t.Error("expected an error for a message which isn't a pointer")
+	}
+}
@@ -0,0 +1,182 @@
+package pflag
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// boundedValue is implemented by the Values which only accept values in a
+// range, for the range to be shown in usage messages.
+type boundedValue interface {
+	bounds() (min, max string)
+}
+
+// -- int Value in [min, max]
+type intRangeValue struct {
+	value    *int
+	min, max int
+}
+
+func newIntRangeValue(val int, p *int, min, max int) *intRangeValue {
+	*p = val
+	return &intRangeValue{value: p, min: min, max: max}
+}
+
+func (i *intRangeValue) Set(s string) error {
+	v, err := strconv.ParseInt(s, 0, 64)
+	if err != nil {
+		return err
+	}
+	if v < int64(i.min) || v > int64(i.max) {
+		return fmt.Errorf("%d is out of range, it should be between %d and %d", v, i.min, i.max)
+	}
+	*i.value = int(v)
+	return nil
+}
+
+func (i *intRangeValue) Type() string {
+	return "int"
+}
+
+func (i *intRangeValue) String() string { return strconv.Itoa(*i.value) }
+
+func (i *intRangeValue) bounds() (string, string) {
+	return strconv.Itoa(i.min), strconv.Itoa(i.max)
+}
+
+// -- float64 Value in [min, max]
+type float64RangeValue struct {
+	value    *float64
+	min, max float64
+}
+
+func newFloat64RangeValue(val float64, p *float64, min, max float64) *float64RangeValue {
+	*p = val
+	return &float64RangeValue{value: p, min: min, max: max}
+}
+
+func (f *float64RangeValue) Set(s string) error {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	if !(v >= f.min && v <= f.max) {
+		return fmt.Errorf("%v is out of range, it should be between %v and %v", v, f.min, f.max)
+	}
+	*f.value = v
+	return nil
+}
+
+func (f *float64RangeValue) Type() string {
+	return "float64"
+}
+
+func (f *float64RangeValue) String() string { return strconv.FormatFloat(*f.value, 'g', -1, 64) }
+
+func (f *float64RangeValue) bounds() (string, string) {
+	return strconv.FormatFloat(f.min, 'g', -1, 64), strconv.FormatFloat(f.max, 'g', -1, 64)
+}
+
+// IntRangeVar defines an int flag with specified name, default value, bounds, and usage string.
+// Values out of [min, max] are rejected, and the range is shown in usage messages.
+// The argument p points to an int variable in which to store the value of the flag.
+func (f *FlagSet) IntRangeVar(p *int, name string, value, min, max int, usage string) {
+	f.VarP(newIntRangeValue(value, p, min, max), name, "", usage)
+}
+
+// IntRangeVarP is like IntRangeVar, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) IntRangeVarP(p *int, name, shorthand string, value, min, max int, usage string) {
+	f.VarP(newIntRangeValue(value, p, min, max), name, shorthand, usage)
+}
+
+// IntRangeVar defines an int flag with specified name, default value, bounds, and usage string.
+// Values out of [min, max] are rejected, and the range is shown in usage messages.
+// The argument p points to an int variable in which to store the value of the flag.
+func IntRangeVar(p *int, name string, value, min, max int, usage string) {
+	CommandLine.VarP(newIntRangeValue(value, p, min, max), name, "", usage)
+}
+
+// IntRangeVarP is like IntRangeVar, but accepts a shorthand letter that can be used after a single dash.
+func IntRangeVarP(p *int, name, shorthand string, value, min, max int, usage string) {
+	CommandLine.VarP(newIntRangeValue(value, p, min, max), name, shorthand, usage)
+}
+
+// IntRange defines an int flag with specified name, default value, bounds, and usage string.
+// Values out of [min, max] are rejected, and the range is shown in usage messages.
+// The return value is the address of an int variable that stores the value of the flag.
+func (f *FlagSet) IntRange(name string, value, min, max int, usage string) *int {
+	p := new(int)
+	f.IntRangeVarP(p, name, "", value, min, max, usage)
+	return p
+}
+
+// IntRangeP is like IntRange, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) IntRangeP(name, shorthand string, value, min, max int, usage string) *int {
+	p := new(int)
+	f.IntRangeVarP(p, name, shorthand, value, min, max, usage)
+	return p
+}
+
+// IntRange defines an int flag with specified name, default value, bounds, and usage string.
+// Values out of [min, max] are rejected, and the range is shown in usage messages.
+// The return value is the address of an int variable that stores the value of the flag.
+func IntRange(name string, value, min, max int, usage string) *int {
+	return CommandLine.IntRangeP(name, "", value, min, max, usage)
+}
+
+// IntRangeP is like IntRange, but accepts a shorthand letter that can be used after a single dash.
+func IntRangeP(name, shorthand string, value, min, max int, usage string) *int {
+	return CommandLine.IntRangeP(name, shorthand, value, min, max, usage)
+}
+
+// Float64RangeVar defines a float64 flag with specified name, default value, bounds, and usage string.
+// Values out of [min, max] are rejected, and the range is shown in usage messages.
+// The argument p points to a float64 variable in which to store the value of the flag.
+func (f *FlagSet) Float64RangeVar(p *float64, name string, value, min, max float64, usage string) {
+	f.VarP(newFloat64RangeValue(value, p, min, max), name, "", usage)
+}
+
+// Float64RangeVarP is like Float64RangeVar, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) Float64RangeVarP(p *float64, name, shorthand string, value, min, max float64, usage string) {
+	f.VarP(newFloat64RangeValue(value, p, min, max), name, shorthand, usage)
+}
+
+// Float64RangeVar defines a float64 flag with specified name, default value, bounds, and usage string.
+// Values out of [min, max] are rejected, and the range is shown in usage messages.
+// The argument p points to a float64 variable in which to store the value of the flag.
+func Float64RangeVar(p *float64, name string, value, min, max float64, usage string) {
+	CommandLine.VarP(newFloat64RangeValue(value, p, min, max), name, "", usage)
+}
+
+// Float64RangeVarP is like Float64RangeVar, but accepts a shorthand letter that can be used after a single dash.
+func Float64RangeVarP(p *float64, name, shorthand string, value, min, max float64, usage string) {
+	CommandLine.VarP(newFloat64RangeValue(value, p, min, max), name, shorthand, usage)
+}
+
+// Float64Range defines a float64 flag with specified name, default value, bounds, and usage string.
+// Values out of [min, max] are rejected, and the range is shown in usage messages.
+// The return value is the address of a float64 variable that stores the value of the flag.
+func (f *FlagSet) Float64Range(name string, value, min, max float64, usage string) *float64 {
+	p := new(float64)
+	f.Float64RangeVarP(p, name, "", value, min, max, usage)
+	return p
+}
+
+// Float64RangeP is like Float64Range, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) Float64RangeP(name, shorthand string, value, min, max float64, usage string) *float64 {
+	p := new(float64)
+	f.Float64RangeVarP(p, name, shorthand, value, min, max, usage)
+	return p
+}
+
+// Float64Range defines a float64 flag with specified name, default value, bounds, and usage string.
+// Values out of [min, max] are rejected, and the range is shown in usage messages.
+// The return value is the address of a float64 variable that stores the value of the flag.
+func Float64Range(name string, value, min, max float64, usage string) *float64 {
+	return CommandLine.Float64RangeP(name, "", value, min, max, usage)
+}
+
+// Float64RangeP is like Float64Range, but accepts a shorthand letter that can be used after a single dash.
+func Float64RangeP(name, shorthand string, value, min, max float64, usage string) *float64 {
+	return CommandLine.Float64RangeP(name, shorthand, value, min, max, usage)
+}
@@ -0,0 +1,40 @@
+package pflag
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestIntRange(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	level := f.IntRangeP("level", "l", 3, 1, 9, "compression level")
+	ratio := f.Float64Range("ratio", 0.5, 0, 1, "sampling ratio")
+
+	if err := f.Parse([]string{"-l", "9", "--ratio=0.25"}); err != nil {
+		t.Fatal(err)
+	}
+	if *level != 9 || *ratio != 0.25 {
+		t.Errorf("got level %d and ratio %v", *level, *ratio)
+	}
+	if got, err := f.GetInt("level"); err != nil || got != 9 {
+		t.Errorf("GetInt returned %d, %v", got, err)
+	}
+
+	for _, args := range [][]string{{"--level=10"}, {"--level=0"}, {"--ratio=1.5"}, {"--ratio=NaN"}, {"--level=x"}} {
+		if err := f.Parse(args); err == nil {
+			t.Errorf("%q: expected an error", args)
+		}
+	}
+	if *level != 9 || *ratio != 0.25 {
+		t.Errorf("rejected values were stored: level %d and ratio %v", *level, *ratio)
+	}
+
+	usages := f.FlagUsages()
+	for _, want := range []string{"compression level (default 3) (between 1 and 9)", "sampling ratio (default 0.5) (between 0 and 1)"} {
+		if !strings.Contains(usages, want) {
+			t.Errorf("got usage %q, want it to contain %q", usages, want)
+		}
+	}
+}
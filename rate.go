@@ -0,0 +1,179 @@
+package pflag
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EventRate is a number of events per interval of time, as given to flags
+// defined with Rate, like "100/s" or "5/min". The zero EventRate, written
+// "0", sets no rate.
+type EventRate struct {
+	Count    int64
+	Interval time.Duration
+}
+
+// rateUnits are the names of the interval units of rates, the first of each
+// being the one used by EventRate.String.
+var rateUnits = []struct {
+	names []string
+	unit  time.Duration
+}{
+	{[]string{"ms", "msec", "millisecond"}, time.Millisecond},
+	{[]string{"s", "sec", "second"}, time.Second},
+	{[]string{"min", "m", "minute"}, time.Minute},
+	{[]string{"h", "hour"}, time.Hour},
+	{[]string{"d", "day"}, 24 * time.Hour},
+}
+
+// PerSecond returns the number of events per second of r, e.g. to be
+// converted to a rate.Limit of golang.org/x/time/rate.
+func (r EventRate) PerSecond() float64 {
+	if r.Interval == 0 {
+		return 0
+	}
+	return float64(r.Count) / r.Interval.Seconds()
+}
+
+// Every returns the time between two events of r, or 0 if r is zero.
+func (r EventRate) Every() time.Duration {
+	if r.Count == 0 {
+		return 0
+	}
+	return r.Interval / time.Duration(r.Count)
+}
+
+// String returns r in the form parsed by flags defined with Rate.
+func (r EventRate) String() string {
+	if r.Count == 0 && r.Interval == 0 {
+		return "0"
+	}
+	count := strconv.FormatInt(r.Count, 10)
+	for _, u := range rateUnits {
+		if r.Interval == u.unit {
+			return count + "/" + u.names[0]
+		}
+	}
+	return count + "/" + r.Interval.String()
+}
+
+// parseRate parses a rate as a count of events, a slash and an interval,
+// which is either the name of a unit like "s" or "min", or a duration like
+// "5m".
+func parseRate(s string) (EventRate, error) {
+	s = strings.TrimSpace(s)
+	if s == "0" {
+		return EventRate{}, nil
+	}
+	i := strings.IndexByte(s, '/')
+	if i < 0 {
+		return EventRate{}, fmt.Errorf("rate %q should be a count per interval, like 100/s", s)
+	}
+	count, err := strconv.ParseInt(s[:i], 10, 64)
+	if err != nil || count < 0 {
+		return EventRate{}, fmt.Errorf("invalid count of events %q in rate %q", s[:i], s)
+	}
+	interval := s[i+1:]
+	for _, u := range rateUnits {
+		for _, name := range u.names {
+			if interval == name {
+				return EventRate{Count: count, Interval: u.unit}, nil
+			}
+		}
+	}
+	d, err := time.ParseDuration(interval)
+	if err != nil || d <= 0 {
+		return EventRate{}, fmt.Errorf("invalid interval %q in rate %q", interval, s)
+	}
+	return EventRate{Count: count, Interval: d}, nil
+}
+
+// -- EventRate Value
+type rateValue EventRate
+
+func newRateValue(val EventRate, p *EventRate) *rateValue {
+	*p = val
+	return (*rateValue)(p)
+}
+
+func (r *rateValue) Set(s string) error {
+	v, err := parseRate(s)
+	if err != nil {
+		return err
+	}
+	*r = rateValue(v)
+	return nil
+}
+
+func (r *rateValue) Type() string {
+	return "rate"
+}
+
+func (r *rateValue) String() string { return EventRate(*r).String() }
+
+func rateConv(sval string) (interface{}, error) {
+	return parseRate(sval)
+}
+
+// GetRate return the EventRate value of a flag with the given name
+func (f *FlagSet) GetRate(name string) (EventRate, error) {
+	val, err := f.getFlagType(name, "rate", rateConv)
+	if err != nil {
+		return EventRate{}, err
+	}
+	return val.(EventRate), nil
+}
+
+// RateVar defines an EventRate flag with specified name, default value, and usage string.
+// The value is given as a count per interval, like "100/s", "5/min" or "10/5m".
+// The argument p points to an EventRate variable in which to store the value of the flag.
+func (f *FlagSet) RateVar(p *EventRate, name string, value EventRate, usage string) {
+	f.VarP(newRateValue(value, p), name, "", usage)
+}
+
+// RateVarP is like RateVar, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) RateVarP(p *EventRate, name, shorthand string, value EventRate, usage string) {
+	f.VarP(newRateValue(value, p), name, shorthand, usage)
+}
+
+// RateVar defines an EventRate flag with specified name, default value, and usage string.
+// The value is given as a count per interval, like "100/s", "5/min" or "10/5m".
+// The argument p points to an EventRate variable in which to store the value of the flag.
+func RateVar(p *EventRate, name string, value EventRate, usage string) {
+	CommandLine.VarP(newRateValue(value, p), name, "", usage)
+}
+
+// RateVarP is like RateVar, but accepts a shorthand letter that can be used after a single dash.
+func RateVarP(p *EventRate, name, shorthand string, value EventRate, usage string) {
+	CommandLine.VarP(newRateValue(value, p), name, shorthand, usage)
+}
+
+// Rate defines an EventRate flag with specified name, default value, and usage string.
+// The value is given as a count per interval, like "100/s", "5/min" or "10/5m".
+// The return value is the address of an EventRate variable that stores the value of the flag.
+func (f *FlagSet) Rate(name string, value EventRate, usage string) *EventRate {
+	p := new(EventRate)
+	f.RateVarP(p, name, "", value, usage)
+	return p
+}
+
+// RateP is like Rate, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) RateP(name, shorthand string, value EventRate, usage string) *EventRate {
+	p := new(EventRate)
+	f.RateVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// Rate defines an EventRate flag with specified name, default value, and usage string.
+// The value is given as a count per interval, like "100/s", "5/min" or "10/5m".
+// The return value is the address of an EventRate variable that stores the value of the flag.
+func Rate(name string, value EventRate, usage string) *EventRate {
+	return CommandLine.RateP(name, "", value, usage)
+}
+
+// RateP is like Rate, but accepts a shorthand letter that can be used after a single dash.
+func RateP(name, shorthand string, value EventRate, usage string) *EventRate {
+	return CommandLine.RateP(name, shorthand, value, usage)
+}
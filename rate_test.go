@@ -0,0 +1,57 @@
+package pflag
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want EventRate
+		str  string
+	}{
+		{"0", EventRate{}, "0"},
+		{"100/s", EventRate{100, time.Second}, "100/s"},
+		{"5/min", EventRate{5, time.Minute}, "5/min"},
+		{"5/m", EventRate{5, time.Minute}, "5/min"},
+		{"10/5m", EventRate{10, 5 * time.Minute}, "10/5m0s"},
+		{"1/day", EventRate{1, 24 * time.Hour}, "1/d"},
+	}
+	for _, test := range tests {
+		f := NewFlagSet("test", ContinueOnError)
+		r := f.RateP("limit", "l", EventRate{}, "")
+		if err := f.Parse([]string{"-l", test.in}); err != nil {
+			t.Errorf("%s: %v", test.in, err)
+			continue
+		}
+		if *r != test.want {
+			t.Errorf("%s: got %+v, want %+v", test.in, *r, test.want)
+		}
+		if got := f.Lookup("limit").Value.String(); got != test.str {
+			t.Errorf("%s: got string %q, want %q", test.in, got, test.str)
+		}
+		if v, err := f.GetRate("limit"); err != nil || v != test.want {
+			t.Errorf("%s: GetRate returned %+v, %v", test.in, v, err)
+		}
+	}
+
+	for _, in := range []string{"", "100", "x/s", "-1/s", "10/fortnight", "10/0s"} {
+		f := NewFlagSet("test", ContinueOnError)
+		f.Rate("limit", EventRate{}, "")
+		if err := f.Set("limit", in); err == nil {
+			t.Errorf("%q: expected an error", in)
+		}
+	}
+
+	r := EventRate{300, time.Minute}
+	if got := r.PerSecond(); got != 5 {
+		t.Errorf("got %v per second, want 5", got)
+	}
+	if got := r.Every(); got != 200*time.Millisecond {
+		t.Errorf("got one every %v, want 200ms", got)
+	}
+	if (EventRate{}).PerSecond() != 0 || (EventRate{}).Every() != 0 {
+		t.Error("the zero rate should have no events")
+	}
+}
@@ -0,0 +1,120 @@
+package pflag
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// flagState is the state of a flag saved by Reload to roll back failed
+// reloads.
+type flagState struct {
+	value   string
+	source  ValueSource
+	changed bool
+}
+
+// Reload re-applies a configuration source to the FlagSet, e.g.
+//
+//	changed, err := flags.Reload(func(f *FlagSet) error {
+//		return f.ParseFile("/etc/app.conf")
+//	})
+//
+// Flags whose value came from a configuration are first restored to their
+// default value, so that settings removed from the configuration are undone
+// and slices don't accumulate, then load is called. Flags set from other
+// sources are only changed by load if it respects their precedence, as
+// ParseFile and the config package do. Reload returns the names of the flags
+// whose value changed, in VisitAll order, after calling their OnChanged
+// callbacks once with the value they had before the reload. If load fails,
+// every flag is rolled back to the state it had before Reload was called and
+// no callback is called.
+func (f *FlagSet) Reload(load func(f *FlagSet) error) ([]string, error) {
+	f.muteChanged = true
+	defer func() { f.muteChanged = false }()
+	saved := make(map[*Flag]flagState, len(f.orderedFormal))
+	for _, flag := range f.orderedFormal {
+		saved[flag] = flagState{flag.Value.String(), flag.source, flag.Changed}
+	}
+	for _, flag := range f.orderedFormal {
+		if flag.source == SourceConfig {
+			if err := f.resetFlag(flag); err != nil {
+				f.rollback(saved)
+				return nil, err
+			}
+		}
+	}
+	if err := load(f); err != nil {
+		f.rollback(saved)
+		return nil, err
+	}
+
+	f.muteChanged = false
+	var changed []string
+	for _, flag := range f.sortedFormalFlags() {
+		if value := flag.Value.String(); value != saved[flag].value {
+			changed = append(changed, flag.Name)
+			f.notifyChanged(flag, saved[flag].value, value)
+		}
+	}
+	return changed, nil
+}
+
+// rollback restores the flags to the state saved by Reload.
+func (f *FlagSet) rollback(saved map[*Flag]flagState) {
+	for flag, state := range saved {
+		if flag.Value.String() == state.value && flag.source == state.source {
+			continue
+		}
+		f.resetFlag(flag)
+		restoreValue(flag, state.value)
+		if state.changed {
+			if f.actual == nil {
+				f.actual = make(map[NormalizedName]*Flag)
+			}
+			f.actual[f.normalizeFlagName(flag.Name)] = flag
+			f.orderedActual = append(f.orderedActual, flag)
+			f.sortedActual = nil
+			flag.Changed = true
+		}
+		flag.source = state.source
+	}
+}
+
+// Reload re-applies a configuration source like FlagSet.Reload, while holding
+// the lock for writing.
+func (s *SyncFlagSet) Reload(load func(f *FlagSet) error) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fs.Reload(load)
+}
+
+// WatchFile checks the file at path every interval and calls reload when its
+// modification time or size changed, typically to call Reload on a
+// SyncFlagSet. reload is called from a separate goroutine, until the
+// returned stop function is called.
+func WatchFile(path string, interval time.Duration, reload func()) (stop func()) {
+	done := make(chan struct{})
+	last, _ := os.Stat(path)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+			info, err := os.Stat(path)
+			if err != nil {
+				continue
+			}
+			if last == nil || !info.ModTime().Equal(last.ModTime()) || info.Size() != last.Size() {
+				last = info
+				reload()
+			}
+		}
+	}()
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
@@ -0,0 +1,90 @@
+package pflag
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReload(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	level := f.String("level", "info", "")
+	tags := f.StringSlice("tags", nil, "")
+	port := f.Int("port", 80, "")
+	if err := f.Parse([]string{"--port=8080"}); err != nil {
+		t.Fatal(err)
+	}
+
+	path := writeTempFile(t, "app.conf", "level = debug\ntags = a\ntags = b\nport = 1\n")
+	defer os.RemoveAll(filepath.Dir(path))
+	load := func(f *FlagSet) error { return f.ParseFile(path) }
+	var calls []string
+	f.OnChanged("tags", func(old, new string) { calls = append(calls, old+"->"+new) })
+
+	changed, err := f.Reload(load)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"level", "tags"}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("got changed %v want %v", changed, want)
+	}
+	if *level != "debug" || !reflect.DeepEqual(*tags, []string{"a", "b"}) || *port != 8080 {
+		t.Errorf("unexpected values %q %v %d", *level, *tags, *port)
+	}
+
+	if err := ioutil.WriteFile(path, []byte("tags = c\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	changed, err = f.Reload(load)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"level", "tags"}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("got changed %v want %v", changed, want)
+	}
+	if *level != "info" || !reflect.DeepEqual(*tags, []string{"c"}) {
+		t.Errorf("unexpected values %q %v", *level, *tags)
+	}
+	if want := []string{"[]->[a,b]", "[a,b]->[c]"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("got calls %v want %v", calls, want)
+	}
+	if f.Changed("level") {
+		t.Error("level removed from the config should no longer be changed")
+	}
+
+	fail := errors.New("fail")
+	_, err = f.Reload(func(f *FlagSet) error {
+		f.SetWithSource("level", "error", SourceConfig)
+		return fail
+	})
+	if err != fail {
+		t.Fatalf("got error %v want %v", err, fail)
+	}
+	if *level != "info" || !reflect.DeepEqual(*tags, []string{"c"}) || f.Lookup("tags").Source() != SourceConfig {
+		t.Errorf("failed reload not rolled back: %q %v", *level, *tags)
+	}
+	if len(calls) != 2 {
+		t.Errorf("callbacks called by a failed reload: %v", calls)
+	}
+}
+
+func TestWatchFile(t *testing.T) {
+	path := writeTempFile(t, "app.conf", "")
+	defer os.RemoveAll(filepath.Dir(path))
+	reloaded := make(chan struct{}, 1)
+	stop := WatchFile(path, 10*time.Millisecond, func() { reloaded <- struct{}{} })
+	defer stop()
+
+	if err := ioutil.WriteFile(path, []byte("level = debug\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-reloaded:
+	case <-time.After(5 * time.Second):
+		t.Fatal("reload not called after the file changed")
+	}
+}
@@ -0,0 +1,117 @@
+package pflag
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// MarkRequired makes Parse fail if the named flag was not set, neither in
+// the arguments nor from another source like the environment, unless the
+// FlagSet prompts for it, see SetPromptMissing.
+func (f *FlagSet) MarkRequired(name string) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	flag.Required = true
+	return nil
+}
+
+// SetPromptMissing sets whether Parse prompts for the values of the required
+// flags which were not set, when the standard input is a terminal. The
+// prompt, e.g. "value for --region: ", is printed to the output of the
+// FlagSet and the answer applied with Set.
+func (f *FlagSet) SetPromptMissing(enabled bool) {
+	f.promptMissing = enabled
+}
+
+// MarkPromptable makes Parse prompt for the value of the named flag if it
+// was not set and the standard input is a terminal, whether it is required
+// or not. The input is not echoed for password flags.
+func (f *FlagSet) MarkPromptable(name string) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	flag.promptable = true
+	return nil
+}
+
+// promptInput returns the reader answers to prompts are read from, or nil if
+// it is not interactive, and the terminal it refers to, if any.
+func (f *FlagSet) promptInput() (io.Reader, *os.File) {
+	in := f.stdin
+	if in == nil {
+		in = os.Stdin
+	}
+	file, ok := in.(*os.File)
+	if !ok {
+		return in, nil
+	}
+	if !isTerminal(file.Fd()) {
+		return nil, nil
+	}
+	return in, file
+}
+
+// checkRequired prompts for the promptable flags which were not set, and
+// prompts for or fails on the required ones.
+func (f *FlagSet) checkRequired() error {
+	var in *bufio.Reader
+	var tty *os.File
+	for _, flag := range f.orderedFormal {
+		if flag.source != SourceDefault || !flag.Required && !flag.promptable {
+			continue
+		}
+		prompt := flag.promptable || f.promptMissing
+		if prompt && in == nil {
+			r, file := f.promptInput()
+			if r != nil {
+				in, tty = bufio.NewReader(r), file
+			}
+		}
+		if !prompt || in == nil {
+			if flag.Required {
+				return f.failf(f.msg(MsgRequired), flag.Name)
+			}
+			continue
+		}
+
+		answer, err := f.prompt(flag, in, tty)
+		if answer == "" {
+			if err != nil && err != io.EOF {
+				return err
+			}
+			if flag.Required {
+				return f.failf(f.msg(MsgRequired), flag.Name)
+			}
+			continue
+		}
+		if err := f.Set(flag.Name, answer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prompt asks for the value of flag and reads the answer from in. The echo of
+// tty, the terminal in reads from if any, is disabled for passwords.
+func (f *FlagSet) prompt(flag *Flag, in *bufio.Reader, tty *os.File) (string, error) {
+	fmt.Fprintf(f.out(), f.msg(MsgPrompt), flag.Name)
+	if _, ok := flag.Value.(*passwordValue); ok && tty != nil {
+		restore, err := disableEcho(tty.Fd())
+		if err != nil {
+			return "", err
+		}
+		defer func() {
+			restore()
+			// The newline typed by the user was not echoed either.
+			fmt.Fprintln(f.out())
+		}()
+	}
+	answer, err := in.ReadString('\n')
+	return strings.TrimRight(answer, "\r\n"), err
+}
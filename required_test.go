@@ -0,0 +1,51 @@
+package pflag
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestRequired(t *testing.T) {
+	newFlagSet := func() (*FlagSet, *string, *int) {
+		f := NewFlagSet("test", ContinueOnError)
+		f.SetOutput(ioutil.Discard)
+		region := f.String("region", "", "")
+		port := f.Int("port", 0, "")
+		f.MarkRequired("region")
+		f.MarkRequired("port")
+		return f, region, port
+	}
+
+	f, _, _ := newFlagSet()
+	if err := f.MarkRequired("missing"); err == nil {
+		t.Error("expected an error for a missing flag")
+	}
+	want := "required flag --port not set"
+	if err := f.Parse([]string{"--region=eu"}); err == nil || err.Error() != want {
+		t.Errorf("got error %v want %s", err, want)
+	}
+
+	f, region, port := newFlagSet()
+	var out bytes.Buffer
+	f.SetOutput(&out)
+	f.SetPromptMissing(true)
+	f.stdin = strings.NewReader("eu-west\n8080\n")
+	if err := f.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	if *region != "eu-west" || *port != 8080 {
+		t.Errorf("got %q %d", *region, *port)
+	}
+	if want := "value for --region: value for --port: "; out.String() != want {
+		t.Errorf("got prompts %q want %q", out.String(), want)
+	}
+
+	f, _, _ = newFlagSet()
+	f.SetPromptMissing(true)
+	f.stdin = strings.NewReader("eu-west\n")
+	if err := f.Parse(nil); err == nil || err.Error() != want {
+		t.Errorf("got error %v want %s", err, want)
+	}
+}
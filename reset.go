@@ -0,0 +1,201 @@
+package pflag
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// resetValue restores the value of flag to its default value, DefValue.
+func resetValue(flag *Flag) error {
+	return restoreValue(flag, flag.DefValue)
+}
+
+// restoreValue replaces the value of flag by the one def, previously returned
+// by flag.Value.String(), represents. Unlike Set, it does not append to
+// slices.
+func restoreValue(flag *Flag, def string) error {
+	switch v := unwrapValue(flag.Value).(type) {
+	case *boolSliceValue:
+		val, err := boolSliceConv(def)
+		if err != nil {
+			return err
+		}
+		*v.value, v.changed = val.([]bool), false
+		return nil
+	case *intSliceValue:
+		val, err := intSliceConv(def)
+		if err != nil {
+			return err
+		}
+		*v.value, v.changed = val.([]int), false
+		return nil
+	case *uintSliceValue:
+		val, err := uintSliceConv(def)
+		if err != nil {
+			return err
+		}
+		*v.value, v.changed = val.([]uint), false
+		return nil
+	case *ipSliceValue:
+		val, err := ipSliceConv(def)
+		if err != nil {
+			return err
+		}
+		*v.value, v.changed = val.([]net.IP), false
+		return nil
+	case *stringSliceValue:
+		val, err := stringSliceConv(def)
+		if err != nil {
+			return err
+		}
+		*v.value, v.changed = val.([]string), false
+		return nil
+	case *stringArrayValue:
+		val, err := stringArrayConv(def)
+		if err != nil {
+			return err
+		}
+		*v.value, v.changed = val.([]string), false
+		return nil
+	case *tlsCipherSuitesValue:
+		val, err := tlsCipherSuitesConv(def)
+		if err != nil {
+			return err
+		}
+		*v.value, v.changed = val.([]uint16), false
+		return nil
+	case *headerValue:
+		val, err := headerConv(def)
+		if err != nil {
+			return err
+		}
+		*v.value, v.changed = val.(http.Header), false
+		return nil
+	case *bitmaskValue:
+		val, err := v.parse(def)
+		if err != nil {
+			return err
+		}
+		*v.value, v.changed = val, false
+		return nil
+	case *stringToStringValue:
+		val, err := stringToStringConv(def)
+		if err != nil {
+			return err
+		}
+		*v.value, v.changed = val.(map[string]string), false
+		return nil
+	case *ipValue:
+		if def == "<nil>" {
+			*v = nil
+			return nil
+		}
+	case *hardwareAddrValue:
+		if def == "" {
+			*v = nil
+			return nil
+		}
+	case *tlsVersionValue:
+		if def == "" {
+			*v = 0
+			return nil
+		}
+	case *languageValue:
+		if def == "" {
+			*v = ""
+			return nil
+		}
+	case *signalValue:
+		if def == "" {
+			*v.value = nil
+			return nil
+		}
+	case *ipMaskValue:
+		if def == "<nil>" {
+			*v = nil
+			return nil
+		}
+	case *ipNetValue:
+		if def == "<nil>" {
+			*v = ipNetValue{}
+			return nil
+		}
+	case SliceValue:
+		// Other slices are expected to be formatted like the built-in
+		// ones, as "[a,b]".
+		elems := []string{}
+		if def = strings.TrimSuffix(strings.TrimPrefix(def, "["), "]"); def != "" {
+			var err error
+			if elems, err = readAsCSV(def); err != nil {
+				return err
+			}
+		}
+		return v.Replace(elems)
+	}
+	return flag.Value.Set(def)
+}
+
+// resetFlag restores flag to its default value and marks it as not changed.
+func (f *FlagSet) resetFlag(flag *Flag) error {
+	if err := resetValue(flag); err != nil {
+		return fmt.Errorf("can not reset flag %q to %q: %v", flag.Name, flag.DefValue, err)
+	}
+	flag.Changed = false
+	flag.source = SourceDefault
+	name := NormalizedName(flag.Name)
+	if _, ok := f.actual[name]; ok {
+		delete(f.actual, name)
+		f.orderedActual = removeFlag(f.orderedActual, flag)
+		f.sortedActual = nil
+	}
+	return nil
+}
+
+// Reset restores every flag to its default value and clears the state left
+// by parsing, like the remaining arguments and the Changed status of the
+// flags, so that the FlagSet can be parsed again as if it was new. Values of
+// custom types are reset by calling Set with the flag's DefValue, or Replace
+// for SliceValues.
+func (f *FlagSet) Reset() error {
+	var err error
+	for _, flag := range f.orderedFormal {
+		if e := f.resetFlag(flag); e != nil && err == nil {
+			err = e
+		}
+		flag.occurrences = 0
+	}
+	f.parsed = false
+	f.args = nil
+	f.argsLenAtDash = -1
+	f.occurrences = f.occurrences[:0]
+	f.argsParsed = 0
+	f.stdinFlag = nil
+	f.command = nil
+	return err
+}
+
+// ResetFlag restores the named flag to its default value and clears the
+// state left by parsing it, like its Changed status, leaving the other flags
+// and the remaining arguments alone, e.g. for test harnesses tweaking one
+// flag between runs. Values of custom types are reset as by Reset.
+func (f *FlagSet) ResetFlag(name string) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	err := f.resetFlag(flag)
+	flag.occurrences = 0
+	occurrences := f.occurrences[:0]
+	for _, o := range f.occurrences {
+		if o.flag != flag {
+			occurrences = append(occurrences, o)
+		}
+	}
+	f.occurrences = occurrences
+	if f.stdinFlag == flag {
+		f.stdinFlag = nil
+	}
+	return err
+}
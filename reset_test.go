@@ -0,0 +1,103 @@
+package pflag
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReset(t *testing.T) {
+	fs := NewFlagSet("TestReset", ContinueOnError)
+	n := fs.Int("n", 1, "")
+	c := fs.CountP("verbose", "v", "")
+	ss := fs.StringSlice("ss", []string{"a", "b"}, "")
+	sa := fs.StringArray("sa", []string{"x"}, "")
+	is := fs.IntSlice("is", nil, "")
+	ip := fs.IP("ip", nil, "")
+	mask := fs.IPMask("mask", nil, "")
+	ipnet := fs.IPNet("ipnet", net.IPNet{}, "")
+	d := fs.Duration("d", time.Second, "")
+
+	args := []string{"--n=2", "-vv", "--ss=c", "--sa=y", "--is=1,2", "--ip=1.2.3.4",
+		"--mask=255.255.0.0", "--ipnet=10.0.0.0/8", "--d=1m", "arg"}
+	for i := 0; i < 2; i++ {
+		if err := fs.Parse(args); err != nil {
+			t.Fatal(err)
+		}
+		if *n != 2 || *c != 2 || len(*ss) != 1 || len(*sa) != 1 || len(*is) != 2 || *d != time.Minute {
+			t.Fatalf("unexpected values after parsing: %d %d %v %v %v %v", *n, *c, *ss, *sa, *is, *d)
+		}
+		if err := fs.Reset(); err != nil {
+			t.Fatal(err)
+		}
+		if *n != 1 || *c != 0 || len(*ss) != 2 || (*ss)[1] != "b" || len(*sa) != 1 || (*sa)[0] != "x" ||
+			len(*is) != 0 || *ip != nil || *mask != nil || ipnet.IP != nil || *d != time.Second {
+			t.Fatalf("unexpected values after reset: %d %d %v %v %v %v %v %v %v", *n, *c, *ss, *sa, *is, *ip, *mask, *ipnet, *d)
+		}
+		if fs.NFlag() != 0 || fs.NArg() != 0 || fs.Changed("n") || fs.Parsed() {
+			t.Fatal("parse state not cleared")
+		}
+	}
+}
+
+func TestResetFlag(t *testing.T) {
+	fs := NewFlagSet("TestResetFlag", ContinueOnError)
+	n := fs.Int("n", 1, "")
+	ss := fs.StringSlice("ss", []string{"a"}, "")
+	if err := fs.Parse([]string{"--n=2", "--ss=b,c", "arg"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.ResetFlag("ss"); err != nil {
+		t.Fatal(err)
+	}
+	if len(*ss) != 1 || (*ss)[0] != "a" || fs.Changed("ss") {
+		t.Errorf("got ss %v, changed %v", *ss, fs.Changed("ss"))
+	}
+	if *n != 2 || !fs.Changed("n") || fs.NFlag() != 1 || fs.NArg() != 1 {
+		t.Errorf("other state was reset: n %d, %d flags, %d args", *n, fs.NFlag(), fs.NArg())
+	}
+	var visited []string
+	fs.VisitInOrder(func(flag *Flag, position int) { visited = append(visited, flag.Name) })
+	if len(visited) != 1 || visited[0] != "n" {
+		t.Errorf("visited %v in order", visited)
+	}
+	if err := fs.ResetFlag("nope"); err == nil {
+		t.Error("expected an error for an undefined flag")
+	}
+}
+
+// listValue is a custom slice value, appending on every Set.
+type listValue []string
+
+func (l *listValue) String() string        { return "[" + strings.Join(*l, ",") + "]" }
+func (l *listValue) Set(s string) error    { *l = append(*l, s); return nil }
+func (l *listValue) Type() string          { return "list" }
+func (l *listValue) Append(s string) error { return l.Set(s) }
+func (l *listValue) Replace(s []string) error {
+	*l = append((*l)[:0], s...)
+	return nil
+}
+func (l *listValue) GetSlice() []string { return *l }
+
+func TestResetSliceValues(t *testing.T) {
+	fs := NewFlagSet("TestResetSliceValues", ContinueOnError)
+	list := &listValue{"a", "b"}
+	fs.Var(list, "list", "")
+	tags := fs.StringSlice("tags", []string{"x"}, "")
+	if err := fs.WrapValue("tags", TransformSet(strings.ToLower)); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Parse([]string{"--list=c", "--tags=Y"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if got := list.String(); got != "[a,b]" {
+		t.Errorf("got list %s, want [a,b]", got)
+	}
+	if len(*tags) != 1 || (*tags)[0] != "x" {
+		t.Errorf("got tags %v, want [x]", *tags)
+	}
+}
@@ -0,0 +1,30 @@
+package pflag
+
+import "fmt"
+
+// redactedValue replaces the values of sensitive flags in usage messages and
+// dumps.
+const redactedValue = "****"
+
+// MarkSensitive marks the named flag as holding a secret. Its default and
+// current values are then shown as "****" wherever the FlagSet prints or
+// dumps them: in usage messages and generated documentation, by MarshalJSON
+// and PrintSources. ToArgs leaves the flag out, as arguments holding "****"
+// would set it to that.
+func (f *FlagSet) MarkSensitive(name string) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	flag.Sensitive = true
+	return nil
+}
+
+// redact returns value, a value of flag, or its replacement if flag is
+// sensitive. Empty values are kept, as they reveal nothing.
+func redact(flag *Flag, value string) string {
+	if flag.Sensitive && value != "" {
+		return redactedValue
+	}
+	return value
+}
@@ -0,0 +1,41 @@
+package pflag
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSensitive(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.String("token", "default-secret", "API token")
+	f.String("user", "", "")
+	if err := f.MarkSensitive("token"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.MarkSensitive("missing"); err == nil {
+		t.Error("expected an error for a missing flag")
+	}
+	if err := f.Parse([]string{"--token=s3cr3t", "--user=bob"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var sources, markdown bytes.Buffer
+	f.PrintSources(&sources)
+	GenMarkdownDocs(f, &markdown)
+	json, err := f.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, out := range []string{f.FlagUsages(), sources.String(), markdown.String(), string(json)} {
+		if strings.Contains(out, "secret") || strings.Contains(out, "s3cr3t") {
+			t.Errorf("sensitive value leaked in %s", out)
+		}
+		if !strings.Contains(out, "****") {
+			t.Errorf("sensitive value not masked in %s", out)
+		}
+	}
+	if args, want := strings.Join(f.ToArgs(false), " "), "--user=bob"; args != want {
+		t.Errorf("got args %q want %q", args, want)
+	}
+}
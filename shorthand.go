@@ -0,0 +1,109 @@
+package pflag
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// SetMultiCharShorthands sets whether shorthands may be longer than one
+// ASCII character, like the non-ASCII letter "é" or the two letters "rm" as
+// in "-rm". It must be enabled before such flags are defined. In a group of
+// shorthands, the longest shorthand defined wins, so "-rm" sets -rm rather
+// than -r and -m if all three are defined.
+func (f *FlagSet) SetMultiCharShorthands(enabled bool) {
+	f.multiCharShorthands = enabled
+}
+
+// ShorthandLookupRune returns the Flag structure of the flag whose shorthand
+// is the character r, returning nil if none exists. Unlike ShorthandLookup,
+// it doesn't panic for non-ASCII characters.
+func (f *FlagSet) ShorthandLookupRune(r rune) *Flag {
+	return f.shorthands[string(r)]
+}
+
+// Shorthands returns the flags which have a shorthand, indexed by their
+// shorthand, e.g. for completion generators and conflict checkers. The map
+// is a copy, which the caller may modify.
+func (f *FlagSet) Shorthands() map[string]*Flag {
+	shorthands := make(map[string]*Flag, len(f.shorthands))
+	for shorthand, flag := range f.shorthands {
+		shorthands[shorthand] = flag
+	}
+	return shorthands
+}
+
+// VisitShorthands calls fn for each flag which has a shorthand, in the
+// lexicographical order of the shorthands.
+func (f *FlagSet) VisitShorthands(fn func(shorthand string, flag *Flag)) {
+	shorthands := make([]string, 0, len(f.shorthands))
+	for shorthand := range f.shorthands {
+		shorthands = append(shorthands, shorthand)
+	}
+	sort.Strings(shorthands)
+	for _, shorthand := range shorthands {
+		fn(shorthand, f.shorthands[shorthand])
+	}
+}
+
+// shorthandAt returns the flag whose shorthand starts the group of
+// shorthands s, if any, and the length of the shorthand in s. The shorthands
+// of the ancestors of f are tried last if SetParseInherited is enabled.
+func (f *FlagSet) shorthandAt(s string) (*Flag, int) {
+	flag, n := f.ownShorthandAt(s)
+	if flag != nil || !f.parseInherited {
+		return flag, n
+	}
+	for p := f.parent; p != nil; p = p.parent {
+		if pflag, pn := p.ownShorthandAt(s); pflag != nil {
+			return pflag, pn
+		}
+	}
+	return nil, n
+}
+
+// ownShorthandAt is like shorthandAt, for the shorthands of f only.
+func (f *FlagSet) ownShorthandAt(s string) (*Flag, int) {
+	if !f.multiCharShorthands {
+		return f.shorthands[s[:1]], 1
+	}
+	n := f.maxShorthandLen
+	if n > len(s) {
+		n = len(s)
+	}
+	for ; n > 1; n-- {
+		if flag := f.shorthands[s[:n]]; flag != nil {
+			return flag, n
+		}
+	}
+	_, n = utf8.DecodeRuneInString(s)
+	return f.shorthands[s[:n]], n
+}
+
+// shorthandArg returns the shorthand s as an argument of the messages about
+// shorthands, which format it with %q or %c: a byte for an ASCII character,
+// to print it as before multi-character shorthands were supported, or else
+// a shorthandText.
+func shorthandArg(s string) interface{} {
+	if len(s) == 1 {
+		return s[0]
+	}
+	return shorthandText(s)
+}
+
+// shorthandText formats a multi-character shorthand like a character: %c
+// prints it as is and %q prints it quoted.
+type shorthandText string
+
+func (s shorthandText) Format(state fmt.State, verb rune) {
+	switch verb {
+	case 'q':
+		if r, n := utf8.DecodeRuneInString(string(s)); n == len(s) {
+			fmt.Fprintf(state, "%q", r)
+			return
+		}
+		fmt.Fprintf(state, "%q", string(s))
+	default:
+		fmt.Fprint(state, string(s))
+	}
+}
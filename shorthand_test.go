@@ -0,0 +1,83 @@
+package pflag
+
+import (
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMultiCharShorthands(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.SetMultiCharShorthands(true)
+	rm := f.BoolP("rm", "rm", false, "")
+	r := f.BoolP("recursive", "r", false, "")
+	m := f.BoolP("mute", "m", false, "")
+	name := f.StringP("name", "é", "", "")
+	if err := f.Parse([]string{"-rm", "-mr", "-éx", "-é=y", "pos"}); err != nil {
+		t.Fatal(err)
+	}
+	if !*rm || !*r || !*m || *name != "y" {
+		t.Errorf("got rm %v, recursive %v, mute %v and name %q", *rm, *r, *m, *name)
+	}
+	if !reflect.DeepEqual(f.Args(), []string{"pos"}) {
+		t.Errorf("got args %v", f.Args())
+	}
+	if flag := f.ShorthandLookup("é"); flag == nil || flag.Name != "name" {
+		t.Errorf("got %v for the shorthand é", flag)
+	}
+
+	err := f.Parse([]string{"-ü"})
+	if err == nil || !strings.Contains(err.Error(), "'ü'") {
+		t.Errorf("got error %v for an unknown shorthand", err)
+	}
+	err = f.Parse([]string{"-é"})
+	if err == nil || !strings.Contains(err.Error(), "'é'") {
+		t.Errorf("got error %v for a missing argument", err)
+	}
+}
+
+func TestMultiCharShorthandsDisabled(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a multi-character shorthand")
+		}
+	}()
+	f.BoolP("rm", "rm", false, "")
+}
+
+func TestShorthands(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetMultiCharShorthands(true)
+	f.BoolP("verbose", "v", false, "")
+	f.StringP("name", "é", "", "")
+	f.Bool("quiet", false, "")
+	f.BoolP("all", "a", false, "")
+
+	if flag := f.ShorthandLookupRune('é'); flag == nil || flag.Name != "name" {
+		t.Errorf("got %v for the shorthand é", flag)
+	}
+	if flag := f.ShorthandLookupRune('q'); flag != nil {
+		t.Errorf("got %v for the undefined shorthand q", flag)
+	}
+
+	shorthands := f.Shorthands()
+	if len(shorthands) != 3 || shorthands["v"].Name != "verbose" {
+		t.Errorf("got shorthands %v", shorthands)
+	}
+	delete(shorthands, "v")
+	if f.ShorthandLookup("v") == nil {
+		t.Error("modifying the returned map changed the FlagSet")
+	}
+
+	var visited []string
+	f.VisitShorthands(func(shorthand string, flag *Flag) {
+		visited = append(visited, shorthand+"="+flag.Name)
+	})
+	if want := []string{"a=all", "v=verbose", "é=name"}; !reflect.DeepEqual(visited, want) {
+		t.Errorf("visited %v, want %v", visited, want)
+	}
+}
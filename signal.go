@@ -0,0 +1,145 @@
+package pflag
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// -- os.Signal value
+type signalValue struct {
+	value *os.Signal
+}
+
+func newSignalValue(val os.Signal, p *os.Signal) *signalValue {
+	*p = val
+	return &signalValue{value: p}
+}
+
+func (s *signalValue) Set(val string) error {
+	sig, err := parseSignal(val)
+	if err != nil {
+		return err
+	}
+	*s.value = sig
+	return nil
+}
+
+func (s *signalValue) Type() string {
+	return "signal"
+}
+
+func (s *signalValue) String() string { return signalString(*s.value) }
+
+// parseSignal returns the signal named val, with or without its "SIG"
+// prefix and in any case, as in "SIGTERM" or "hup", or numbered val where
+// signals have numbers.
+func parseSignal(val string) (os.Signal, error) {
+	name := strings.ToUpper(strings.TrimSpace(val))
+	if sig, ok := signals[strings.TrimPrefix(name, "SIG")]; ok {
+		return sig, nil
+	}
+	if n, err := strconv.Atoi(name); err == nil {
+		if sig, ok := signalNumber(n); ok {
+			return sig, nil
+		}
+	}
+	names := make([]string, 0, len(signals))
+	for name := range signals {
+		names = append(names, "SIG"+name)
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("unknown signal %q, it should be a number or one of %s", val, strings.Join(names, ", "))
+}
+
+// signalString returns the name of sig, like "SIGTERM", or its number if it
+// has no name, or the empty string if sig is nil.
+func signalString(sig os.Signal) string {
+	if sig == nil {
+		return ""
+	}
+	for name, s := range signals {
+		if s == sig {
+			return "SIG" + name
+		}
+	}
+	if n, ok := signalToNumber(sig); ok {
+		return strconv.Itoa(n)
+	}
+	return sig.String()
+}
+
+func signalConv(sval string) (interface{}, error) {
+	if sval == "" {
+		return os.Signal(nil), nil
+	}
+	return parseSignal(sval)
+}
+
+// GetSignal return the os.Signal value of a flag with the given name
+func (f *FlagSet) GetSignal(name string) (os.Signal, error) {
+	val, err := f.getFlagType(name, "signal", signalConv)
+	if err != nil {
+		return nil, err
+	}
+	sig, _ := val.(os.Signal)
+	return sig, nil
+}
+
+// SignalVar defines an os.Signal flag with specified name, default value, and usage string.
+// The value is a signal name, with or without its SIG prefix, as in "SIGTERM" or "HUP", or
+// a signal number on Unix systems.
+// The argument p points to an os.Signal variable in which to store the value of the flag.
+func (f *FlagSet) SignalVar(p *os.Signal, name string, value os.Signal, usage string) {
+	f.VarP(newSignalValue(value, p), name, "", usage)
+}
+
+// SignalVarP is like SignalVar, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) SignalVarP(p *os.Signal, name, shorthand string, value os.Signal, usage string) {
+	f.VarP(newSignalValue(value, p), name, shorthand, usage)
+}
+
+// SignalVar defines an os.Signal flag with specified name, default value, and usage string.
+// The value is a signal name, with or without its SIG prefix, as in "SIGTERM" or "HUP", or
+// a signal number on Unix systems.
+// The argument p points to an os.Signal variable in which to store the value of the flag.
+func SignalVar(p *os.Signal, name string, value os.Signal, usage string) {
+	CommandLine.VarP(newSignalValue(value, p), name, "", usage)
+}
+
+// SignalVarP is like SignalVar, but accepts a shorthand letter that can be used after a single dash.
+func SignalVarP(p *os.Signal, name, shorthand string, value os.Signal, usage string) {
+	CommandLine.VarP(newSignalValue(value, p), name, shorthand, usage)
+}
+
+// Signal defines an os.Signal flag with specified name, default value, and usage string.
+// The value is a signal name, with or without its SIG prefix, as in "SIGTERM" or "HUP", or
+// a signal number on Unix systems.
+// The return value is the address of an os.Signal variable that stores the value of the flag.
+func (f *FlagSet) Signal(name string, value os.Signal, usage string) *os.Signal {
+	p := new(os.Signal)
+	f.SignalVarP(p, name, "", value, usage)
+	return p
+}
+
+// SignalP is like Signal, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) SignalP(name, shorthand string, value os.Signal, usage string) *os.Signal {
+	p := new(os.Signal)
+	f.SignalVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// Signal defines an os.Signal flag with specified name, default value, and usage string.
+// The value is a signal name, with or without its SIG prefix, as in "SIGTERM" or "HUP", or
+// a signal number on Unix systems.
+// The return value is the address of an os.Signal variable that stores the value of the flag.
+func Signal(name string, value os.Signal, usage string) *os.Signal {
+	return CommandLine.SignalP(name, "", value, usage)
+}
+
+// SignalP is like Signal, but accepts a shorthand letter that can be used after a single dash.
+func SignalP(name, shorthand string, value os.Signal, usage string) *os.Signal {
+	return CommandLine.SignalP(name, shorthand, value, usage)
+}
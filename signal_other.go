@@ -0,0 +1,25 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package pflag
+
+import "os"
+
+// signals maps the names of signals without their SIG prefix to them. Only
+// the signals every platform has are known on this one.
+var signals = map[string]os.Signal{
+	"INT":  os.Interrupt,
+	"KILL": os.Kill,
+}
+
+// signalNumber returns the signal numbered n. Signals have no numbers on
+// this platform, so it always returns false.
+func signalNumber(n int) (os.Signal, bool) {
+	return nil, false
+}
+
+// signalToNumber returns the number of sig. Signals have no numbers on this
+// platform, so it always returns false.
+func signalToNumber(sig os.Signal) (int, bool) {
+	return 0, false
+}
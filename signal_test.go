@@ -0,0 +1,47 @@
+package pflag
+
+import (
+	"os"
+	"testing"
+)
+
+func TestSignal(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	sig := f.SignalP("stop-signal", "s", os.Interrupt, "`signal` to stop the process with")
+	if got, want := f.FlagUsages(), "  -s, --stop-signal signal   signal to stop the process with (default SIGINT)\n"; got != want {
+		t.Errorf("got usage %q, want %q", got, want)
+	}
+
+	for _, in := range []string{"SIGKILL", "KILL", "sigkill", " kill "} {
+		if err := f.Parse([]string{"-s", in}); err != nil {
+			t.Errorf("%s: %v", in, err)
+			continue
+		}
+		if *sig != os.Kill {
+			t.Errorf("%s: got %v, want %v", in, *sig, os.Kill)
+		}
+	}
+	if got := f.Lookup("stop-signal").Value.String(); got != "SIGKILL" {
+		t.Errorf("got string %q, want SIGKILL", got)
+	}
+	if v, err := f.GetSignal("stop-signal"); err != nil || v != os.Kill {
+		t.Errorf("GetSignal returned %v, %v", v, err)
+	}
+	if err := f.Set("stop-signal", "SIGNOPE"); err == nil {
+		t.Error("expected an error for an unknown signal")
+	}
+
+	f.Signal("reload-signal", nil, "")
+	if args := f.ToArgs(false); len(args) != 1 || args[0] != "--stop-signal=SIGKILL" {
+		t.Errorf("got args %q", args)
+	}
+	if v, err := f.GetSignal("reload-signal"); err != nil || v != nil {
+		t.Errorf("GetSignal returned %v, %v for an unset signal", v, err)
+	}
+	if err := f.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if *sig != os.Interrupt {
+		t.Errorf("got %v after Reset, want %v", *sig, os.Interrupt)
+	}
+}
@@ -0,0 +1,29 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package pflag
+
+import (
+	"syscall"
+	"testing"
+)
+
+func TestSignalNumber(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	sig := f.Signal("stop-signal", syscall.SIGTERM, "")
+	if err := f.Set("stop-signal", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if *sig != syscall.SIGHUP {
+		t.Errorf("got %v, want %v", *sig, syscall.SIGHUP)
+	}
+	if err := f.Set("stop-signal", "usr1"); err != nil || *sig != syscall.SIGUSR1 {
+		t.Errorf("got %v, %v, want %v", *sig, err, syscall.SIGUSR1)
+	}
+	if err := f.Set("stop-signal", "40"); err != nil || f.Lookup("stop-signal").Value.String() != "40" {
+		t.Errorf("got %v, %v, want signal 40", *sig, err)
+	}
+	if err := f.Set("stop-signal", "0"); err == nil {
+		t.Error("expected an error for signal 0")
+	}
+}
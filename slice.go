@@ -0,0 +1,338 @@
+package pflag
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Duplicates tells how the duplicate elements of slice flags are handled,
+// see SetSliceDuplicates.
+type Duplicates int
+
+const (
+	// DuplicatesKeep keeps duplicate elements, the default.
+	DuplicatesKeep Duplicates = iota
+	// DuplicatesRemove keeps the first of duplicate elements only.
+	DuplicatesRemove
+	// DuplicatesError makes setting a flag to a duplicate element an error.
+	DuplicatesError
+)
+
+// SliceValue is implemented by the values of slice and array flags, which
+// hold lists of elements, so that they can be handled without knowing the
+// type of their elements.
+type SliceValue interface {
+	// Append adds an element, in the syntax accepted by Set for a single
+	// element, at the end of the list.
+	Append(string) error
+	// Replace replaces the list by the given elements.
+	Replace([]string) error
+	// GetSlice returns the elements of the list, in the syntax accepted by
+	// Append.
+	GetSlice() []string
+}
+
+// sliceOptions holds the options common to the values of slice flags.
+type sliceOptions struct {
+	sep        rune // separator of the elements, if sepSet, see SetSliceSeparator
+	sepSet     bool
+	duplicates Duplicates
+	sorted     bool
+	// appendDefault makes the first value append to the default rather
+	// than replace it, see SetSliceAppendDefault.
+	appendDefault bool
+}
+
+func (o *sliceOptions) options() *sliceOptions { return o }
+
+// sliceOptioner is implemented by the values of slice flags.
+type sliceOptioner interface {
+	options() *sliceOptions
+}
+
+// split splits val into elements, with the separator set by
+// SetSliceSeparator if any, or else with split.
+func (o *sliceOptions) split(val string, split func(string) ([]string, error)) ([]string, error) {
+	if !o.sepSet {
+		return split(val)
+	}
+	if o.sep == 0 {
+		return []string{val}, nil
+	}
+	return splitEscaped(val, o.sep)
+}
+
+// assign sets the slice p points to to out, or appends out to it if
+// appendOut, then handles duplicates and sorts the elements as configured.
+// p is left alone if an error is returned.
+func (o *sliceOptions) assign(p, out interface{}, appendOut bool) error {
+	pv := reflect.ValueOf(p).Elem()
+	res := reflect.ValueOf(out)
+	if appendOut {
+		res = reflect.AppendSlice(pv, res)
+	}
+	if o.duplicates != DuplicatesKeep {
+		unique := reflect.MakeSlice(res.Type(), 0, res.Len())
+		seen := make(map[string]bool, res.Len())
+		for i := 0; i < res.Len(); i++ {
+			key := fmt.Sprint(res.Index(i).Interface())
+			if seen[key] {
+				if o.duplicates == DuplicatesError {
+					return fmt.Errorf("duplicate value %q", key)
+				}
+				continue
+			}
+			seen[key] = true
+			unique = reflect.Append(unique, res.Index(i))
+		}
+		res = unique
+	}
+	if o.sorted {
+		sort.Sort(sortedSlice{res})
+	}
+	pv.Set(res)
+	return nil
+}
+
+// sortedSlice sorts the elements of a slice flag.
+type sortedSlice struct {
+	v reflect.Value
+}
+
+func (s sortedSlice) Len() int { return s.v.Len() }
+
+func (s sortedSlice) Swap(i, j int) {
+	tmp := reflect.New(s.v.Type().Elem()).Elem()
+	tmp.Set(s.v.Index(i))
+	s.v.Index(i).Set(s.v.Index(j))
+	s.v.Index(j).Set(tmp)
+}
+
+func (s sortedSlice) Less(i, j int) bool {
+	switch a := s.v.Index(i).Interface().(type) {
+	case string:
+		return a < s.v.Index(j).Interface().(string)
+	case int:
+		return a < s.v.Index(j).Interface().(int)
+	case uint:
+		return a < s.v.Index(j).Interface().(uint)
+	case bool:
+		return !a && s.v.Index(j).Interface().(bool)
+	case net.IP:
+		return bytes.Compare(a.To16(), s.v.Index(j).Interface().(net.IP).To16()) < 0
+	}
+	return false
+}
+
+// splitComma splits val at commas.
+func splitComma(val string) ([]string, error) {
+	return strings.Split(val, ","), nil
+}
+
+// splitEscaped splits val at sep, except where sep is quoted by a preceding
+// backslash or within double quotes. The quotes and backslashes quoting a
+// character are removed.
+func splitEscaped(val string, sep rune) ([]string, error) {
+	if val == "" {
+		return []string{}, nil
+	}
+	var elems []string
+	var elem bytes.Buffer
+	quoted := false
+	escaped := false
+	for _, c := range val {
+		switch {
+		case escaped:
+			elem.WriteRune(c)
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+		case c == sep && !quoted:
+			elems = append(elems, elem.String())
+			elem.Reset()
+		default:
+			elem.WriteRune(c)
+		}
+	}
+	if escaped {
+		return nil, errors.New("unterminated backslash")
+	}
+	if quoted {
+		return nil, errors.New("unterminated double quote")
+	}
+	return append(elems, elem.String()), nil
+}
+
+// escapeElement quotes the backslashes, double quotes and separators in
+// elem, so that splitEscaped reads it back.
+func escapeElement(elem string, sep rune) string {
+	var buf bytes.Buffer
+	for _, c := range elem {
+		if c == '\\' || c == '"' || c == sep {
+			buf.WriteByte('\\')
+		}
+		buf.WriteRune(c)
+	}
+	return buf.String()
+}
+
+// sliceFlagOptions returns the options of the value of the named slice flag.
+func (f *FlagSet) sliceFlagOptions(name string) (*sliceOptions, error) {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return nil, fmt.Errorf("flag %q does not exist", name)
+	}
+	v, ok := flag.Value.(sliceOptioner)
+	if !ok {
+		return nil, fmt.Errorf("flag %q is not a slice", name)
+	}
+	return v.options(), nil
+}
+
+// SetSliceSeparator sets the separator of the elements given in a value of
+// the named slice flag, e.g. ':' for lists of paths. Separators can then be
+// part of an element by quoting them with a backslash, as in a\:b, or with
+// double quotes, as in "a:b". With a zero sep, the values aren't split, so
+// --header "Accept: a,b" sets a single element. By default, elements are
+// separated by commas, and quoted as in CSV files for string slices, while
+// the values of string arrays aren't split. The value of the flag is printed
+// with commas regardless.
+func (f *FlagSet) SetSliceSeparator(name string, sep rune) error {
+	o, err := f.sliceFlagOptions(name)
+	if err != nil {
+		return err
+	}
+	o.sep, o.sepSet = sep, true
+	return nil
+}
+
+// SetSliceDuplicates sets how duplicate elements of the named slice flag are
+// handled. They are checked every time the flag is set, across all the
+// values it was set to.
+func (f *FlagSet) SetSliceDuplicates(name string, duplicates Duplicates) error {
+	o, err := f.sliceFlagOptions(name)
+	if err != nil {
+		return err
+	}
+	o.duplicates = duplicates
+	return nil
+}
+
+// SetSliceSorted sets whether the elements of the named slice flag are
+// sorted every time the flag is set. Strings are sorted lexicographically,
+// numbers and IP addresses by value, and false comes before true.
+func (f *FlagSet) SetSliceSorted(name string, sorted bool) error {
+	o, err := f.sliceFlagOptions(name)
+	if err != nil {
+		return err
+	}
+	o.sorted = sorted
+	return nil
+}
+
+// sliceFlag returns the named flag and its value, if it is a SliceValue.
+func (f *FlagSet) sliceFlag(name string) (*Flag, SliceValue, error) {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return nil, nil, fmt.Errorf("flag %q does not exist", name)
+	}
+	v, ok := flag.Value.(SliceValue)
+	if !ok {
+		return nil, nil, fmt.Errorf("flag %q is not a slice", name)
+	}
+	return flag, v, nil
+}
+
+// GetSlice returns the elements of the named slice flag, see SliceValue.
+func (f *FlagSet) GetSlice(name string) ([]string, error) {
+	_, v, err := f.sliceFlag(name)
+	if err != nil {
+		return nil, err
+	}
+	return v.GetSlice(), nil
+}
+
+// AppendSlice adds an element at the end of the named slice flag, which is
+// then marked as set like with Set.
+func (f *FlagSet) AppendSlice(name, value string) error {
+	flag, v, err := f.sliceFlag(name)
+	if err != nil {
+		return err
+	}
+	return f.update(flag, f.normalizeFlagName(flag.Name), value, SourceSet, func() error {
+		if err := f.checkValue(flag, value); err != nil {
+			return err
+		}
+		return v.Append(value)
+	})
+}
+
+// ReplaceSlice replaces the elements of the named slice flag, which is then
+// marked as set like with Set.
+func (f *FlagSet) ReplaceSlice(name string, values []string) error {
+	return f.ReplaceSliceWithSource(name, values, SourceSet)
+}
+
+// ReplaceSliceWithSource is like ReplaceSlice, but records src as where the
+// value comes from, like SetWithSource.
+func (f *FlagSet) ReplaceSliceWithSource(name string, values []string, src ValueSource) error {
+	flag, v, err := f.sliceFlag(name)
+	if err != nil {
+		return err
+	}
+	return f.update(flag, f.normalizeFlagName(flag.Name), strings.Join(values, ","), src, func() error {
+		for _, value := range values {
+			if err := f.checkValue(flag, value); err != nil {
+				return err
+			}
+		}
+		return v.Replace(values)
+	})
+}
+
+// SetSliceAppendDefault sets whether the values given to the named slice
+// flag append to its default value. By default, the first value replaces the
+// default and the next ones append to it. Set the RepeatPolicy of the flag to
+// RepeatError with SetFlagRepeatPolicy to make giving it again an error
+// instead. Both are noted in help and usage messages.
+func (f *FlagSet) SetSliceAppendDefault(name string, appendDefault bool) error {
+	o, err := f.sliceFlagOptions(name)
+	if err != nil {
+		return err
+	}
+	o.appendDefault = appendDefault
+	return nil
+}
+
+// semantics returns the notes on the values accepted by flag and how they
+// combine shown in help, if they differ from the default ones.
+func (f *FlagSet) semantics(flag *Flag) string {
+	var notes []string
+	if note := f.enumNote(flag); note != "" {
+		notes = append(notes, note)
+	}
+	if b, ok := flag.Value.(boundedValue); ok {
+		min, max := b.bounds()
+		notes = append(notes, fmt.Sprintf(f.msg(MsgRange), min, max))
+	}
+	if b, ok := flag.Value.(*bitmaskValue); ok {
+		notes = append(notes, fmt.Sprintf(f.msg(MsgBitmask), strings.Join(b.sortedNames(), ", ")))
+	}
+	if o, ok := flag.Value.(sliceOptioner); ok && o.options().appendDefault {
+		notes = append(notes, f.msg(MsgAppendsDefault))
+	}
+	if accumulates(flag) && flag.repeatPolicy == RepeatError {
+		notes = append(notes, f.msg(MsgGivenOnce))
+	}
+	if flag.envVar != "" {
+		notes = append(notes, fmt.Sprintf(f.msg(MsgEnvVar), flag.envVar))
+	}
+	return strings.Join(notes, " ")
+}
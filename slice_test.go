@@ -0,0 +1,188 @@
+package pflag
+
+import (
+	"io/ioutil"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSliceSeparator(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	paths := f.StringSlice("path", nil, "")
+	headers := f.StringSlice("header", nil, "")
+	ports := f.IntSlice("port", nil, "")
+	tags := f.StringSlice("tag", nil, "")
+	f.Int("n", 0, "")
+	f.SetSliceSeparator("path", ':')
+	f.SetSliceSeparator("header", 0)
+	f.SetSliceSeparator("port", ';')
+	if err := f.SetSliceSeparator("n", ':'); err == nil {
+		t.Error("expected an error for a flag which is not a slice")
+	}
+
+	args := []string{
+		"--path", `/bin:/usr/local\:x:"/a:b":c,d`,
+		"--header", "Accept: a,b", "--header", "X: 1",
+		"--port", "80;443",
+		"--tag", `a,"b,c"`,
+	}
+	if err := f.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"/bin", "/usr/local:x", "/a:b", "c,d"}; !reflect.DeepEqual(*paths, want) {
+		t.Errorf("got paths %q, want %q", *paths, want)
+	}
+	if want := []string{"Accept: a,b", "X: 1"}; !reflect.DeepEqual(*headers, want) {
+		t.Errorf("got headers %q, want %q", *headers, want)
+	}
+	if want := []int{80, 443}; !reflect.DeepEqual(*ports, want) {
+		t.Errorf("got ports %v, want %v", *ports, want)
+	}
+	if want := []string{"a", "b,c"}; !reflect.DeepEqual(*tags, want) {
+		t.Errorf("got tags %q, want %q", *tags, want)
+	}
+	if err := f.Set("path", `a\`); err == nil {
+		t.Error("expected an error for a trailing backslash")
+	}
+
+	// The values must survive ToArgs.
+	c, err := f.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Reset()
+	if err := c.Parse(f.ToArgs(true)); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"path", "header", "port", "tag"} {
+		if got, want := c.Lookup(name).Value.String(), f.Lookup(name).Value.String(); got != want {
+			t.Errorf("--%s: got %s after ToArgs, want %s", name, got, want)
+		}
+	}
+}
+
+func TestSliceDuplicatesAndSorting(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	tags := f.New("tag").Unique().Sorted().StringSlice()
+	ports := f.New("port").Sorted().IntSlice()
+	ips := f.New("ip").Sorted().Var(newIPSliceValue(nil, new([]net.IP)))
+	names := f.New("name").NoDuplicates().StringArray()
+	if err := f.SetSliceSorted("name", true); err != nil {
+		t.Fatal(err)
+	}
+
+	args := []string{"--tag", "b,a", "--tag", "c,a", "--port", "443,80,8080", "--ip", "10.0.0.2,10.0.0.1", "--name", "z", "--name", "y"}
+	if err := f.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(*tags, want) {
+		t.Errorf("got tags %q, want %q", *tags, want)
+	}
+	if want := []int{80, 443, 8080}; !reflect.DeepEqual(*ports, want) {
+		t.Errorf("got ports %v, want %v", *ports, want)
+	}
+	if got := ips.Value.String(); got != "[10.0.0.1,10.0.0.2]" {
+		t.Errorf("got ips %s", got)
+	}
+	if want := []string{"y", "z"}; !reflect.DeepEqual(*names, want) {
+		t.Errorf("got names %q, want %q", *names, want)
+	}
+
+	err := f.Parse([]string{"--name", "y"})
+	if want := `invalid argument "y" for "--name" flag: duplicate value "y"`; err == nil || err.Error() != want {
+		t.Errorf("got error %v, want %s", err, want)
+	}
+	if want := []string{"y", "z"}; !reflect.DeepEqual(*names, want) {
+		t.Errorf("a duplicate should leave the value alone, got %q", *names)
+	}
+}
+
+func TestSliceAppendDefault(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	paths := f.New("path").Default("/usr/lib").AppendDefault().StringSlice()
+	hosts := f.New("host").Default("localhost").StringSlice()
+	if err := f.SetFlagRepeatPolicy("host", RepeatError); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.Parse([]string{"--path", "/opt/lib", "--path", "/lib", "--host", "a,b"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"/usr/lib", "/opt/lib", "/lib"}; !reflect.DeepEqual(*paths, want) {
+		t.Errorf("got paths %q, want %q", *paths, want)
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(*hosts, want) {
+		t.Errorf("got hosts %q, want %q", *hosts, want)
+	}
+
+	f.Reset()
+	err := f.Parse([]string{"--host", "a", "--host", "b"})
+	if want := "flag --host given more than once"; err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("got error %v, want %s", err, want)
+	}
+
+	usage := f.FlagUsages()
+	for _, want := range []string{`(default [/usr/lib]) (appends to the default)`, `(default [localhost]) (may be given once)`} {
+		if !strings.Contains(usage, want) {
+			t.Errorf("usage does not contain %q:\n%s", want, usage)
+		}
+	}
+}
+
+func TestSliceValue(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.StringSlice("tags", []string{"x"}, "")
+	f.StringArray("names", nil, "")
+	f.IntSlice("ports", nil, "")
+	f.UintSlice("ids", nil, "")
+	f.BoolSlice("bits", nil, "")
+	f.IPSlice("ips", nil, "")
+	f.Int("n", 0, "")
+
+	tests := []struct {
+		name    string
+		replace []string
+		append  string
+		want    []string
+	}{
+		{"tags", []string{"a,b", "c"}, "d", []string{"a,b", "c", "d"}},
+		{"names", []string{"a"}, "b,c", []string{"a", "b,c"}},
+		{"ports", []string{"80"}, "443", []string{"80", "443"}},
+		{"ids", []string{"1"}, "2", []string{"1", "2"}},
+		{"bits", []string{"true"}, "false", []string{"true", "false"}},
+		{"ips", []string{"10.0.0.1"}, "::1", []string{"10.0.0.1", "::1"}},
+	}
+	for _, test := range tests {
+		if _, ok := f.Lookup(test.name).Value.(SliceValue); !ok {
+			t.Errorf("--%s is not a SliceValue", test.name)
+			continue
+		}
+		if err := f.ReplaceSlice(test.name, test.replace); err != nil {
+			t.Errorf("--%s: %v", test.name, err)
+		}
+		if err := f.AppendSlice(test.name, test.append); err != nil {
+			t.Errorf("--%s: %v", test.name, err)
+		}
+		if got, err := f.GetSlice(test.name); err != nil || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("--%s: got %q, %v, want %q", test.name, got, err, test.want)
+		}
+		if !f.Changed(test.name) {
+			t.Errorf("--%s not marked as changed", test.name)
+		}
+	}
+
+	if err := f.AppendSlice("ports", "x"); err == nil {
+		t.Error("expected an error for an invalid element")
+	}
+	if _, err := f.GetSlice("n"); err == nil {
+		t.Error("expected an error for a flag which is not a slice")
+	}
+	f.SetChoices("names", "a", "b")
+	if err := f.ReplaceSlice("names", []string{"a", "c"}); err == nil {
+		t.Error("expected an error for an element which is not a choice")
+	}
+}
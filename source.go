@@ -0,0 +1,131 @@
+package pflag
+
+import (
+	"fmt"
+	"io"
+)
+
+// ValueSource tells where the current value of a flag comes from.
+type ValueSource int
+
+const (
+	// SourceDefault means the flag still has its default value.
+	SourceDefault ValueSource = iota
+	// SourceDefaultArg means the flag was given on the command line without
+	// an argument and got its NoOptDefVal.
+	SourceDefaultArg
+	// SourceCommandLine means the value was given on the command line.
+	SourceCommandLine
+	// SourceSet means the value was set by the program through Set.
+	SourceSet
+	// SourceConfig means the value was read from a configuration file.
+	SourceConfig
+	// SourceEnv means the value was read from an environment variable.
+	SourceEnv
+)
+
+func (s ValueSource) String() string {
+	switch s {
+	case SourceDefault:
+		return "default"
+	case SourceDefaultArg:
+		return "default argument"
+	case SourceCommandLine:
+		return "command line"
+	case SourceSet:
+		return "set"
+	case SourceConfig:
+		return "config"
+	case SourceEnv:
+		return "env"
+	}
+	return fmt.Sprintf("ValueSource(%d)", int(s))
+}
+
+// Source returns where the current value of the flag comes from.
+func (f *Flag) Source() ValueSource {
+	return f.source
+}
+
+// defaultPrecedence is the resolution order of sources used unless
+// SetPrecedence is called.
+var defaultPrecedence = []ValueSource{SourceCommandLine, SourceSet, SourceEnv, SourceConfig}
+
+// SetPrecedence sets the order in which values from the different sources
+// win over each other, from the highest precedence to the lowest. A value is
+// only applied by the environment layer (see SetEnvPrefix), ParseFile or the
+// config package if its source ranks at least as high as the source of the
+// value the flag currently holds; the command line and Set always apply.
+// Sources which are not listed rank below the listed ones, SourceDefault
+// always ranks last and SourceDefaultArg ranks like SourceCommandLine.
+//
+// The default order is SourceCommandLine, SourceSet, SourceEnv, SourceConfig.
+func (f *FlagSet) SetPrecedence(sources ...ValueSource) {
+	f.precedence = append([]ValueSource(nil), sources...)
+}
+
+// Precedence returns the resolution order of sources, see SetPrecedence.
+func (f *FlagSet) Precedence() []ValueSource {
+	if f.precedence == nil {
+		return append([]ValueSource(nil), defaultPrecedence...)
+	}
+	return append([]ValueSource(nil), f.precedence...)
+}
+
+// rank returns the position of src in the resolution order; lower ranks win.
+func (f *FlagSet) rank(src ValueSource) int {
+	order := f.precedence
+	if order == nil {
+		order = defaultPrecedence
+	}
+	if src == SourceDefaultArg {
+		src = SourceCommandLine
+	}
+	if src == SourceDefault {
+		return len(order) + 1
+	}
+	for i, s := range order {
+		if s == src {
+			return i
+		}
+	}
+	return len(order)
+}
+
+// overrides returns true if a value from src takes precedence over the
+// current value of flag.
+func (f *FlagSet) overrides(flag *Flag, src ValueSource) bool {
+	return f.rank(src) <= f.rank(flag.source)
+}
+
+// Overrides returns true if a value from src takes precedence over the
+// current value of the named flag, according to the order set with
+// SetPrecedence. It returns false if the flag does not exist.
+func (f *FlagSet) Overrides(name string, src ValueSource) bool {
+	flag := f.Lookup(name)
+	return flag != nil && f.overrides(flag, src)
+}
+
+// SourceOf returns where the current value of the named flag comes from,
+// that is the source which won according to the order set with SetPrecedence.
+func (f *FlagSet) SourceOf(name string) (ValueSource, error) {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return SourceDefault, fmt.Errorf("flag %q does not exist", name)
+	}
+	return flag.source, nil
+}
+
+// PrintSources prints, for every flag in VisitAll order, its current value
+// and where that value comes from, e.g.
+//
+//	--log-level=debug (command line)
+func (f *FlagSet) PrintSources(w io.Writer) error {
+	var err error
+	f.VisitAll(func(flag *Flag) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, "--%s=%s (%s)\n", flag.Name, redact(flag, flag.Value.String()), flag.source)
+		}
+	})
+	return err
+}
@@ -0,0 +1,78 @@
+package pflag
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSource(t *testing.T) {
+	fs := NewFlagSet("TestSource", ContinueOnError)
+	fs.String("a", "", "")
+	fs.String("b", "", "")
+	fs.Lookup("b").NoOptDefVal = "opt"
+	fs.Int("c", 0, "")
+	fs.Bool("d", false, "")
+
+	if err := fs.Parse([]string{"--a=x", "--b"}); err != nil {
+		t.Fatal(err)
+	}
+	fs.Set("c", "1")
+
+	want := map[string]ValueSource{
+		"a": SourceCommandLine,
+		"b": SourceDefaultArg,
+		"c": SourceSet,
+		"d": SourceDefault,
+	}
+	for name, src := range want {
+		if got := fs.Lookup(name).Source(); got != src {
+			t.Errorf("%s: got source %v want %v", name, got, src)
+		}
+	}
+
+	var buf bytes.Buffer
+	fs.PrintSources(&buf)
+	wantOut := "--a=x (command line)\n--b=opt (default argument)\n--c=1 (set)\n--d=false (default)\n"
+	if got := buf.String(); got != wantOut {
+		t.Errorf("got %q want %q", got, wantOut)
+	}
+
+	fs.ParseAll([]string{"--d=true"}, func(flag *Flag, value string) error {
+		return fs.Set(flag.Name, value)
+	})
+	if got := fs.Lookup("d").Source(); got != SourceCommandLine {
+		t.Errorf("got source %v want %v", got, SourceCommandLine)
+	}
+}
+
+func TestPrecedence(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.String("a", "", "")
+	f.String("b", "", "")
+	if err := f.Parse([]string{"--a=cli"}); err != nil {
+		t.Fatal(err)
+	}
+	if f.Overrides("a", SourceConfig) || !f.Overrides("b", SourceConfig) {
+		t.Error("config should only override the default value")
+	}
+	if err := f.SetWithSource("b", "env", SourceEnv); err != nil {
+		t.Fatal(err)
+	}
+	if f.Overrides("b", SourceConfig) || !f.Overrides("b", SourceEnv) {
+		t.Error("config should not override env")
+	}
+
+	f.SetPrecedence(SourceConfig, SourceEnv, SourceCommandLine)
+	if !f.Overrides("a", SourceEnv) || !f.Overrides("b", SourceConfig) {
+		t.Error("reversed precedence not applied")
+	}
+	if f.Overrides("a", SourceSet) {
+		t.Error("unlisted source should rank below listed ones")
+	}
+	if f.Overrides("missing", SourceCommandLine) {
+		t.Error("missing flag should not be overridden")
+	}
+	if src, err := f.SourceOf("b"); err != nil || src != SourceEnv {
+		t.Errorf("got %v, %v want %v", src, err, SourceEnv)
+	}
+}
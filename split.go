@@ -0,0 +1,86 @@
+package pflag
+
+import (
+	"bytes"
+	"errors"
+)
+
+// SplitArgs splits s into arguments following the quoting rules of the
+// POSIX shell, for strings of extra arguments read from configuration files
+// or environment variables, e.g. `--name "John Doe" -v`. Arguments are
+// separated by blanks and newlines. Characters are quoted by a preceding
+// backslash, and strings by single quotes, in which no character is special,
+// or double quotes, in which a backslash only quotes '$', '`', '"', '\' and
+// newline. A '#' starting an argument starts a comment up to the end of the
+// line. Unlike the shell, SplitArgs doesn't expand variables or patterns and
+// has no operators. It returns an error for an unterminated quote.
+func SplitArgs(s string) ([]string, error) {
+	var args []string
+	var arg bytes.Buffer
+	inArg := false // arg holds an argument, possibly empty like ""
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case ' ', '\t', '\n', '\r':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		case '#':
+			if inArg {
+				arg.WriteByte(c)
+				continue
+			}
+			for i < len(s) && s[i] != '\n' {
+				i++
+			}
+		case '\\':
+			i++
+			if i == len(s) {
+				return nil, errors.New("unterminated backslash")
+			}
+			if s[i] != '\n' {
+				// A backslash and a newline continue the line.
+				arg.WriteByte(s[i])
+				inArg = true
+			}
+		case '\'':
+			end := i + 1
+			for end < len(s) && s[end] != '\'' {
+				end++
+			}
+			if end == len(s) {
+				return nil, errors.New("unterminated single quote")
+			}
+			arg.WriteString(s[i+1 : end])
+			inArg = true
+			i = end
+		case '"':
+			i++
+			for ; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' && i+1 < len(s) {
+					switch s[i+1] {
+					case '$', '`', '"', '\\':
+						i++
+					case '\n':
+						i++
+						continue
+					}
+				}
+				arg.WriteByte(s[i])
+			}
+			if i == len(s) {
+				return nil, errors.New("unterminated double quote")
+			}
+			inArg = true
+		default:
+			arg.WriteByte(c)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,43 @@
+package pflag
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{"  \t\n", nil},
+		{"-v --name=x", []string{"-v", "--name=x"}},
+		{`--name "John Doe"`, []string{"--name", "John Doe"}},
+		{`--name='a "b" \c'`, []string{`--name=a "b" \c`}},
+		{`"a\"b\\c\d\$"`, []string{`a"b\c\d$`}},
+		{`a\ b c\\d \'`, []string{"a b", `c\d`, "'"}},
+		{`'' ""`, []string{"", ""}},
+		{"a\\\nb", []string{"ab"}},
+		{"\"a\\\nb\"", []string{"ab"}},
+		{"-v # comment\n--x a#b", []string{"-v", "--x", "a#b"}},
+		{`x"y"'z'`, []string{"xyz"}},
+		{"$HOME", []string{"$HOME"}},
+	}
+	for _, test := range tests {
+		got, err := SplitArgs(test.in)
+		if err != nil {
+			t.Errorf("%q: %v", test.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%q: got %q, want %q", test.in, got, test.want)
+		}
+	}
+
+	for _, in := range []string{`'abc`, `"abc`, `abc\`, `"abc\"`} {
+		if _, err := SplitArgs(in); err == nil {
+			t.Errorf("%q: expected an error", in)
+		}
+	}
+}
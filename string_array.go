@@ -4,6 +4,7 @@ package pflag
 type stringArrayValue struct {
 	value   *[]string
 	changed bool
+	sliceOptions
 }
 
 func newStringArrayValue(val []string, p *[]string) *stringArrayValue {
@@ -14,12 +15,16 @@ func newStringArrayValue(val []string, p *[]string) *stringArrayValue {
 }
 
 func (s *stringArrayValue) Set(val string) error {
-	if !s.changed {
-		*s.value = []string{val}
-		s.changed = true
-	} else {
-		*s.value = append(*s.value, val)
+	v, err := s.split(val, func(val string) ([]string, error) {
+		return []string{val}, nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.assign(s.value, v, s.changed || s.appendDefault); err != nil {
+		return err
 	}
+	s.changed = true
 	return nil
 }
 
@@ -27,6 +32,26 @@ func (s *stringArrayValue) Type() string {
 	return "stringArray"
 }
 
+func (s *stringArrayValue) Append(val string) error {
+	if err := s.assign(s.value, []string{val}, true); err != nil {
+		return err
+	}
+	s.changed = true
+	return nil
+}
+
+func (s *stringArrayValue) Replace(val []string) error {
+	if err := s.assign(s.value, append([]string(nil), val...), false); err != nil {
+		return err
+	}
+	s.changed = true
+	return nil
+}
+
+func (s *stringArrayValue) GetSlice() []string {
+	return append([]string(nil), (*s.value)...)
+}
+
 func (s *stringArrayValue) String() string {
 	str, _ := writeAsCSV(*s.value)
 	return "[" + str + "]"
@@ -10,6 +10,7 @@ import (
 type stringSliceValue struct {
 	value   *[]string
 	changed bool
+	sliceOptions
 }
 
 func newStringSliceValue(val []string, p *[]string) *stringSliceValue {
@@ -29,6 +30,10 @@ func readAsCSV(val string) ([]string, error) {
 }
 
 func writeAsCSV(vals []string) (string, error) {
+	if len(vals) == 0 {
+		// Common for defaults; spares setting up a csv.Writer per flag.
+		return "", nil
+	}
 	b := &bytes.Buffer{}
 	w := csv.NewWriter(b)
 	err := w.Write(vals)
@@ -40,14 +45,12 @@ func writeAsCSV(vals []string) (string, error) {
 }
 
 func (s *stringSliceValue) Set(val string) error {
-	v, err := readAsCSV(val)
+	v, err := s.split(val, readAsCSV)
 	if err != nil {
 		return err
 	}
-	if !s.changed {
-		*s.value = v
-	} else {
-		*s.value = append(*s.value, v...)
+	if err := s.assign(s.value, v, s.changed || s.appendDefault); err != nil {
+		return err
 	}
 	s.changed = true
 	return nil
@@ -57,6 +60,26 @@ func (s *stringSliceValue) Type() string {
 	return "stringSlice"
 }
 
+func (s *stringSliceValue) Append(val string) error {
+	if err := s.assign(s.value, []string{val}, true); err != nil {
+		return err
+	}
+	s.changed = true
+	return nil
+}
+
+func (s *stringSliceValue) Replace(val []string) error {
+	if err := s.assign(s.value, append([]string(nil), val...), false); err != nil {
+		return err
+	}
+	s.changed = true
+	return nil
+}
+
+func (s *stringSliceValue) GetSlice() []string {
+	return append([]string(nil), (*s.value)...)
+}
+
 func (s *stringSliceValue) String() string {
 	str, _ := writeAsCSV(*s.value)
 	return "[" + str + "]"
@@ -0,0 +1,209 @@
+package pflag
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// -- stringToString Value
+type stringToStringValue struct {
+	value   *map[string]string
+	changed bool
+	pairSep rune // separator of the pairs, if sepSet, see SetMapSeparators
+	kvSep   rune // separator of the keys and values, if sepSet
+	sepSet  bool
+}
+
+func newStringToStringValue(val map[string]string, p *map[string]string) *stringToStringValue {
+	ssv := new(stringToStringValue)
+	ssv.value = p
+	*ssv.value = val
+	return ssv
+}
+
+// readPairs reads the key=value pairs of val, which are comma separated and
+// quoted as in CSV files if there are several.
+func readPairs(val string) (map[string]string, error) {
+	var ss []string
+	switch strings.Count(val, "=") {
+	case 0:
+		return nil, fmt.Errorf("%s must be formatted as key=value", val)
+	case 1:
+		ss = append(ss, strings.Trim(val, `"`))
+	default:
+		var err error
+		ss, err = readAsCSV(val)
+		if err != nil {
+			return nil, err
+		}
+	}
+	out := make(map[string]string, len(ss))
+	for _, pair := range ss {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("%s must be formatted as key=value", pair)
+		}
+		out[kv[0]] = kv[1]
+	}
+	return out, nil
+}
+
+// readSeparatedPairs reads the pairs of val with the separators set by
+// SetMapSeparators.
+func (s *stringToStringValue) readSeparatedPairs(val string) (map[string]string, error) {
+	ss := []string{val}
+	if s.pairSep != 0 {
+		var err error
+		if ss, err = splitEscaped(val, s.pairSep); err != nil {
+			return nil, err
+		}
+	}
+	out := make(map[string]string, len(ss))
+	for _, pair := range ss {
+		i := strings.IndexRune(pair, s.kvSep)
+		if i < 0 {
+			return nil, fmt.Errorf("%s must be formatted as key%cvalue", pair, s.kvSep)
+		}
+		out[pair[:i]] = pair[i+len(string(s.kvSep)):]
+	}
+	return out, nil
+}
+
+// Format: a=1,b=2
+func (s *stringToStringValue) Set(val string) error {
+	var out map[string]string
+	var err error
+	if s.sepSet {
+		out, err = s.readSeparatedPairs(val)
+	} else {
+		out, err = readPairs(val)
+	}
+	if err != nil {
+		return err
+	}
+	if !s.changed || *s.value == nil {
+		*s.value = out
+	} else {
+		for k, v := range out {
+			(*s.value)[k] = v
+		}
+	}
+	s.changed = true
+	return nil
+}
+
+func (s *stringToStringValue) Type() string {
+	return "stringToString"
+}
+
+// String returns the pairs sorted by key, so that the default value shown in
+// usage messages is stable.
+func (s *stringToStringValue) String() string {
+	keys := make([]string, 0, len(*s.value))
+	for k := range *s.value {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	records := make([]string, len(keys))
+	for i, k := range keys {
+		records[i] = k + "=" + (*s.value)[k]
+	}
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write(records)
+	w.Flush()
+	return "[" + strings.TrimSpace(buf.String()) + "]"
+}
+
+func stringToStringConv(val string) (interface{}, error) {
+	val = strings.Trim(val, "[]")
+	// An empty string would cause an empty map
+	if len(val) == 0 {
+		return map[string]string{}, nil
+	}
+	return readPairs(val)
+}
+
+// GetStringToString return the map[string]string value of a flag with the given name
+func (f *FlagSet) GetStringToString(name string) (map[string]string, error) {
+	val, err := f.getFlagType(name, "stringToString", stringToStringConv)
+	if err != nil {
+		return map[string]string{}, err
+	}
+	return val.(map[string]string), nil
+}
+
+// SetMapSeparators sets the separators of the pairs, and of the keys and
+// values, given in a value of the named map flag, e.g. ';' and ':' for
+// --header "Accept:a=b;Host:x". Pair separators can be part of the values by
+// quoting them like the elements of slices, see SetSliceSeparator, and only
+// the first key separator of a pair splits it. With a zero pairSep, every
+// value is a single pair. By default, pairs are separated by commas and keys
+// by '='. The value of the flag is printed with the default separators
+// regardless.
+func (f *FlagSet) SetMapSeparators(name string, pairSep, kvSep rune) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	v, ok := flag.Value.(*stringToStringValue)
+	if !ok {
+		return fmt.Errorf("flag %q is not a map", name)
+	}
+	if kvSep == 0 {
+		return fmt.Errorf("flag %q needs a key separator", name)
+	}
+	v.pairSep, v.kvSep, v.sepSet = pairSep, kvSep, true
+	return nil
+}
+
+// StringToStringVar defines a string flag with specified name, default value, and usage string.
+// The argument p points to a map[string]string variable in which to store the value of the flag.
+func (f *FlagSet) StringToStringVar(p *map[string]string, name string, value map[string]string, usage string) {
+	f.VarP(newStringToStringValue(value, p), name, "", usage)
+}
+
+// StringToStringVarP is like StringToStringVar, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) StringToStringVarP(p *map[string]string, name, shorthand string, value map[string]string, usage string) {
+	f.VarP(newStringToStringValue(value, p), name, shorthand, usage)
+}
+
+// StringToStringVar defines a string flag with specified name, default value, and usage string.
+// The argument p points to a map[string]string variable in which to store the value of the flag.
+func StringToStringVar(p *map[string]string, name string, value map[string]string, usage string) {
+	CommandLine.VarP(newStringToStringValue(value, p), name, "", usage)
+}
+
+// StringToStringVarP is like StringToStringVar, but accepts a shorthand letter that can be used after a single dash.
+func StringToStringVarP(p *map[string]string, name, shorthand string, value map[string]string, usage string) {
+	CommandLine.VarP(newStringToStringValue(value, p), name, shorthand, usage)
+}
+
+// StringToString defines a string flag with specified name, default value, and usage string.
+// The return value is the address of a map[string]string variable that stores the value of the flag.
+func (f *FlagSet) StringToString(name string, value map[string]string, usage string) *map[string]string {
+	p := map[string]string{}
+	f.StringToStringVarP(&p, name, "", value, usage)
+	return &p
+}
+
+// StringToStringP is like StringToString, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) StringToStringP(name, shorthand string, value map[string]string, usage string) *map[string]string {
+	p := map[string]string{}
+	f.StringToStringVarP(&p, name, shorthand, value, usage)
+	return &p
+}
+
+// StringToString defines a string flag with specified name, default value, and usage string.
+// The return value is the address of a map[string]string variable that stores the value of the flag.
+func StringToString(name string, value map[string]string, usage string) *map[string]string {
+	return CommandLine.StringToStringP(name, "", value, usage)
+}
+
+// StringToStringP is like StringToString, but accepts a shorthand letter that can be used after a single dash.
+func StringToStringP(name, shorthand string, value map[string]string, usage string) *map[string]string {
+	return CommandLine.StringToStringP(name, shorthand, value, usage)
+}
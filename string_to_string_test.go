@@ -0,0 +1,79 @@
+package pflag
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStringToString(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	labels := f.StringToString("label", map[string]string{"b": "2", "a": "1"}, "")
+	if got := f.Lookup("label").DefValue; got != "[a=1,b=2]" {
+		t.Errorf("got default %s", got)
+	}
+	if err := f.Parse([]string{"--label", "x=1,y=2", "--label", "url=http://h/?q=1", "--label", `"z=a,b"`}); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"x": "1", "y": "2", "url": "http://h/?q=1", "z": "a,b"}
+	if !reflect.DeepEqual(*labels, want) {
+		t.Errorf("got %v, want %v", *labels, want)
+	}
+	if m, err := f.GetStringToString("label"); err != nil || !reflect.DeepEqual(m, want) {
+		t.Errorf("GetStringToString returned %v, %v", m, err)
+	}
+	if err := f.Set("label", "nokey"); err == nil {
+		t.Error("expected an error for a pair without '='")
+	}
+
+	c, err := f.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Reset()
+	if !reflect.DeepEqual(*labels, want) {
+		t.Error("resetting the clone changed the original")
+	}
+	if err := c.Parse(f.ToArgs(true)); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := c.GetStringToString("label"); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v after ToArgs, want %v", got, want)
+	}
+}
+
+func TestMapSeparators(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	headers := f.StringToString("header", nil, "")
+	links := f.StringToString("link", nil, "")
+	f.Int("n", 0, "")
+	f.SetMapSeparators("header", ';', ':')
+	f.SetMapSeparators("link", 0, '=')
+	if err := f.SetMapSeparators("n", ',', '='); err == nil {
+		t.Error("expected an error for a flag which is not a map")
+	}
+
+	args := []string{"--header", `Accept:a=b,c;Host:x\;y`, "--link", "home=http://h/?a=1,b=2"}
+	if err := f.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"Accept": "a=b,c", "Host": "x;y"}; !reflect.DeepEqual(*headers, want) {
+		t.Errorf("got headers %v, want %v", *headers, want)
+	}
+	if want := map[string]string{"home": "http://h/?a=1,b=2"}; !reflect.DeepEqual(*links, want) {
+		t.Errorf("got links %v, want %v", *links, want)
+	}
+
+	c, err := f.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Reset()
+	if err := c.Parse(f.ToArgs(true)); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"header", "link"} {
+		if got, want := c.Lookup(name).Value.String(), f.Lookup(name).Value.String(); got != want {
+			t.Errorf("--%s: got %s after ToArgs, want %s", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package pflag
+
+import "sync"
+
+// SyncFlagSet wraps a FlagSet so that it can be used from several goroutines,
+// e.g. by a daemon changing its log level at runtime while other goroutines
+// read it. All access to the flags, including reading the variables they are
+// bound to, must go through the SyncFlagSet for it to be safe.
+type SyncFlagSet struct {
+	mu sync.RWMutex
+	fs *FlagSet
+}
+
+// NewSyncFlagSet returns a SyncFlagSet wrapping fs.
+func NewSyncFlagSet(fs *FlagSet) *SyncFlagSet {
+	return &SyncFlagSet{fs: fs}
+}
+
+// Parse parses the arguments like FlagSet.Parse.
+func (s *SyncFlagSet) Parse(arguments []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fs.Parse(arguments)
+}
+
+// Set sets the value of the named flag like FlagSet.Set.
+func (s *SyncFlagSet) Set(name, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fs.Set(name, value)
+}
+
+// Value returns the string representation of the value of the named flag,
+// and false if there is no such flag.
+func (s *SyncFlagSet) Value(name string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	flag := s.fs.Lookup(name)
+	if flag == nil {
+		return "", false
+	}
+	return flag.Value.String(), true
+}
+
+// Changed returns true if the named flag was set, like FlagSet.Changed.
+func (s *SyncFlagSet) Changed(name string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.fs.Changed(name)
+}
+
+// Read calls fn with the wrapped FlagSet while holding the lock for reading.
+// fn may read flags and their values (e.g. with Lookup, GetInt or VisitAll),
+// but must not modify them. Note that the defaults set with SetLazyDefault
+// are still resolved and stored in the flags when first needed, e.g. by
+// FlagUsages, so calls which may resolve them must go through Write.
+func (s *SyncFlagSet) Read(fn func(fs *FlagSet)) {
+	s.mu.RLock()
+	for !s.fs.cachesBuilt() {
+		// Set may reset the caches between the two locks, so check again.
+		s.mu.RUnlock()
+		s.mu.Lock()
+		s.fs.buildCaches()
+		s.mu.Unlock()
+		s.mu.RLock()
+	}
+	defer s.mu.RUnlock()
+	fn(s.fs)
+}
+
+// cachesBuilt returns true if the caches which reading the FlagSet fills in,
+// the sorted flags and the index of their names, are up to date.
+func (f *FlagSet) cachesBuilt() bool {
+	return f.sortedFormal != nil && f.sortedActual != nil && f.names != nil
+}
+
+// buildCaches fills in the caches of the FlagSet, so that concurrent readers
+// don't have to.
+func (f *FlagSet) buildCaches() {
+	f.sortedFormalFlags()
+	if f.sortedActual == nil {
+		f.sortedActual = f.sortFlags(f.actual)
+	}
+	f.nameIndex()
+}
+
+// Write calls fn with the wrapped FlagSet while holding the lock for writing.
+func (s *SyncFlagSet) Write(fn func(fs *FlagSet)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(s.fs)
+}
@@ -0,0 +1,39 @@
+package pflag
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSyncFlagSet(t *testing.T) {
+	fs := NewFlagSet("TestSyncFlagSet", ContinueOnError)
+	fs.String("log-level", "info", "")
+	s := NewSyncFlagSet(fs)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			s.Set("log-level", "debug")
+		}()
+		go func() {
+			defer wg.Done()
+			s.Value("log-level")
+			s.Read(func(fs *FlagSet) {
+				fs.GetString("log-level")
+				fs.VisitAll(func(*Flag) {})
+				fs.Visit(func(*Flag) {})
+				fs.FlagsWithPrefix("log")
+			})
+		}()
+	}
+	wg.Wait()
+
+	if v, ok := s.Value("log-level"); !ok || v != "debug" || !s.Changed("log-level") {
+		t.Errorf("unexpected value %q", v)
+	}
+	if _, ok := s.Value("nope"); ok {
+		t.Error("expected no value for an undefined flag")
+	}
+}
@@ -0,0 +1,140 @@
+package pflag
+
+import (
+	"fmt"
+	"text/template"
+)
+
+// -- text/template Value
+type templateValue struct {
+	value **template.Template
+	text  string
+	name  string
+	funcs template.FuncMap
+}
+
+func newTemplateValue(val string, p **template.Template, name string) *templateValue {
+	tv := &templateValue{value: p, name: name}
+	if err := tv.Set(val); err != nil {
+		panic(fmt.Sprintf("default value of flag --%s: %v", name, err))
+	}
+	return tv
+}
+
+// parse parses text as a template named after the flag, or returns nil if
+// text is empty.
+func (t *templateValue) parse(text string) (*template.Template, error) {
+	if text == "" {
+		return nil, nil
+	}
+	return template.New(t.name).Funcs(t.funcs).Parse(text)
+}
+
+func (t *templateValue) Set(val string) error {
+	tmpl, err := t.parse(val)
+	if err != nil {
+		return err
+	}
+	*t.value, t.text = tmpl, val
+	return nil
+}
+
+func (t *templateValue) Type() string {
+	return "template"
+}
+
+func (t *templateValue) String() string { return t.text }
+
+// SetTemplateFuncs sets the functions the templates given to the named
+// template flag can call, in addition to the predefined ones of text/template.
+// The current template is parsed again with them.
+func (f *FlagSet) SetTemplateFuncs(name string, funcs template.FuncMap) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	t, ok := flag.Value.(*templateValue)
+	if !ok {
+		return fmt.Errorf("flag %q is not a template", name)
+	}
+	t.funcs = funcs
+	return t.Set(t.text)
+}
+
+// GetTemplate return the *template.Template value of a flag with the given name,
+// or nil if it is empty
+func (f *FlagSet) GetTemplate(name string) (*template.Template, error) {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return nil, fmt.Errorf("flag accessed but not defined: %s", name)
+	}
+	t, ok := flag.Value.(*templateValue)
+	if !ok {
+		return nil, fmt.Errorf("trying to get template value of flag of type %s", flag.Value.Type())
+	}
+	return *t.value, nil
+}
+
+// TemplateVar defines a text/template flag with specified name, default value, and usage string.
+// The value is the text of a template, parsed when the flag is set so that syntax errors are
+// reported as invalid arguments; the default value must be valid. Use AllowFileValue to let the
+// template be read from a file, as in --format=@report.tmpl.
+// The argument p points to a *template.Template variable in which to store the value of the flag,
+// nil if the text is empty.
+func (f *FlagSet) TemplateVar(p **template.Template, name string, value string, usage string) {
+	f.VarP(newTemplateValue(value, p, name), name, "", usage)
+}
+
+// TemplateVarP is like TemplateVar, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) TemplateVarP(p **template.Template, name, shorthand string, value string, usage string) {
+	f.VarP(newTemplateValue(value, p, name), name, shorthand, usage)
+}
+
+// TemplateVar defines a text/template flag with specified name, default value, and usage string.
+// The value is the text of a template, parsed when the flag is set so that syntax errors are
+// reported as invalid arguments; the default value must be valid. Use AllowFileValue to let the
+// template be read from a file, as in --format=@report.tmpl.
+// The argument p points to a *template.Template variable in which to store the value of the flag,
+// nil if the text is empty.
+func TemplateVar(p **template.Template, name string, value string, usage string) {
+	CommandLine.VarP(newTemplateValue(value, p, name), name, "", usage)
+}
+
+// TemplateVarP is like TemplateVar, but accepts a shorthand letter that can be used after a single dash.
+func TemplateVarP(p **template.Template, name, shorthand string, value string, usage string) {
+	CommandLine.VarP(newTemplateValue(value, p, name), name, shorthand, usage)
+}
+
+// Template defines a text/template flag with specified name, default value, and usage string.
+// The value is the text of a template, parsed when the flag is set so that syntax errors are
+// reported as invalid arguments; the default value must be valid. Use AllowFileValue to let the
+// template be read from a file, as in --format=@report.tmpl.
+// The return value is the address of a *template.Template variable that stores the value of the flag,
+// nil if the text is empty.
+func (f *FlagSet) Template(name string, value string, usage string) **template.Template {
+	p := new(*template.Template)
+	f.TemplateVarP(p, name, "", value, usage)
+	return p
+}
+
+// TemplateP is like Template, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) TemplateP(name, shorthand string, value string, usage string) **template.Template {
+	p := new(*template.Template)
+	f.TemplateVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// Template defines a text/template flag with specified name, default value, and usage string.
+// The value is the text of a template, parsed when the flag is set so that syntax errors are
+// reported as invalid arguments; the default value must be valid. Use AllowFileValue to let the
+// template be read from a file, as in --format=@report.tmpl.
+// The return value is the address of a *template.Template variable that stores the value of the flag,
+// nil if the text is empty.
+func Template(name string, value string, usage string) **template.Template {
+	return CommandLine.TemplateP(name, "", value, usage)
+}
+
+// TemplateP is like Template, but accepts a shorthand letter that can be used after a single dash.
+func TemplateP(name, shorthand string, value string, usage string) **template.Template {
+	return CommandLine.TemplateP(name, shorthand, value, usage)
+}
@@ -0,0 +1,84 @@
+package pflag
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestTemplate(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	format := f.TemplateP("format", "f", "{{.Name}}", "output `template`")
+	if *format == nil || (*format).Name() != "format" {
+		t.Fatalf("got default template %v", *format)
+	}
+	if err := f.Parse([]string{"-f", "{{.Name}}: {{.Size}}"}); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := (*format).Execute(&buf, struct {
+		Name string
+		Size int
+	}{"a.txt", 3}); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "a.txt: 3" {
+		t.Errorf("got %q", got)
+	}
+	if got, err := f.GetTemplate("format"); err != nil || got != *format {
+		t.Errorf("GetTemplate returned %v, %v", got, err)
+	}
+
+	err := f.Parse([]string{"--format", "{{.Name"})
+	if err == nil || !strings.Contains(err.Error(), `invalid argument "{{.Name" for "-f, --format" flag: template: format:1:`) {
+		t.Errorf("got error %v", err)
+	}
+	if err := f.Set("format", "{{upper .Name}}"); err == nil {
+		t.Error("expected an error for an undefined function")
+	}
+	if err := f.SetTemplateFuncs("format", template.FuncMap{"upper": strings.ToUpper}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Set("format", "{{upper .Name}}"); err != nil {
+		t.Error(err)
+	}
+	if err := f.Set("format", ""); err != nil || *format != nil {
+		t.Errorf("got %v, %v for an empty template", *format, err)
+	}
+	if usage, want := f.FlagUsages(), "  -f, --format template   output template (default {{.Name}})\n"; usage != want {
+		t.Errorf("got usage %q, want %q", usage, want)
+	}
+}
+
+func TestTemplateFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pflag")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "report.tmpl")
+	if err := ioutil.WriteFile(path, []byte("{{range .}}{{.}}\n{{end}}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	f := NewFlagSet("test", ContinueOnError)
+	format := f.Template("format", "", "")
+	if err := f.AllowFileValue("format"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Parse([]string{"--format=@" + path}); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := (*format).Execute(&buf, []string{"a", "b"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "a\nb\n" {
+		t.Errorf("got %q", got)
+	}
+}
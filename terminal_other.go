@@ -0,0 +1,24 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package pflag
+
+import "errors"
+
+// terminalWidth returns the number of columns of the terminal fd refers to.
+// Terminals are not detected on this platform, so it always returns 0.
+func terminalWidth(fd uintptr) int {
+	return 0
+}
+
+// isTerminal returns true if fd refers to a terminal. Terminals are not
+// detected on this platform, so it always returns false.
+func isTerminal(fd uintptr) bool {
+	return false
+}
+
+// disableEcho turns off the echo of the input of the terminal fd refers to.
+// It is not supported on this platform.
+func disableEcho(fd uintptr) (restore func(), err error) {
+	return nil, errors.New("disabling terminal echo is not supported on this platform")
+}
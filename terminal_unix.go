@@ -0,0 +1,54 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package pflag
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+type winsize struct {
+	Row, Col, Xpixel, Ypixel uint16
+}
+
+// getWinsize returns the size of the terminal fd refers to, and false if fd
+// is not a terminal.
+func getWinsize(fd uintptr) (winsize, bool) {
+	var ws winsize
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	return ws, errno == 0
+}
+
+// terminalWidth returns the number of columns of the terminal fd refers to,
+// or 0 if fd is not a terminal.
+func terminalWidth(fd uintptr) int {
+	ws, ok := getWinsize(fd)
+	if !ok {
+		return 0
+	}
+	return int(ws.Col)
+}
+
+// isTerminal returns true if fd refers to a terminal.
+func isTerminal(fd uintptr) bool {
+	_, ok := getWinsize(fd)
+	return ok
+}
+
+// disableEcho turns off the echo of the input of the terminal fd refers to,
+// and returns a function restoring it.
+func disableEcho(fd uintptr) (restore func(), err error) {
+	var old syscall.Termios
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, ioctlGetTermios, uintptr(unsafe.Pointer(&old))); errno != 0 {
+		return nil, errno
+	}
+	termios := old
+	termios.Lflag &^= syscall.ECHO
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, ioctlSetTermios, uintptr(unsafe.Pointer(&termios))); errno != 0 {
+		return nil, errno
+	}
+	return func() {
+		syscall.Syscall(syscall.SYS_IOCTL, fd, ioctlSetTermios, uintptr(unsafe.Pointer(&old)))
+	}, nil
+}
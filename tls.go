@@ -0,0 +1,343 @@
+package pflag
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// versionTLS13 is tls.VersionTLS13, which is missing before Go 1.12.
+const versionTLS13 = 0x0304
+
+// tlsVersions maps the TLS versions, as in "1.2", to their crypto/tls
+// constants.
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": versionTLS13,
+}
+
+// parseTLSVersion parses a TLS version, with or without a "TLS" prefix and
+// in any case, as in "1.2", "TLS1.3" or "tlsv1.2".
+func parseTLSVersion(s string) (uint16, error) {
+	v := strings.ToLower(strings.TrimSpace(s))
+	v = strings.TrimPrefix(v, "tls")
+	v = strings.TrimLeft(strings.TrimPrefix(strings.TrimLeft(v, " _-"), "v"), " ")
+	if version, ok := tlsVersions[strings.Replace(v, "_", ".", -1)]; ok {
+		return version, nil
+	}
+	return 0, fmt.Errorf("unknown TLS version %q, it should be one of 1.0, 1.1, 1.2, 1.3", s)
+}
+
+// -- TLS version Value
+type tlsVersionValue uint16
+
+func newTLSVersionValue(val uint16, p *uint16) *tlsVersionValue {
+	*p = val
+	return (*tlsVersionValue)(p)
+}
+
+func (v *tlsVersionValue) Set(s string) error {
+	version, err := parseTLSVersion(s)
+	if err != nil {
+		return err
+	}
+	*v = tlsVersionValue(version)
+	return nil
+}
+
+func (v *tlsVersionValue) Type() string {
+	return "tlsVersion"
+}
+
+func (v *tlsVersionValue) String() string {
+	if *v == 0 {
+		return ""
+	}
+	for name, version := range tlsVersions {
+		if uint16(*v) == version {
+			return name
+		}
+	}
+	return fmt.Sprintf("0x%04x", uint16(*v))
+}
+
+func tlsVersionConv(sval string) (interface{}, error) {
+	if sval == "" {
+		return uint16(0), nil
+	}
+	return parseTLSVersion(sval)
+}
+
+// GetTLSVersion return the crypto/tls version constant of a flag with the given name
+func (f *FlagSet) GetTLSVersion(name string) (uint16, error) {
+	val, err := f.getFlagType(name, "tlsVersion", tlsVersionConv)
+	if err != nil {
+		return 0, err
+	}
+	return val.(uint16), nil
+}
+
+// TLSVersionVar defines a TLS version flag with specified name, default value, and usage string.
+// The value is given as in "1.2" or "TLS1.3" and stored as a crypto/tls constant, like
+// tls.VersionTLS12, which can be used in tls.Config.MinVersion; 0 is no version.
+// The argument p points to a uint16 variable in which to store the value of the flag.
+func (f *FlagSet) TLSVersionVar(p *uint16, name string, value uint16, usage string) {
+	f.VarP(newTLSVersionValue(value, p), name, "", usage)
+}
+
+// TLSVersionVarP is like TLSVersionVar, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) TLSVersionVarP(p *uint16, name, shorthand string, value uint16, usage string) {
+	f.VarP(newTLSVersionValue(value, p), name, shorthand, usage)
+}
+
+// TLSVersionVar defines a TLS version flag with specified name, default value, and usage string.
+// The value is given as in "1.2" or "TLS1.3" and stored as a crypto/tls constant, like
+// tls.VersionTLS12, which can be used in tls.Config.MinVersion; 0 is no version.
+// The argument p points to a uint16 variable in which to store the value of the flag.
+func TLSVersionVar(p *uint16, name string, value uint16, usage string) {
+	CommandLine.VarP(newTLSVersionValue(value, p), name, "", usage)
+}
+
+// TLSVersionVarP is like TLSVersionVar, but accepts a shorthand letter that can be used after a single dash.
+func TLSVersionVarP(p *uint16, name, shorthand string, value uint16, usage string) {
+	CommandLine.VarP(newTLSVersionValue(value, p), name, shorthand, usage)
+}
+
+// TLSVersion defines a TLS version flag with specified name, default value, and usage string.
+// The value is given as in "1.2" or "TLS1.3" and stored as a crypto/tls constant, like
+// tls.VersionTLS12, which can be used in tls.Config.MinVersion; 0 is no version.
+// The return value is the address of a uint16 variable that stores the value of the flag.
+func (f *FlagSet) TLSVersion(name string, value uint16, usage string) *uint16 {
+	p := new(uint16)
+	f.TLSVersionVarP(p, name, "", value, usage)
+	return p
+}
+
+// TLSVersionP is like TLSVersion, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) TLSVersionP(name, shorthand string, value uint16, usage string) *uint16 {
+	p := new(uint16)
+	f.TLSVersionVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// TLSVersion defines a TLS version flag with specified name, default value, and usage string.
+// The value is given as in "1.2" or "TLS1.3" and stored as a crypto/tls constant, like
+// tls.VersionTLS12, which can be used in tls.Config.MinVersion; 0 is no version.
+// The return value is the address of a uint16 variable that stores the value of the flag.
+func TLSVersion(name string, value uint16, usage string) *uint16 {
+	return CommandLine.TLSVersionP(name, "", value, usage)
+}
+
+// TLSVersionP is like TLSVersion, but accepts a shorthand letter that can be used after a single dash.
+func TLSVersionP(name, shorthand string, value uint16, usage string) *uint16 {
+	return CommandLine.TLSVersionP(name, shorthand, value, usage)
+}
+
+// parseCipherSuite parses the name of a cipher suite, in any case, as in
+// "TLS_AES_128_GCM_SHA256", or its ID in hexadecimal, as in "0x1301". Suites
+// crypto/tls considers insecure are rejected unless insecure is true.
+func parseCipherSuite(s string, insecure bool) (uint16, error) {
+	name := strings.ToUpper(strings.TrimSpace(s))
+	id, known, secure := uint16(0), false, false
+	if strings.HasPrefix(name, "0X") {
+		n, err := strconv.ParseUint(name[2:], 16, 16)
+		if err != nil {
+			return 0, fmt.Errorf("invalid cipher suite ID %q", s)
+		}
+		id = uint16(n)
+		known, secure = cipherSuiteByID(id)
+	} else {
+		id, known, secure = cipherSuiteByName(name)
+	}
+	if !known {
+		return 0, fmt.Errorf("unknown cipher suite %q", s)
+	}
+	if !secure && !insecure {
+		return 0, fmt.Errorf("cipher suite %s is insecure", cipherSuiteName(id))
+	}
+	return id, nil
+}
+
+// -- tlsCipherSuiteSlice Value
+type tlsCipherSuitesValue struct {
+	value   *[]uint16
+	changed bool
+	sliceOptions
+	// insecure allows the suites crypto/tls considers insecure, see
+	// SetAllowInsecureCipherSuites.
+	insecure bool
+}
+
+func newTLSCipherSuitesValue(val []uint16, p *[]uint16) *tlsCipherSuitesValue {
+	csv := new(tlsCipherSuitesValue)
+	csv.value = p
+	*csv.value = val
+	return csv
+}
+
+func (s *tlsCipherSuitesValue) Set(val string) error {
+	ss, err := s.split(val, splitComma)
+	if err != nil {
+		return err
+	}
+	out := make([]uint16, len(ss))
+	for i, d := range ss {
+		if out[i], err = parseCipherSuite(d, s.insecure); err != nil {
+			return err
+		}
+	}
+	if err := s.assign(s.value, out, s.changed || s.appendDefault); err != nil {
+		return err
+	}
+	s.changed = true
+	return nil
+}
+
+func (s *tlsCipherSuitesValue) Type() string {
+	return "tlsCipherSuiteSlice"
+}
+
+func (s *tlsCipherSuitesValue) Append(val string) error {
+	id, err := parseCipherSuite(val, s.insecure)
+	if err != nil {
+		return err
+	}
+	if err := s.assign(s.value, []uint16{id}, true); err != nil {
+		return err
+	}
+	s.changed = true
+	return nil
+}
+
+func (s *tlsCipherSuitesValue) Replace(val []string) error {
+	out := make([]uint16, len(val))
+	for i, d := range val {
+		var err error
+		if out[i], err = parseCipherSuite(d, s.insecure); err != nil {
+			return err
+		}
+	}
+	if err := s.assign(s.value, out, false); err != nil {
+		return err
+	}
+	s.changed = true
+	return nil
+}
+
+func (s *tlsCipherSuitesValue) GetSlice() []string {
+	out := make([]string, len(*s.value))
+	for i, id := range *s.value {
+		out[i] = cipherSuiteName(id)
+	}
+	return out
+}
+
+func (s *tlsCipherSuitesValue) String() string {
+	return "[" + strings.Join(s.GetSlice(), ",") + "]"
+}
+
+func tlsCipherSuitesConv(val string) (interface{}, error) {
+	val = strings.Trim(val, "[]")
+	// Empty string would cause a slice with one (empty) entry
+	if len(val) == 0 {
+		return []uint16{}, nil
+	}
+	ss := strings.Split(val, ",")
+	out := make([]uint16, len(ss))
+	for i, d := range ss {
+		var err error
+		// The value was checked when set.
+		if out[i], err = parseCipherSuite(d, true); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// SetAllowInsecureCipherSuites sets whether the named cipher suite flag
+// accepts the suites crypto/tls considers insecure, which it rejects by
+// default.
+func (f *FlagSet) SetAllowInsecureCipherSuites(name string, allow bool) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	v, ok := flag.Value.(*tlsCipherSuitesValue)
+	if !ok {
+		return fmt.Errorf("flag %q is not a cipher suite flag", name)
+	}
+	v.insecure = allow
+	return nil
+}
+
+// GetTLSCipherSuites returns the cipher suite IDs of a flag with the given name.
+func (f *FlagSet) GetTLSCipherSuites(name string) ([]uint16, error) {
+	val, err := f.getFlagType(name, "tlsCipherSuiteSlice", tlsCipherSuitesConv)
+	if err != nil {
+		return []uint16{}, err
+	}
+	return val.([]uint16), nil
+}
+
+// TLSCipherSuitesVar defines a cipher suite slice flag with specified name, default value, and usage string.
+// The suites are given by name, as in "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", or ID, as in "0xc02f",
+// and stored as IDs which can be used in tls.Config.CipherSuites. Insecure suites are rejected,
+// see SetAllowInsecureCipherSuites.
+// The argument p points to a []uint16 variable in which to store the value of the flag.
+func (f *FlagSet) TLSCipherSuitesVar(p *[]uint16, name string, value []uint16, usage string) {
+	f.VarP(newTLSCipherSuitesValue(value, p), name, "", usage)
+}
+
+// TLSCipherSuitesVarP is like TLSCipherSuitesVar, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) TLSCipherSuitesVarP(p *[]uint16, name, shorthand string, value []uint16, usage string) {
+	f.VarP(newTLSCipherSuitesValue(value, p), name, shorthand, usage)
+}
+
+// TLSCipherSuitesVar defines a cipher suite slice flag with specified name, default value, and usage string.
+// The suites are given by name, as in "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", or ID, as in "0xc02f",
+// and stored as IDs which can be used in tls.Config.CipherSuites. Insecure suites are rejected,
+// see SetAllowInsecureCipherSuites.
+// The argument p points to a []uint16 variable in which to store the value of the flag.
+func TLSCipherSuitesVar(p *[]uint16, name string, value []uint16, usage string) {
+	CommandLine.VarP(newTLSCipherSuitesValue(value, p), name, "", usage)
+}
+
+// TLSCipherSuitesVarP is like TLSCipherSuitesVar, but accepts a shorthand letter that can be used after a single dash.
+func TLSCipherSuitesVarP(p *[]uint16, name, shorthand string, value []uint16, usage string) {
+	CommandLine.VarP(newTLSCipherSuitesValue(value, p), name, shorthand, usage)
+}
+
+// TLSCipherSuites defines a cipher suite slice flag with specified name, default value, and usage string.
+// The suites are given by name, as in "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", or ID, as in "0xc02f",
+// and stored as IDs which can be used in tls.Config.CipherSuites. Insecure suites are rejected,
+// see SetAllowInsecureCipherSuites.
+// The return value is the address of a []uint16 variable that stores the value of the flag.
+func (f *FlagSet) TLSCipherSuites(name string, value []uint16, usage string) *[]uint16 {
+	p := []uint16{}
+	f.TLSCipherSuitesVarP(&p, name, "", value, usage)
+	return &p
+}
+
+// TLSCipherSuitesP is like TLSCipherSuites, but accepts a shorthand letter that can be used after a single dash.
+func (f *FlagSet) TLSCipherSuitesP(name, shorthand string, value []uint16, usage string) *[]uint16 {
+	p := []uint16{}
+	f.TLSCipherSuitesVarP(&p, name, shorthand, value, usage)
+	return &p
+}
+
+// TLSCipherSuites defines a cipher suite slice flag with specified name, default value, and usage string.
+// The suites are given by name, as in "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", or ID, as in "0xc02f",
+// and stored as IDs which can be used in tls.Config.CipherSuites. Insecure suites are rejected,
+// see SetAllowInsecureCipherSuites.
+// The return value is the address of a []uint16 variable that stores the value of the flag.
+func TLSCipherSuites(name string, value []uint16, usage string) *[]uint16 {
+	return CommandLine.TLSCipherSuitesP(name, "", value, usage)
+}
+
+// TLSCipherSuitesP is like TLSCipherSuites, but accepts a shorthand letter that can be used after a single dash.
+func TLSCipherSuitesP(name, shorthand string, value []uint16, usage string) *[]uint16 {
+	return CommandLine.TLSCipherSuitesP(name, shorthand, value, usage)
+}
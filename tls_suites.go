@@ -0,0 +1,44 @@
+//go:build go1.14
+// +build go1.14
+
+package pflag
+
+import "crypto/tls"
+
+// cipherSuiteByName returns the ID of the cipher suite name in upper case,
+// and whether it is known and secure according to crypto/tls.
+func cipherSuiteByName(name string) (id uint16, known, secure bool) {
+	for _, s := range tls.CipherSuites() {
+		if s.Name == name {
+			return s.ID, true, true
+		}
+	}
+	for _, s := range tls.InsecureCipherSuites() {
+		if s.Name == name {
+			return s.ID, true, false
+		}
+	}
+	return 0, false, false
+}
+
+// cipherSuiteByID returns whether the cipher suite id is known and secure
+// according to crypto/tls.
+func cipherSuiteByID(id uint16) (known, secure bool) {
+	for _, s := range tls.CipherSuites() {
+		if s.ID == id {
+			return true, true
+		}
+	}
+	for _, s := range tls.InsecureCipherSuites() {
+		if s.ID == id {
+			return true, false
+		}
+	}
+	return false, false
+}
+
+// cipherSuiteName returns the name of the cipher suite id, or its ID in
+// hexadecimal if it is unknown.
+func cipherSuiteName(id uint16) string {
+	return tls.CipherSuiteName(id)
+}
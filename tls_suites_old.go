@@ -0,0 +1,24 @@
+//go:build !go1.14
+// +build !go1.14
+
+package pflag
+
+import "fmt"
+
+// cipherSuiteByName returns the ID of the cipher suite name in upper case,
+// and whether it is known and secure. crypto/tls lists its cipher suites
+// from Go 1.14 onwards only, so suites must be given by ID before.
+func cipherSuiteByName(name string) (id uint16, known, secure bool) {
+	return 0, false, false
+}
+
+// cipherSuiteByID returns whether the cipher suite id is known and secure.
+// Without the lists of crypto/tls, all suites are taken as known and secure.
+func cipherSuiteByID(id uint16) (known, secure bool) {
+	return true, true
+}
+
+// cipherSuiteName returns the ID of the cipher suite id in hexadecimal.
+func cipherSuiteName(id uint16) string {
+	return fmt.Sprintf("0x%04X", id)
+}
@@ -0,0 +1,63 @@
+//go:build go1.14
+// +build go1.14
+
+package pflag
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTLSCipherSuites(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	suites := f.TLSCipherSuites("ciphers", nil, "")
+	args := []string{"--ciphers", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,0xc030", "--ciphers", "tls_ecdhe_ecdsa_with_aes_128_gcm_sha256"}
+	if err := f.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	want := []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384, tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256}
+	if !reflect.DeepEqual(*suites, want) {
+		t.Errorf("got %#x, want %#x", *suites, want)
+	}
+	if got, err := f.GetTLSCipherSuites("ciphers"); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("GetTLSCipherSuites returned %#x, %v", got, err)
+	}
+	if got := f.Lookup("ciphers").Value.String(); !strings.HasPrefix(got, "[TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,") {
+		t.Errorf("got string %q", got)
+	}
+
+	if err := f.Set("ciphers", "TLS_NOPE"); err == nil {
+		t.Error("expected an error for an unknown suite")
+	}
+	err := f.Set("ciphers", "TLS_RSA_WITH_RC4_128_SHA")
+	if err == nil || !strings.Contains(err.Error(), "insecure") {
+		t.Errorf("got error %v for an insecure suite", err)
+	}
+	if err := f.SetAllowInsecureCipherSuites("ciphers", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Set("ciphers", "TLS_RSA_WITH_RC4_128_SHA"); err != nil {
+		t.Error(err)
+	}
+
+	c, err := f.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ReplaceSlice("ciphers", []string{"0x1301"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(*suites) != 4 {
+		t.Errorf("setting a clone changed the original to %#x", *suites)
+	}
+	if err := f.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if len(*suites) != 0 {
+		t.Errorf("got %#x after Reset", *suites)
+	}
+}
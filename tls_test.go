@@ -0,0 +1,58 @@
+package pflag
+
+import (
+	"crypto/tls"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTLSVersion(t *testing.T) {
+	tests := []struct {
+		in   string
+		want uint16
+	}{
+		{"1.0", tls.VersionTLS10},
+		{"1.1", tls.VersionTLS11},
+		{"TLS1.2", tls.VersionTLS12},
+		{"tlsv1.2", tls.VersionTLS12},
+		{"TLS 1.3", versionTLS13},
+		{"TLS1_3", versionTLS13},
+	}
+	for _, test := range tests {
+		f := NewFlagSet("test", ContinueOnError)
+		v := f.TLSVersionP("min-tls", "t", 0, "")
+		if err := f.Parse([]string{"-t", test.in}); err != nil {
+			t.Errorf("%s: %v", test.in, err)
+			continue
+		}
+		if *v != test.want {
+			t.Errorf("%s: got %#x, want %#x", test.in, *v, test.want)
+		}
+		if got, err := f.GetTLSVersion("min-tls"); err != nil || got != test.want {
+			t.Errorf("%s: GetTLSVersion returned %#x, %v", test.in, got, err)
+		}
+	}
+
+	f := NewFlagSet("test", ContinueOnError)
+	f.TLSVersion("min-tls", tls.VersionTLS12, "minimum TLS version")
+	f.TLSVersion("max-tls", 0, "")
+	if err := f.Set("min-tls", "1.4"); err == nil {
+		t.Error("expected an error for an unknown version")
+	}
+	if got := f.FlagUsages(); !strings.Contains(got, "--min-tls version   minimum TLS version (default 1.2)") {
+		t.Errorf("got usage %q", got)
+	}
+	if args := f.ToArgs(false); !reflect.DeepEqual(args, []string{"--min-tls=1.2"}) {
+		t.Errorf("got args %q", args)
+	}
+	if err := f.Set("max-tls", "1.3"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := f.GetTLSVersion("max-tls"); v != 0 {
+		t.Errorf("got %#x after Reset, want 0", v)
+	}
+}
@@ -0,0 +1,120 @@
+package pflag
+
+import (
+	"sort"
+	"strings"
+)
+
+// ToArgs returns command line arguments which reproduce the current values
+// of the flags when parsed, in VisitAll order. If onlyChanged is true, only
+// the flags which have been set are included. Every flag is given in the
+// --name=value form, as a single argument which needs no further quoting.
+// Flags whose value can't be written on the command line, like an empty
+// string array, an empty int slice or an unset IP address, are left out, as
+// are passwords and sensitive flags, see MarkSensitive, so that the
+// arguments can be logged.
+func (f *FlagSet) ToArgs(onlyChanged bool) []string {
+	var args []string
+	f.VisitAll(func(flag *Flag) {
+		if onlyChanged && !flag.Changed {
+			return
+		}
+		args = append(args, flagArgs(flag)...)
+	})
+	return args
+}
+
+// flagArgs returns the arguments which set flag to its current value.
+func flagArgs(flag *Flag) []string {
+	prefix := "--" + flag.Name + "="
+	value := unwrapValue(flag.Value)
+	if _, ok := value.(*passwordValue); ok || flag.Sensitive {
+		return nil
+	}
+	switch v := value.(type) {
+	case *stringArrayValue:
+		if v.sepSet && v.sep != 0 {
+			return separatedArgs(prefix, *v.value, v.sep)
+		}
+		// Values of string arrays are not split, so each needs its own flag.
+		args := make([]string, len(*v.value))
+		for i, s := range *v.value {
+			args[i] = prefix + s
+		}
+		return args
+	case *stringSliceValue:
+		if v.sepSet {
+			return separatedArgs(prefix, *v.value, v.sep)
+		}
+		return []string{prefix + strings.TrimSuffix(strings.TrimPrefix(v.String(), "["), "]")}
+	case *headerValue:
+		// Each occurrence adds a field, so each needs its own flag.
+		fields := v.fields()
+		args := make([]string, len(fields))
+		for i, field := range fields {
+			args[i] = prefix + field
+		}
+		return args
+	case *stringToStringValue:
+		// Pairs are merged, so each can have its own flag.
+		keys := make([]string, 0, len(*v.value))
+		for k := range *v.value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		sep := "="
+		if v.sepSet {
+			sep = string(v.kvSep)
+		}
+		args := make([]string, len(keys))
+		for i, k := range keys {
+			pair := k + sep + (*v.value)[k]
+			if !v.sepSet {
+				pair, _ = writeAsCSV([]string{pair})
+			} else if v.pairSep != 0 {
+				pair = escapeElement(k, v.pairSep) + sep + escapeElement((*v.value)[k], v.pairSep)
+			}
+			args[i] = prefix + pair
+		}
+		return args
+	case *boolSliceValue, *intSliceValue, *uintSliceValue, *ipSliceValue, *tlsCipherSuitesValue:
+		// The elements in brackets are comma separated, in the form Set reads.
+		s := strings.TrimSuffix(strings.TrimPrefix(v.String(), "["), "]")
+		if s == "" {
+			// Set can't parse an empty element.
+			return nil
+		}
+		if o := v.(sliceOptioner).options(); o.sepSet {
+			return separatedArgs(prefix, strings.Split(s, ","), o.sep)
+		}
+		return []string{prefix + s}
+	}
+	s := value.String()
+	switch value.(type) {
+	case *hardwareAddrValue, *signalValue, *languageValue, *tlsVersionValue:
+		if s == "" {
+			return nil
+		}
+	}
+	if s == "<nil>" {
+		return nil
+	}
+	return []string{prefix + s}
+}
+
+// separatedArgs returns the arguments which set a slice flag whose elements
+// are separated by sep, see SetSliceSeparator, to elems.
+func separatedArgs(prefix string, elems []string, sep rune) []string {
+	if sep == 0 {
+		args := make([]string, len(elems))
+		for i, elem := range elems {
+			args[i] = prefix + elem
+		}
+		return args
+	}
+	escaped := make([]string, len(elems))
+	for i, elem := range elems {
+		escaped[i] = escapeElement(elem, sep)
+	}
+	return []string{prefix + strings.Join(escaped, string(sep))}
+}
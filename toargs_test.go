@@ -0,0 +1,45 @@
+package pflag
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToArgs(t *testing.T) {
+	fs := NewFlagSet("TestToArgs", ContinueOnError)
+	fs.Int("n", 1, "")
+	fs.CountP("verbose", "v", "")
+	fs.StringSlice("ss", nil, "")
+	fs.StringArray("sa", nil, "")
+	fs.IntSlice("is", nil, "")
+	fs.BoolSlice("bs", nil, "")
+	fs.IP("ip", nil, "")
+	fs.String("s", "", "")
+
+	args := []string{"-vv", `--ss=a,"b,c"`, "--sa=x,y", "--sa=z", "--is=1,2", "--s=with space"}
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"--is=1,2", "--s=with space", "--sa=x,y", "--sa=z", `--ss=a,"b,c"`, "--verbose=2"}
+	got := fs.ToArgs(true)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q want %q", got, want)
+	}
+	want = []string{"--is=1,2", "--n=1", "--s=with space", "--sa=x,y", "--sa=z", `--ss=a,"b,c"`, "--verbose=2"}
+	if got := fs.ToArgs(false); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q want %q", got, want)
+	}
+
+	c, err := fs.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Reset()
+	if err := c.Parse(got); err != nil {
+		t.Fatal(err)
+	}
+	if again := c.ToArgs(false); !reflect.DeepEqual(again, want) {
+		t.Errorf("round trip: got %q want %q", again, want)
+	}
+}
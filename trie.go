@@ -0,0 +1,154 @@
+package pflag
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// nameTrie is a prefix tree of the names of the flags of a FlagSet, for the
+// lookups by prefix behind abbreviations and suggestions. Exact lookups use
+// FlagSet.formal.
+type nameTrie struct {
+	flag     *Flag // flag whose name ends here, if any
+	children map[byte]*nameTrie
+}
+
+// insert adds flag under name.
+func (t *nameTrie) insert(name string, flag *Flag) {
+	for i := 0; i < len(name); i++ {
+		child := t.children[name[i]]
+		if child == nil {
+			if t.children == nil {
+				t.children = make(map[byte]*nameTrie)
+			}
+			child = new(nameTrie)
+			t.children[name[i]] = child
+		}
+		t = child
+	}
+	t.flag = flag
+}
+
+// walk returns the node of the longest prefix of s in the trie, and the
+// length of this prefix.
+func (t *nameTrie) walk(s string) (*nameTrie, int) {
+	for i := 0; i < len(s); i++ {
+		child := t.children[s[i]]
+		if child == nil {
+			return t, i
+		}
+		t = child
+	}
+	return t, len(s)
+}
+
+// collect appends the flags of the subtree to flags, in the lexicographical
+// order of their names.
+func (t *nameTrie) collect(flags []*Flag) []*Flag {
+	if t.flag != nil {
+		flags = append(flags, t.flag)
+	}
+	keys := make([]int, 0, len(t.children))
+	for c := range t.children {
+		keys = append(keys, int(c))
+	}
+	sort.Ints(keys)
+	for _, c := range keys {
+		flags = t.children[byte(c)].collect(flags)
+	}
+	return flags
+}
+
+// nameIndex returns the trie of the names of the flags, built on first use
+// after the flags changed.
+func (f *FlagSet) nameIndex() *nameTrie {
+	if f.names == nil {
+		f.names = new(nameTrie)
+		for name, flag := range f.formal {
+			f.names.insert(string(name), flag)
+		}
+	}
+	return f.names
+}
+
+// FlagsWithPrefix returns the flags whose name starts with prefix, in
+// lexicographical order, e.g. for completion. The prefix is normalized like
+// the names of the flags, see SetNormalizeFunc.
+func (f *FlagSet) FlagsWithPrefix(prefix string) []*Flag {
+	if prefix != "" {
+		prefix = string(f.normalizeFlagName(prefix))
+	}
+	node, n := f.nameIndex().walk(prefix)
+	if n < len(prefix) {
+		return nil
+	}
+	return node.collect(nil)
+}
+
+// SuggestFlags returns the names of the flags which share the longest
+// prefix with name, of at least half its length, in lexicographical order.
+// It is meant to suggest corrections for unknown flags, see SetSuggestions.
+func (f *FlagSet) SuggestFlags(name string) []string {
+	name = string(f.normalizeFlagName(name))
+	node, n := f.nameIndex().walk(name)
+	if n == 0 || n*2 < len(name) {
+		return nil
+	}
+	var names []string
+	for _, flag := range node.collect(nil) {
+		if !flag.Hidden && flag.Deprecated == "" {
+			names = append(names, flag.Name)
+		}
+	}
+	return names
+}
+
+// SetAbbreviations sets whether a long flag may be given by a prefix of its
+// name which is not the name of another flag, like --verb for --verbose, as
+// getopt_long allows. Parse fails for a prefix which is ambiguous.
+func (f *FlagSet) SetAbbreviations(enabled bool) {
+	f.abbreviations = enabled
+}
+
+// SetSuggestions sets whether the error for an unknown flag suggests the
+// flags with a similar name, see SuggestFlags.
+func (f *FlagSet) SetSuggestions(enabled bool) {
+	f.suggestions = enabled
+}
+
+// lookupAbbreviation returns the flag whose name starts with name, if
+// abbreviations are enabled and a single flag does. It returns an error if
+// several flags do.
+func (f *FlagSet) lookupAbbreviation(name string) (*Flag, error) {
+	if !f.abbreviations || name == "" {
+		return nil, nil
+	}
+	flags := f.FlagsWithPrefix(name)
+	switch len(flags) {
+	case 0:
+		return nil, nil
+	case 1:
+		return flags[0], nil
+	}
+	names := make([]string, len(flags))
+	for i, flag := range flags {
+		names[i] = "--" + flag.Name
+	}
+	return nil, f.failf(f.msg(MsgAmbiguousFlag), name, strings.Join(names, ", "))
+}
+
+// unknownFlag returns the error for the unknown flag name, with suggestions
+// if they are enabled.
+func (f *FlagSet) unknownFlag(name string) error {
+	if f.suggestions {
+		if names := f.SuggestFlags(name); len(names) > 0 {
+			for i := range names {
+				names[i] = "--" + names[i]
+			}
+			msg := fmt.Sprintf(f.msg(MsgUnknownFlag), name)
+			return f.failf("%s%s", msg, fmt.Sprintf(f.msg(MsgSuggestion), strings.Join(names, ", ")))
+		}
+	}
+	return f.failf(f.msg(MsgUnknownFlag), name)
+}
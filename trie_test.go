@@ -0,0 +1,66 @@
+package pflag
+
+import (
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFlagsWithPrefix(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	for _, name := range []string{"verbose", "version", "verify", "debug", "ver"} {
+		f.Bool(name, false, "")
+	}
+	if got, want := flagNames(f.FlagsWithPrefix("ver")), []string{"ver", "verbose", "verify", "version"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := f.FlagsWithPrefix("x"); got != nil {
+		t.Errorf("got %v for an unknown prefix", flagNames(got))
+	}
+	if got := f.FlagsWithPrefix(""); len(got) != 5 {
+		t.Errorf("got %v for an empty prefix", flagNames(got))
+	}
+
+	f.Remove("verify")
+	f.Bool("verbatim", false, "")
+	if got, want := flagNames(f.FlagsWithPrefix("verb")), []string{"verbatim", "verbose"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v after changing the flags", got, want)
+	}
+}
+
+func TestAbbreviationsAndSuggestions(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	verbose := f.Bool("verbose", false, "")
+	version := f.String("version", "", "")
+	f.Bool("debug", false, "")
+
+	if err := f.Parse([]string{"--verb"}); err == nil {
+		t.Error("expected an error for an abbreviation while they are disabled")
+	}
+	f.SetAbbreviations(true)
+	if err := f.Parse([]string{"--verb", "--vers=1.2", "--debug"}); err != nil {
+		t.Fatal(err)
+	}
+	if !*verbose || *version != "1.2" || !f.Changed("verbose") {
+		t.Errorf("got verbose %v and version %q", *verbose, *version)
+	}
+	err := f.Parse([]string{"--ver"})
+	if err == nil || !strings.Contains(err.Error(), "ambiguous flag: --ver could be --verbose, --version") {
+		t.Errorf("got error %v for an ambiguous abbreviation", err)
+	}
+
+	if got, want := f.SuggestFlags("verbos-mode"), []string{"verbose"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got suggestions %v, want %v", got, want)
+	}
+	if got := f.SuggestFlags("dxyz"); got != nil {
+		t.Errorf("got suggestions %v for a short common prefix", got)
+	}
+	f.SetAbbreviations(false)
+	f.SetSuggestions(true)
+	err = f.Parse([]string{"--verz"})
+	if err == nil || err.Error() != "unknown flag: --verz, did you mean --verbose, --version?" {
+		t.Errorf("got error %v for an unknown flag", err)
+	}
+}
@@ -10,6 +10,7 @@ import (
 type uintSliceValue struct {
 	value   *[]uint
 	changed bool
+	sliceOptions
 }
 
 func newUintSliceValue(val []uint, p *[]uint) *uintSliceValue {
@@ -20,7 +21,10 @@ func newUintSliceValue(val []uint, p *[]uint) *uintSliceValue {
 }
 
 func (s *uintSliceValue) Set(val string) error {
-	ss := strings.Split(val, ",")
+	ss, err := s.split(val, splitComma)
+	if err != nil {
+		return err
+	}
 	out := make([]uint, len(ss))
 	for i, d := range ss {
 		u, err := strconv.ParseUint(d, 10, 0)
@@ -29,10 +33,8 @@ func (s *uintSliceValue) Set(val string) error {
 		}
 		out[i] = uint(u)
 	}
-	if !s.changed {
-		*s.value = out
-	} else {
-		*s.value = append(*s.value, out...)
+	if err := s.assign(s.value, out, s.changed || s.appendDefault); err != nil {
+		return err
 	}
 	s.changed = true
 	return nil
@@ -42,6 +44,47 @@ func (s *uintSliceValue) Type() string {
 	return "uintSlice"
 }
 
+// parseUintSliceElement parses an element of the value of a uintSlice flag.
+func parseUintSliceElement(val string) (uint, error) {
+	u, err := strconv.ParseUint(val, 10, 0)
+	return uint(u), err
+}
+
+func (s *uintSliceValue) Append(val string) error {
+	v, err := parseUintSliceElement(val)
+	if err != nil {
+		return err
+	}
+	if err := s.assign(s.value, []uint{v}, true); err != nil {
+		return err
+	}
+	s.changed = true
+	return nil
+}
+
+func (s *uintSliceValue) Replace(val []string) error {
+	out := make([]uint, len(val))
+	for i, d := range val {
+		var err error
+		if out[i], err = parseUintSliceElement(d); err != nil {
+			return err
+		}
+	}
+	if err := s.assign(s.value, out, false); err != nil {
+		return err
+	}
+	s.changed = true
+	return nil
+}
+
+func (s *uintSliceValue) GetSlice() []string {
+	out := make([]string, len(*s.value))
+	for i, d := range *s.value {
+		out[i] = strconv.FormatUint(uint64(d), 10)
+	}
+	return out
+}
+
 func (s *uintSliceValue) String() string {
 	out := make([]string, len(*s.value))
 	for i, d := range *s.value {
@@ -0,0 +1,177 @@
+package pflag
+
+import (
+	"bytes"
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+	"unicode"
+)
+
+// Unmarshal copies the values of the flags into the fields of v, a pointer
+// to a struct, so that flags defined one by one can be consumed as a
+// configuration struct once parsed:
+//
+//	var cfg struct {
+//		Host     string
+//		MaxConns int           `flag:"max-connections"`
+//		Timeout  time.Duration
+//		Tags     []string
+//		Internal bool          `flag:"-"`
+//	}
+//	err := f.Unmarshal(&cfg)
+//
+// A field is set from the flag named in its "flag" tag, or else from the
+// flag named after the field in lower case with dashes between words, e.g.
+// --max-conns for MaxConns. Fields tagged "-", unexported fields and fields
+// without a flag are left alone. The fields of a nested struct are set from
+// the flags named with the name of the struct field and a dash as a prefix,
+// like the flags added by AddFlagSetWithPrefix, unless the struct field is
+// embedded.
+//
+// The values are converted to the types of the fields: values of the same
+// kind are converted directly, the elements of slice flags (see SliceValue)
+// are converted one by one, and others are converted from their string
+// representation, to basic types, time.Duration or types implementing
+// encoding.TextUnmarshaler.
+func (f *FlagSet) Unmarshal(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%T is not a pointer to a struct", v)
+	}
+	return f.unmarshalStruct("", rv.Elem())
+}
+
+// unmarshalStruct sets the fields of the struct sv from the flags whose
+// names start with prefix.
+func (f *FlagSet) unmarshalStruct(prefix string, sv reflect.Value) error {
+	t := sv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("flag")
+		if tag == "-" || (field.PkgPath != "" && !field.Anonymous) {
+			continue
+		}
+		fv := sv.Field(i)
+		name := tag
+		if name == "" {
+			name = dashedName(field.Name)
+		}
+		if flag := f.Lookup(prefix + name); flag != nil && fv.CanSet() {
+			if err := unmarshalFlag(flag, fv); err != nil {
+				return fmt.Errorf("flag %q: %v", flag.Name, err)
+			}
+			continue
+		}
+		if fv.Kind() != reflect.Struct || isTextUnmarshaler(fv) {
+			continue
+		}
+		sub := prefix + name + "-"
+		if field.Anonymous && tag == "" {
+			sub = prefix
+		}
+		if err := f.unmarshalStruct(sub, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unmarshalFlag sets v to the value of flag.
+func unmarshalFlag(flag *Flag, v reflect.Value) error {
+	if rv := reflect.ValueOf(flag.Value); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		elem := rv.Elem()
+		if elem.Kind() == v.Kind() && elem.Type().ConvertibleTo(v.Type()) {
+			v.Set(elem.Convert(v.Type()))
+			return nil
+		}
+	}
+	if sv, ok := flag.Value.(SliceValue); ok && v.Kind() == reflect.Slice {
+		elems := sv.GetSlice()
+		s := reflect.MakeSlice(v.Type(), len(elems), len(elems))
+		for i, elem := range elems {
+			if err := setFromString(s.Index(i), elem); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+		return nil
+	}
+	return setFromString(v, flag.Value.String())
+}
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// setFromString sets v to the value represented by s.
+func setFromString(v reflect.Value, s string) error {
+	if isTextUnmarshaler(v) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	default:
+		return fmt.Errorf("can not convert %q to %s", s, v.Type())
+	}
+	return nil
+}
+
+// isTextUnmarshaler returns whether the address of v implements
+// encoding.TextUnmarshaler.
+func isTextUnmarshaler(v reflect.Value) bool {
+	return reflect.PtrTo(v.Type()).Implements(textUnmarshalerType)
+}
+
+// dashedName returns the name of a struct field in lower case, with dashes
+// between words: MaxConns gives max-conns and HTTPPort gives http-port.
+func dashedName(name string) string {
+	runes := []rune(name)
+	var b bytes.Buffer
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (!unicode.IsUpper(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('-')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,88 @@
+package pflag
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUnmarshal(t *testing.T) {
+	type db struct {
+		Host string
+		Port uint16
+	}
+	var cfg struct {
+		Name     string
+		MaxConns int64 `flag:"max-connections"`
+		Timeout  time.Duration
+		Ratio    float32
+		Tags     []string
+		Ports    []int
+		Addr     net.IP
+		Level    string
+		DB       db
+		Skipped  string `flag:"-"`
+		Missing  string
+		hidden   string
+	}
+	f := NewFlagSet("test", ContinueOnError)
+	f.String("name", "app", "")
+	f.Int("max-connections", 10, "")
+	f.Duration("timeout", time.Second, "")
+	f.Float64("ratio", 0.5, "")
+	f.StringSlice("tags", nil, "")
+	f.StringSlice("ports", nil, "")
+	f.IP("addr", nil, "")
+	f.Count("level", "")
+	f.String("db-host", "localhost", "")
+	f.Int("db-port", 5432, "")
+	f.String("skipped", "x", "")
+	f.String("hidden", "x", "")
+	if err := f.Parse([]string{"--timeout=1m", "--tags=a,b", "--ports=80,443", "--addr=10.0.0.1", "--level", "--level", "--db-port=6432"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Unmarshal(&cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Name != "app" || cfg.MaxConns != 10 || cfg.Timeout != time.Minute || cfg.Ratio != 0.5 {
+		t.Errorf("got %+v", cfg)
+	}
+	if !reflect.DeepEqual(cfg.Tags, []string{"a", "b"}) || !reflect.DeepEqual(cfg.Ports, []int{80, 443}) {
+		t.Errorf("got tags %v and ports %v", cfg.Tags, cfg.Ports)
+	}
+	if !cfg.Addr.Equal(net.ParseIP("10.0.0.1")) || cfg.Level != "2" {
+		t.Errorf("got addr %v and level %q", cfg.Addr, cfg.Level)
+	}
+	if cfg.DB != (db{"localhost", 6432}) {
+		t.Errorf("got db %+v", cfg.DB)
+	}
+	if cfg.Skipped != "" || cfg.Missing != "" || cfg.hidden != "" {
+		t.Errorf("got %+v", cfg)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.String("port", "http", "")
+	var cfg struct{ Port int }
+	if err := f.Unmarshal(&cfg); err == nil {
+		t.Error("expected an error for a value which can not be converted")
+	}
+	if err := f.Unmarshal(cfg); err == nil {
+		t.Error("expected an error for a struct which is not a pointer")
+	}
+}
+
+func TestDashedName(t *testing.T) {
+	for name, want := range map[string]string{
+		"Host":     "host",
+		"MaxConns": "max-conns",
+		"HTTPPort": "http-port",
+		"UseTLS":   "use-tls",
+	} {
+		if got := dashedName(name); got != want {
+			t.Errorf("dashedName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,324 @@
+package pflag
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// FlagUsage describes how a flag is presented in help and usage messages.
+// It is the per-flag data made available to usage templates.
+type FlagUsage struct {
+	Flag        *Flag
+	Name        string
+	Shorthand   string // empty if the flag has no shorthand, or if it is deprecated
+	Type        string
+	Varname     string // name of the flag's argument, see UnquoteUsage
+	OptionalArg string // rendering of NoOptDefVal, e.g. `[="bar"]`; empty if none
+	Usage       string // usage message, with back quotes removed
+	Default     string // rendering of the default value, e.g. `(default "foo")`; empty for zero values
+	Example     string // example invocation of the flag, see SetExample
+	Versions    string // in verbose help, e.g. "(added in v1.2)"; see SetVerboseHelp
+	Semantics   string // accepted values or how values combine if unusual, e.g. "(appends to the default)"
+}
+
+// Names returns the flag names the way they are printed by the built-in
+// usage output, e.g. "-v, --verbose" or "    --verbose".
+func (u *FlagUsage) Names() string {
+	if u.Shorthand != "" {
+		return fmt.Sprintf("-%s, --%s", u.Shorthand, u.Name)
+	}
+	return "    --" + u.Name
+}
+
+// Arg returns the argument part of the flag specification, e.g. " string"
+// or `[="bar"]`.
+func (u *FlagUsage) Arg() string {
+	if u.OptionalArg != "" {
+		return u.OptionalArg
+	}
+	if u.Varname != "" {
+		return " " + u.Varname
+	}
+	return ""
+}
+
+// Spec returns the left column of the built-in usage output, that is the
+// indented flag names followed by the argument.
+func (u *FlagUsage) Spec() string {
+	return "  " + u.Names() + u.Arg()
+}
+
+// text returns the right column of the built-in usage output, that is the
+// usage message followed by the default value and other notes.
+func (u *FlagUsage) text() string {
+	text := u.Usage
+	if u.Default != "" {
+		text += " " + u.Default
+	}
+	if u.Semantics != "" {
+		text += " " + u.Semantics
+	}
+	if u.Versions != "" {
+		text += " " + u.Versions
+	}
+	return text
+}
+
+// UsageLine returns the line describing the flag in the built-in usage
+// output, as FlagUsages prints it for a FlagSet holding only this flag but
+// without the example, e.g. `  -v, --verbose   verbose output`. It uses the
+// built-in messages, not those of a FlagSet.
+func (f *Flag) UsageLine() string {
+	u := new(FlagSet).newFlagUsage(f)
+	return u.Spec() + "   " + u.text()
+}
+
+// UsageGroup is a section of the usage output, see SetGroup. Flags which do
+// not belong to any group are collected in a UsageGroup with an empty Name.
+type UsageGroup struct {
+	Name  string
+	Flags []*FlagUsage
+}
+
+// UsageData is the data a usage template is executed with.
+type UsageData struct {
+	Name        string       // name of the FlagSet
+	UsageLine   string       // synopsis of the command line, see SetUsageLine
+	Description string       // see SetDescription
+	Flags       []*FlagUsage // all flags shown in help, in VisitAll order
+	Inherited   []*FlagUsage // flags inherited from the ancestors, see SetParent
+	Groups      []UsageGroup // Flags split into sections; ungrouped flags come first
+	FlagUsages  string       // the built-in rendering of the flags, see FlagUsages
+}
+
+// newFlagUsage computes the presentation of flag, using the messages of f.
+func (f *FlagSet) newFlagUsage(flag *Flag) *FlagUsage {
+	flag.resolveDefault()
+	u := &FlagUsage{
+		Flag:      flag,
+		Name:      flag.Name,
+		Type:      flag.Value.Type(),
+		Example:   flag.Example,
+		Versions:  f.versions(flag),
+		Semantics: f.semantics(flag),
+	}
+	if flag.ShorthandDeprecated == "" {
+		u.Shorthand = flag.Shorthand
+	}
+
+	u.Varname, u.Usage = UnquoteUsage(flag)
+	if arg := flag.DefaultArgText(); arg != "" {
+		switch u.Type {
+		case "string":
+			u.OptionalArg = fmt.Sprintf("[=\"%s\"]", arg)
+		case "bool":
+			if arg != "true" {
+				u.OptionalArg = fmt.Sprintf("[=%s]", arg)
+			}
+		default:
+			u.OptionalArg = fmt.Sprintf("[=%s]", arg)
+		}
+	}
+
+	if def, quote := flag.defaultText(); def != "" {
+		if quote {
+			def = strconv.Quote(def)
+		}
+		u.Default = fmt.Sprintf(f.msg(MsgDefault), def)
+	}
+	return u
+}
+
+// defaultText returns the default value of flag as shown in help, and
+// whether it is to be quoted, being the DefValue of a string flag.
+func (f *Flag) defaultText() (text string, quote bool) {
+	if f.HideDefault {
+		return "", false
+	}
+	if f.DefaultText != "" {
+		return f.DefaultText, false
+	}
+	if v, ok := f.Value.(DefaultValueValue); ok {
+		return redact(f, v.DefaultValue()), false
+	}
+	if f.defaultIsZeroValue() {
+		return "", false
+	}
+	if f.Sensitive {
+		return redactedValue, false
+	}
+	return f.DefValue, f.Value.Type() == "string"
+}
+
+// DefaultValueText returns the default value of the flag as shown in help
+// and usage messages, unquoted, or an empty string if none is shown: the
+// DefaultText if set, else the DefaultValue of values implementing
+// DefaultValueValue, else the DefValue unless it is a zero value. Sensitive
+// defaults are redacted. It lets doc generators render defaults like the
+// built-in usage message does.
+func (f *Flag) DefaultValueText() string {
+	f.resolveDefault()
+	text, _ := f.defaultText()
+	return text
+}
+
+// DefaultArgText returns the argument the flag is set to when given without
+// one, shown as "[=X]" in help and usage messages, or an empty string if it
+// requires an argument: the NoOptDefVal if set, else the DefaultArg of
+// values implementing DefaultArgValue.
+func (f *Flag) DefaultArgText() string {
+	if f.NoOptDefVal != "" {
+		return f.NoOptDefVal
+	}
+	if v, ok := f.Value.(DefaultArgValue); ok {
+		return v.DefaultArg()
+	}
+	return ""
+}
+
+// flagUsages returns the presentation of all flags which are shown in help
+// and usage messages, in VisitAll order.
+func (f *FlagSet) flagUsages() []*FlagUsage {
+	usages := make([]*FlagUsage, 0, len(f.formal))
+	f.VisitAll(func(flag *Flag) {
+		if flag.Hidden || flag.Deprecated != "" && !f.verboseHelp {
+			return
+		}
+		usages = append(usages, f.newFlagUsage(flag))
+	})
+	return usages
+}
+
+// usageGroups splits usages into sections. The ungrouped flags come first,
+// followed by one section per group in the order the groups were first used.
+func (f *FlagSet) usageGroups(usages []*FlagUsage) []UsageGroup {
+	var ungrouped []*FlagUsage
+	grouped := make(map[string][]*FlagUsage)
+	groups := append([]string(nil), f.groups...)
+	for _, u := range usages {
+		group := u.Flag.Group
+		if group == "" {
+			ungrouped = append(ungrouped, u)
+			continue
+		}
+		if !containsString(groups, group) {
+			// Group was set on the Flag directly rather than via SetGroup.
+			groups = append(groups, group)
+		}
+		grouped[group] = append(grouped[group], u)
+	}
+
+	var sections []UsageGroup
+	if len(ungrouped) > 0 {
+		sections = append(sections, UsageGroup{Flags: ungrouped})
+	}
+	for _, group := range groups {
+		if len(grouped[group]) > 0 {
+			sections = append(sections, UsageGroup{Name: group, Flags: grouped[group]})
+		}
+	}
+	return sections
+}
+
+// UsageData returns the data usage templates are executed with.
+func (f *FlagSet) UsageData() *UsageData {
+	usages := f.flagUsages()
+	return &UsageData{
+		Name:        f.name,
+		UsageLine:   f.usageLine,
+		Description: f.description,
+		Flags:       usages,
+		Inherited:   f.inheritedFlagUsages(),
+		Groups:      f.usageGroups(usages),
+		FlagUsages:  f.FlagUsages(),
+	}
+}
+
+// SetDescription sets a description of the program or command, typically a
+// one-line title optionally followed by paragraphs, which the default usage
+// message prints above the flags.
+func (f *FlagSet) SetDescription(description string) {
+	f.description = description
+}
+
+// SetUsageLine sets the synopsis of the command line, like
+// "prog [flags] FILE...", which the default usage message prints as
+// "Usage: prog [flags] FILE..." in place of "Usage of prog:".
+func (f *FlagSet) SetUsageLine(line string) {
+	f.usageLine = line
+}
+
+// printUsageHeader prints the first lines of the default usage message, for
+// the program or command name.
+func (f *FlagSet) printUsageHeader(name string) {
+	if f.usageLine != "" {
+		fmt.Fprintf(f.out(), f.msg(MsgUsageLine), f.usageLine)
+	} else {
+		fmt.Fprintf(f.out(), f.msg(MsgUsageOf), name)
+	}
+	if f.description != "" {
+		fmt.Fprintf(f.out(), "\n%s\n\n", strings.TrimRight(f.description, "\n"))
+	}
+}
+
+var usageTemplateFuncs = template.FuncMap{
+	"rpad": func(s string, n int) string {
+		return fmt.Sprintf("%-*s", n, s)
+	},
+	"wrap": func(indent, cols int, s string) string {
+		return wrap(indent, cols, s)
+	},
+	"join":   strings.Join,
+	"repeat": strings.Repeat,
+}
+
+// SetUsageTemplate replaces the default usage message of the FlagSet with
+// the given text/template. The template is executed with a *UsageData and
+// the following functions are available:
+//
+//	rpad   "s" n           pads s with spaces on the right to n characters
+//	wrap   indent cols "s" wraps s like FlagUsagesWrapped does
+//	join   list "sep"      strings.Join
+//	repeat "s" n           strings.Repeat
+//
+// The template is used by the default Usage function; an empty text restores
+// the built-in message.
+func (f *FlagSet) SetUsageTemplate(text string) error {
+	if text == "" {
+		f.usageTemplate = nil
+		return nil
+	}
+	tmpl, err := template.New(f.name).Funcs(usageTemplateFuncs).Parse(text)
+	if err != nil {
+		return err
+	}
+	f.usageTemplate = tmpl
+	return nil
+}
+
+// UsageString returns the usage message printed when parsing fails or help
+// is requested, so that it can be embedded in a larger help screen. The
+// Usage function must print to the output of the FlagSet, as the built-in
+// one does, for its message to be returned.
+func (f *FlagSet) UsageString() string {
+	buf := new(bytes.Buffer)
+	output := f.output
+	f.output = buf
+	defer func() { f.output = output }()
+	f.usage()
+	return buf.String()
+}
+
+// executeUsageTemplate prints the usage message rendered by the usage
+// template. Any error executing the template is printed in place of it.
+func (f *FlagSet) executeUsageTemplate() {
+	buf := new(bytes.Buffer)
+	if err := f.usageTemplate.Execute(buf, f.UsageData()); err != nil {
+		fmt.Fprintln(f.errOut(), err)
+		return
+	}
+	fmt.Fprint(f.out(), buf.String())
+}
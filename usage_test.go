@@ -0,0 +1,153 @@
+package pflag
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+)
+
+func TestUsageTemplate(t *testing.T) {
+	fs := NewFlagSet("tmpl", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.StringP("output", "o", "json", "output `format`")
+	fs.Int("retries", 0, "number of retries")
+	fs.Bool("debug", false, "")
+	fs.MarkHidden("debug")
+
+	err := fs.SetUsageTemplate(`{{.Name}} options:
+{{range .Flags}}{{rpad .Names 14}}{{.Arg}}	{{.Usage}}{{with .Default}} {{.}}{{end}}
+{{end}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs.usage()
+
+	want := `tmpl options:
+-o, --output   format	output format (default "json")
+    --retries  int	number of retries
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+
+	buf.Reset()
+	fs.SetUsageTemplate("")
+	fs.usage()
+	if got, want := buf.String(), "Usage of tmpl:\n"+fs.FlagUsages(); got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+
+	if err := fs.SetUsageTemplate("{{.Nope"); err == nil {
+		t.Error("expected an error for an invalid template")
+	}
+}
+
+func TestUsageString(t *testing.T) {
+	fs := NewFlagSet("app", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.StringP("output", "o", "json", "output `format`")
+	fs.Int("retries", 0, "number of retries")
+
+	if got, want := fs.UsageString(), "Usage of app:\n"+fs.FlagUsages(); got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("got output %q", buf.String())
+	}
+
+	fs.Usage = func() { fs.PrintDefaults() }
+	if got, want := fs.UsageString(), fs.FlagUsages(); got != want {
+		t.Errorf("got %q want %q with a custom Usage", got, want)
+	}
+}
+
+func TestUsageLine(t *testing.T) {
+	fs := NewFlagSet("app", ContinueOnError)
+	fs.StringP("output", "o", "json", "output `format`")
+	fs.Int("retries", 0, "number of retries")
+
+	if got, want := fs.Lookup("output").UsageLine(), `  -o, --output format   output format (default "json")`; got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+	alone := NewFlagSet("alone", ContinueOnError)
+	alone.AddFlag(fs.Lookup("retries"))
+	if got, want := fs.Lookup("retries").UsageLine()+"\n", alone.FlagUsages(); got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+}
+
+func TestDescriptionAndUsageLine(t *testing.T) {
+	fs := NewFlagSet("cat", ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.Bool("number", false, "number the lines")
+	fs.SetUsageLine("cat [flags] FILE...")
+	fs.SetDescription("Concatenate files.\n\nPrint the files to the standard output.\n")
+
+	want := "Usage: cat [flags] FILE...\n\nConcatenate files.\n\nPrint the files to the standard output.\n\n" + fs.FlagUsages()
+	if got := fs.UsageString(); got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+
+	fs.SetUsageLine("")
+	want = "Usage of cat:\n\nConcatenate files.\n\nPrint the files to the standard output.\n\n" + fs.FlagUsages()
+	if got := fs.UsageString(); got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+
+	if data := fs.UsageData(); data.Description == "" || data.UsageLine != "" {
+		t.Errorf("got usage data %+v", data)
+	}
+}
+
+// levelValue renders its default itself and may be given without an
+// argument.
+type levelValue int
+
+func (l *levelValue) String() string     { return strconv.Itoa(int(*l)) }
+func (l *levelValue) Set(s string) error { n, err := strconv.Atoi(s); *l = levelValue(n); return err }
+func (l *levelValue) Type() string       { return "level" }
+func (l *levelValue) DefaultArg() string { return "9" }
+func (l *levelValue) DefaultValue() string {
+	if *l == 0 {
+		return ""
+	}
+	return "level " + l.String()
+}
+
+func TestCustomValueDefaults(t *testing.T) {
+	fs := NewFlagSet("custom", ContinueOnError)
+	level := levelValue(3)
+	fs.AddFlag(&Flag{Name: "level", Usage: "compression level", Value: &level, DefValue: level.String()})
+	zero := levelValue(0)
+	fs.Var(&zero, "zero", "no default")
+
+	flag := fs.Lookup("level")
+	if got := flag.DefaultValueText(); got != "level 3" {
+		t.Errorf("got default %q", got)
+	}
+	if got := flag.DefaultArgText(); got != "9" {
+		t.Errorf("got default argument %q", got)
+	}
+	if got, want := flag.UsageLine(), "      --level[=9]   compression level (default level 3)"; got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+	if got := fs.Lookup("zero").DefaultValueText(); got != "" {
+		t.Errorf("got default %q for a zero value", got)
+	}
+
+	if err := fs.Parse([]string{"--level"}); err != nil {
+		t.Fatal(err)
+	}
+	if level != 9 {
+		t.Errorf("got level %d", level)
+	}
+
+	fs.Lookup("zero").Sensitive = true
+	zero = 1
+	if got := fs.Lookup("zero").DefaultValueText(); got != redactedValue {
+		t.Errorf("got sensitive default %q", got)
+	}
+}
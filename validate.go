@@ -0,0 +1,40 @@
+package pflag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetValidator makes fn check the values the named flag is set to, from any
+// source, before they are passed to the flag's Set method. An error returned
+// by fn is reported like the errors of Set.
+func (f *FlagSet) SetValidator(name string, fn func(value string) error) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	flag.validate = fn
+	return nil
+}
+
+// SetChoices restricts the values the named flag can be set to to choices.
+// It is checked before the validator set with SetValidator, if any.
+func (f *FlagSet) SetChoices(name string, choices ...string) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	flag.choices = choices
+	return nil
+}
+
+// checkValue returns an error if value isn't valid for flag.
+func (f *FlagSet) checkValue(flag *Flag, value string) error {
+	if choices := allowedValues(flag); choices != nil && !containsString(choices, value) {
+		return fmt.Errorf(f.msg(MsgChoices), strings.Join(choices, ", "))
+	}
+	if flag.validate != nil {
+		return flag.validate(value)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package pflag
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestValidators(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	format := f.String("format", "json", "")
+	name := f.String("name", "", "")
+	f.SetChoices("format", "json", "yaml")
+	f.SetValidator("name", func(value string) error {
+		if strings.ContainsAny(value, " \t") {
+			return errors.New("must not contain spaces")
+		}
+		return nil
+	})
+	if err := f.SetChoices("nope", "a"); err == nil {
+		t.Error("expected an error for an unknown flag")
+	}
+
+	if err := f.Parse([]string{"--format=yaml", "--name=api"}); err != nil {
+		t.Fatal(err)
+	}
+	if *format != "yaml" || *name != "api" {
+		t.Errorf("unexpected values %q %q", *format, *name)
+	}
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--format=xml"}, `invalid argument "xml" for "--format" flag: must be one of json, yaml`},
+		{[]string{"--name=a b"}, `invalid argument "a b" for "--name" flag: must not contain spaces`},
+	}
+	for _, test := range tests {
+		err := f.Parse(test.args)
+		if err == nil || err.Error() != test.want {
+			t.Errorf("%v: got error %v, want %s", test.args, err, test.want)
+		}
+	}
+	if err := f.Set("format", "toml"); err == nil {
+		t.Error("Set should check the choices")
+	}
+	if *format != "yaml" {
+		t.Errorf("invalid values should not be set, got %q", *format)
+	}
+}
@@ -0,0 +1,56 @@
+package pflag
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrVersion is the error returned if the flag --version, defined by
+// SetVersion, is invoked.
+var ErrVersion = errors.New("pflag: version requested")
+
+// SetVersion defines a --version flag, with the -V shorthand unless it is
+// already used, which prints version to the output set with SetOutput and stops
+// parsing with ErrVersion, like an undefined --help does with ErrHelp. With
+// ExitOnError, the program exits with status 0. A boolean --version flag
+// defined already is used instead, while a flag of another type is an error.
+// Calling SetVersion again changes the version printed.
+func (f *FlagSet) SetVersion(version string) error {
+	if f.versionFlag == nil {
+		if flag := f.Lookup("version"); flag != nil {
+			if _, ok := flag.Value.(boolFlag); !ok {
+				return fmt.Errorf("flag %q is already defined and not boolean", "version")
+			}
+			f.versionFlag = flag
+		}
+	}
+	f.version = version
+	if f.versionFlag != nil {
+		return nil
+	}
+	shorthand := "V"
+	if _, used := f.shorthands["V"]; used {
+		shorthand = ""
+	}
+	f.BoolP("version", shorthand, false, f.msg(MsgVersionUsage))
+	f.versionFlag = f.Lookup("version")
+	return nil
+}
+
+// versionRequested returns true if flag is the flag of SetVersion and value
+// sets it, unlike --version=false.
+func (f *FlagSet) versionRequested(flag *Flag, value string) bool {
+	if flag != f.versionFlag {
+		return false
+	}
+	v, _ := strconv.ParseBool(value)
+	return v
+}
+
+// printVersion prints the version given to SetVersion and returns
+// ErrVersion.
+func (f *FlagSet) printVersion() error {
+	fmt.Fprintln(f.out(), f.version)
+	return ErrVersion
+}
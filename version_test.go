@@ -0,0 +1,66 @@
+package pflag
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSetVersion(t *testing.T) {
+	var out bytes.Buffer
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(&out)
+	f.SetVersion("test 1.0")
+	f.SetVersion("test 1.1")
+	port := f.Int("port", 0, "")
+	if err := f.Parse([]string{"--port=1", "--version", "--port=2", "--unknown"}); err != ErrVersion {
+		t.Fatalf("expected ErrVersion, got %v", err)
+	}
+	if out.String() != "test 1.1\n" {
+		t.Errorf("got output %q", out.String())
+	}
+	if *port != 1 {
+		t.Errorf("parsing should stop at --version, got --port=%d", *port)
+	}
+
+	out.Reset()
+	if err := f.Parse([]string{"-V"}); err != ErrVersion || out.String() != "test 1.1\n" {
+		t.Errorf("got %v and output %q", err, out.String())
+	}
+
+	g := NewFlagSet("test", ContinueOnError)
+	g.SetOutput(&out)
+	g.BoolP("verbose", "V", false, "")
+	g.SetVersion("1.0")
+	if flag := g.Lookup("version"); flag == nil || flag.Shorthand != "" || flag.Usage != "print the version and exit" {
+		t.Errorf("got %+v", flag)
+	}
+	if err := g.Parse([]string{"-V"}); err != nil {
+		t.Errorf("-V should be --verbose, got %v", err)
+	}
+}
+
+func TestSetVersionFlag(t *testing.T) {
+	var out bytes.Buffer
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(&out)
+	f.Bool("version", false, "show the version")
+	if err := f.SetVersion("1.0"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Parse([]string{"--version=false"}); err != nil {
+		t.Errorf("--version=false should not print the version, got %v", err)
+	}
+	c, err := f.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Parse([]string{"--version"}); err != ErrVersion || out.String() != "1.0\n" {
+		t.Errorf("got %v and output %q from the clone", err, out.String())
+	}
+
+	g := NewFlagSet("test", ContinueOnError)
+	g.String("version", "", "")
+	if err := g.SetVersion("1.0"); err == nil {
+		t.Error("expected an error for a --version flag which is not boolean")
+	}
+}
@@ -0,0 +1,131 @@
+package pflag
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeNow returns the current time, against which the removal dates of
+// MarkDeprecatedUntil are checked. Tests replace it.
+var timeNow = time.Now
+
+// SetAddedIn records the release of the program a flag was added in, e.g.
+// "v1.4". It is shown in verbose help, see SetVerboseHelp.
+func (f *FlagSet) SetAddedIn(name, version string) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	flag.AddedIn = version
+	return nil
+}
+
+// MarkDeprecatedIn is like MarkDeprecated, also recording the release of the
+// program the flag was deprecated in, which is shown in verbose help.
+func (f *FlagSet) MarkDeprecatedIn(name, version, usageMessage string) error {
+	if err := f.MarkDeprecated(name, usageMessage); err != nil {
+		return err
+	}
+	f.Lookup(name).DeprecatedIn = version
+	return nil
+}
+
+// MarkDeprecatedUntil is like MarkDeprecated, also scheduling the removal of
+// the flag after removal, a release of the program like "v2.0" or a date like
+// "2026-12-31". Until then, setting the flag warns that it will be removed;
+// after, setting it is an error with the usage message, so that sunset
+// policies are enforced without another change to the program. Releases are
+// compared with the one set with SetRelease, dates with the current date.
+func (f *FlagSet) MarkDeprecatedUntil(name, removal, usageMessage string) error {
+	if err := f.MarkDeprecated(name, usageMessage); err != nil {
+		return err
+	}
+	f.Lookup(name).RemovalAfter = removal
+	return nil
+}
+
+// SetRelease sets the release of the program, like "v1.8.2", against which
+// the removal releases of MarkDeprecatedUntil are checked. Until it is set,
+// flags scheduled for removal after a release only warn.
+func (f *FlagSet) SetRelease(version string) {
+	f.release = version
+}
+
+// removed returns true if the removal of flag, see MarkDeprecatedUntil, is
+// past.
+func (f *FlagSet) removed(flag *Flag) bool {
+	if _, err := time.Parse("2006-01-02", flag.RemovalAfter); err == nil {
+		// Dates in this format compare like strings.
+		return timeNow().Format("2006-01-02") > flag.RemovalAfter
+	}
+	return f.release != "" && compareVersions(f.release, flag.RemovalAfter) > 0
+}
+
+// compareVersions compares the releases a and b, like "v1.10.2" and "1.9",
+// element by element, numerically for numbers and else lexically. It
+// returns -1, 0 or 1 if a is before, the same as or after b.
+func compareVersions(a, b string) int {
+	as := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bs := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		// Missing elements are 0, so that 1.2 is the same as 1.2.0.
+		x, y := "0", "0"
+		if i < len(as) {
+			x = as[i]
+		}
+		if i < len(bs) {
+			y = bs[i]
+		}
+		xn, xerr := strconv.Atoi(x)
+		yn, yerr := strconv.Atoi(y)
+		switch {
+		case xerr == nil && yerr == nil && xn != yn:
+			if xn < yn {
+				return -1
+			}
+			return 1
+		case (xerr != nil || yerr != nil) && x != y:
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// SetVerboseHelp sets whether help and usage messages are verbose. Verbose
+// help shows the releases flags were added and deprecated in, and lists the
+// deprecated flags, which are otherwise left out, so that the help of a
+// binary tells which flags it supports.
+func (f *FlagSet) SetVerboseHelp(verbose bool) {
+	f.verboseHelp = verbose
+}
+
+// versions returns the rendering of the releases flag was added and
+// deprecated in, or the empty string if help isn't verbose.
+func (f *FlagSet) versions(flag *Flag) string {
+	if !f.verboseHelp {
+		return ""
+	}
+	var s string
+	if flag.AddedIn != "" {
+		s = fmt.Sprintf(f.msg(MsgAddedIn), flag.AddedIn)
+	}
+	if flag.Deprecated != "" {
+		if s != "" {
+			s += " "
+		}
+		if flag.DeprecatedIn != "" {
+			s += fmt.Sprintf(f.msg(MsgDeprecatedIn), flag.DeprecatedIn)
+		} else {
+			s += f.msg(MsgDeprecatedFlag)
+		}
+		if flag.RemovalAfter != "" {
+			s += " " + fmt.Sprintf(f.msg(MsgRemovalAfter), flag.RemovalAfter)
+		}
+	}
+	return s
+}
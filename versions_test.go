@@ -0,0 +1,93 @@
+package pflag
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerboseHelp(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.String("format", "", "output format")
+	f.Int("retries", 0, "number of retries")
+	f.Bool("legacy", false, "legacy mode")
+	f.SetAddedIn("format", "v1.2")
+	f.SetAddedIn("retries", "v1.0")
+	if err := f.MarkDeprecatedIn("retries", "v2.0", "use --attempts"); err != nil {
+		t.Fatal(err)
+	}
+	f.MarkDeprecated("legacy", "it does nothing")
+
+	usages := f.FlagUsages()
+	if strings.Contains(usages, "added in") || strings.Contains(usages, "retries") {
+		t.Errorf("versions and deprecated flags shown without verbose help:\n%s", usages)
+	}
+
+	f.SetVerboseHelp(true)
+	usages = f.FlagUsages()
+	for _, want := range []string{
+		"output format (added in v1.2)\n",
+		"number of retries (added in v1.0) (deprecated in v2.0)\n",
+		"legacy mode (deprecated)\n",
+	} {
+		if !strings.Contains(usages, want) {
+			t.Errorf("expected %q in:\n%s", want, usages)
+		}
+	}
+}
+
+func TestMarkDeprecatedUntil(t *testing.T) {
+	defer func(now func() time.Time) { timeNow = now }(timeNow)
+	timeNow = func() time.Time { return time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC) }
+
+	var out bytes.Buffer
+	f := NewFlagSet("test", ContinueOnError)
+	f.SetOutput(&out)
+	f.Int("retries", 0, "number of retries")
+	f.Bool("legacy", false, "legacy mode")
+	f.Bool("old", false, "old mode")
+	if err := f.MarkDeprecatedUntil("retries", "v2.0", "use --attempts"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.MarkDeprecatedUntil("legacy", "2026-06-01", "it does nothing"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.MarkDeprecatedUntil("old", "2026-05-31", "use --new"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without a release, scheduled removals only warn.
+	if err := f.Parse([]string{"--retries=3", "--legacy"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "Flag --retries has been deprecated and will be removed after v2.0, use --attempts\n"; !strings.Contains(out.String(), want) {
+		t.Errorf("expected %q in %q", want, out.String())
+	}
+	err := f.Parse([]string{"--old"})
+	if want := "flag --old was removed after 2026-05-31, use --new"; err == nil || err.Error() != want {
+		t.Errorf("got error %v, want %s", err, want)
+	}
+
+	for _, test := range []struct {
+		release string
+		removed bool
+	}{
+		{"v1.9.9", false},
+		{"2.0", false},
+		{"v2.0.0", false},
+		{"v2.0.1", true},
+		{"v10.0", true},
+	} {
+		f.SetRelease(test.release)
+		err := f.Set("retries", "1")
+		if removed := err != nil; removed != test.removed {
+			t.Errorf("release %s: got error %v, want removed %v", test.release, err, test.removed)
+		}
+	}
+
+	f.SetVerboseHelp(true)
+	if want := "number of retries (deprecated) (to be removed after v2.0)\n"; !strings.Contains(f.FlagUsages(), want) {
+		t.Errorf("expected %q in:\n%s", want, f.FlagUsages())
+	}
+}
@@ -0,0 +1,36 @@
+package pflag
+
+// FlagsWhere returns the flags of f for which pred returns true, in VisitAll
+// order. The predicates below can be used, or combined, as pred.
+func (f *FlagSet) FlagsWhere(pred func(*Flag) bool) []*Flag {
+	var flags []*Flag
+	f.VisitAll(func(flag *Flag) {
+		if pred(flag) {
+			flags = append(flags, flag)
+		}
+	})
+	return flags
+}
+
+// Changed reports whether flag has been set, see Flag.Changed.
+func Changed(flag *Flag) bool {
+	return flag.Changed
+}
+
+// Hidden reports whether flag is hidden from help and usage messages.
+func Hidden(flag *Flag) bool {
+	return flag.Hidden
+}
+
+// Deprecated reports whether flag is deprecated.
+func Deprecated(flag *Flag) bool {
+	return flag.Deprecated != ""
+}
+
+// InGroup returns a predicate reporting whether a flag belongs to group, see
+// SetGroup. Flags not belonging to any group are in the group "".
+func InGroup(group string) func(*Flag) bool {
+	return func(flag *Flag) bool {
+		return flag.Group == group
+	}
+}
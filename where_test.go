@@ -0,0 +1,46 @@
+package pflag
+
+import (
+	"reflect"
+	"testing"
+)
+
+func flagNames(flags []*Flag) []string {
+	var names []string
+	for _, flag := range flags {
+		names = append(names, flag.Name)
+	}
+	return names
+}
+
+func TestFlagsWhere(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.Bool("b", false, "")
+	f.Int("a", 0, "")
+	f.String("c", "", "")
+	f.String("old", "", "")
+	f.String("secret", "", "")
+	f.MarkDeprecated("old", "use c")
+	f.MarkHidden("secret")
+	f.SetGroup("network", "a", "c")
+	if err := f.Parse([]string{"--c", "x", "--b"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		pred func(*Flag) bool
+		want []string
+	}{
+		{"Changed", Changed, []string{"b", "c"}},
+		{"Hidden", Hidden, []string{"secret"}},
+		{"Deprecated", Deprecated, []string{"old"}},
+		{"InGroup", InGroup("network"), []string{"a", "c"}},
+		{"InGroup empty", InGroup(""), []string{"b", "old", "secret"}},
+	}
+	for _, test := range tests {
+		if got := flagNames(f.FlagsWhere(test.pred)); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package pflag
+
+import "fmt"
+
+// WrapValue replaces the Value of the named flag by the one mw returns for
+// it, so that behaviors can be layered onto a flag already defined without
+// defining it again with a custom type:
+//
+//	f.WrapValue("name", pflag.TransformSet(strings.TrimSpace))
+//
+// The flag keeps its name, default value, state and options. The Value
+// returned by mw replaces the flag's Value for all purposes, so optional
+// interfaces such as SliceValue are only available if it implements them
+// too. Wrapping a flag several times applies the last middleware first.
+// Middlewares may give access to the Value they wrap with an Unwrap() Value
+// method, like the one of TransformSet, so that Reset can restore slices.
+func (f *FlagSet) WrapValue(name string, mw func(Value) Value) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	v := mw(flag.Value)
+	if v == nil {
+		return fmt.Errorf("flag %q: no value to wrap it with", name)
+	}
+	flag.Value = v
+	return nil
+}
+
+// TransformSet returns a middleware for WrapValue which passes the values the
+// flag is set to through fn before setting them, e.g. strings.TrimSpace,
+// strings.ToLower or os.ExpandEnv.
+func TransformSet(fn func(string) string) func(Value) Value {
+	return func(v Value) Value {
+		return &transformValue{Value: v, fn: fn}
+	}
+}
+
+// transformValue is a Value whose values are passed through fn, see
+// TransformSet.
+type transformValue struct {
+	Value
+	fn func(string) string
+}
+
+func (t *transformValue) Set(s string) error {
+	return t.Value.Set(t.fn(s))
+}
+
+// Unwrap returns the wrapped Value.
+func (t *transformValue) Unwrap() Value {
+	return t.Value
+}
+
+// unwrapValue returns the Value v wraps, through all the middlewares which
+// have an Unwrap method, or v itself.
+func unwrapValue(v Value) Value {
+	for {
+		u, ok := v.(interface{ Unwrap() Value })
+		if !ok {
+			return v
+		}
+		v = u.Unwrap()
+	}
+}
@@ -0,0 +1,68 @@
+package pflag
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWrapValue(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	name := f.String("name", "", "")
+	port := f.Int("port", 80, "")
+	if err := f.WrapValue("name", TransformSet(strings.ToLower)); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.WrapValue("name", TransformSet(strings.TrimSpace)); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.WrapValue("port", TransformSet(strings.TrimSpace)); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.WrapValue("missing", TransformSet(strings.TrimSpace)); err == nil {
+		t.Error("expected an error for a missing flag")
+	}
+	if err := f.WrapValue("name", func(Value) Value { return nil }); err == nil {
+		t.Error("expected an error for a nil value")
+	}
+
+	if err := f.Parse([]string{"--name", "  Alice ", "--port= 8080"}); err != nil {
+		t.Fatal(err)
+	}
+	if *name != "alice" || *port != 8080 {
+		t.Errorf("got name %q port %d", *name, *port)
+	}
+	if got, err := f.GetInt("port"); err != nil || got != 8080 {
+		t.Errorf("got %d, %v", got, err)
+	}
+	if !f.Changed("name") || f.Lookup("port").DefValue != "80" {
+		t.Error("wrapping lost the state of the flags")
+	}
+
+	c, err := f.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Set("name", " Bob"); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Lookup("name").Value.String(); got != "bob" || *name != "alice" {
+		t.Errorf("got clone %q original %q", got, *name)
+	}
+
+	tags := f.StringSlice("tags", nil, "")
+	if err := f.WrapValue("tags", TransformSet(strings.ToLower)); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Set("tags", "A,B"); err != nil {
+		t.Fatal(err)
+	}
+	args := f.ToArgs(true)
+	if want := "--tags=a,b"; args[len(args)-1] != want {
+		t.Errorf("got args %q want %q last", args, want)
+	}
+	*tags = nil
+	if err := f.Parse(args); err != nil || !reflect.DeepEqual(*tags, []string{"a", "b"}) {
+		t.Errorf("got tags %q, %v", *tags, err)
+	}
+}